@@ -2,10 +2,14 @@
 索引管理 API 端点
 """
 import asyncio
+import hashlib
+import ipaddress
 import logging
+import socket
 import time
 from datetime import datetime
-from typing import Dict, List, Optional
+from typing import Dict, List, Optional, Union
+from urllib.parse import urlsplit, urlunsplit
 
 import httpx
 from fastapi import APIRouter, BackgroundTasks, HTTPException
@@ -41,9 +45,16 @@ def get_index_manager() -> IndexManager:
 
 # ==================== 请求/响应模型 ====================
 
+# 索引操作：upsert 无论是否已索引都索引；create 只索引尚未索引的 memo；replace 只替换已索引的 memo；
+# refresh 丢弃 memo 的旧向量后重新索引。除 refresh 外，内容哈希与已索引的相同时跳过重新嵌入
+INDEX_OPERATIONS = ("upsert", "create", "replace", "refresh")
+
+
 class IndexMemoRequest(BaseModel):
     memo: dict
     operation: str = "upsert"
+    # memos 服务器计算的 memo 哈希，索引成功后记录，并由 GET /internal/index/memo/{memo_uid} 返回
+    content_hash: Optional[str] = None
 
 
 class IndexMemoResponse(BaseModel):
@@ -61,6 +72,18 @@ class DeleteMemoResponse(BaseModel):
     image_vectors_removed: int
 
 
+class DeleteCreatorResponse(BaseModel):
+    creator: str
+    memos_deleted: int
+    text_vectors_removed: int
+    image_vectors_removed: int
+
+
+class ListIndexedMemosResponse(BaseModel):
+    memo_uids: List[str]
+    next_page_token: str = ""
+
+
 class IndexStatusResponse(BaseModel):
     total_memos: int
     total_text_vectors: int
@@ -71,6 +94,12 @@ class IndexStatusResponse(BaseModel):
 
 class RebuildIndexRequest(BaseModel):
     creator: str  # 用户标识，如 "users/1"
+    # 重建结束（completed/failed/cancelled）后 POST 最终状态的地址，由 memos 服务器校验
+    callback_url: Optional[str] = None
+    # 为 True 时跳过公开（PUBLIC）的 memo，对应实例 AI 设置 exclude_public_memos
+    exclude_public: bool = False
+    # 为 True 时允许回调到回环和私有地址，对应实例 AI 设置 allow_internal_callback_urls
+    allow_internal_callback: bool = False
 
 
 class RebuildIndexResponse(BaseModel):
@@ -80,6 +109,9 @@ class RebuildIndexResponse(BaseModel):
     timestamp: str
 
 
+# 列出已索引 memo 时每页的 memo 数量
+INDEXED_MEMOS_PAGE_SIZE = 100
+
 # 重建任务状态追踪
 _rebuild_tasks: Dict[str, dict] = {}
 
@@ -91,8 +123,8 @@ def get_rebuild_status(creator: str) -> Optional[dict]:
 
 # ==================== 辅助函数 ====================
 
-async def fetch_user_memos(creator: str) -> List[dict]:
-    """从 memos 服务器获取用户的所有 memo"""
+async def fetch_user_memos(creator: str, exclude_public: bool = False) -> List[dict]:
+    """从 memos 服务器获取用户的所有 memo，exclude_public 为 True 时跳过公开的 memo"""
     memos_base_url = settings.memos_base_url
     session_cookie = settings.memos_session_cookie
 
@@ -117,8 +149,11 @@ async def fetch_user_memos(creator: str) -> List[dict]:
             memos = data.get("memos", [])
             # 按 creator 过滤
             for memo in memos:
-                if memo.get("creator") == creator:
-                    all_memos.append(memo)
+                if memo.get("creator") != creator:
+                    continue
+                if exclude_public and memo.get("visibility") == "PUBLIC":
+                    continue
+                all_memos.append(memo)
 
             page_token = data.get("nextPageToken", "")
             if not page_token:
@@ -127,8 +162,102 @@ async def fetch_user_memos(creator: str) -> List[dict]:
     return all_memos
 
 
-async def process_rebuild_index(creator: str):
+# 云服务元数据地址，与 memos 服务器 server/ai/serviceurl.go 中的列表一致
+CALLBACK_METADATA_HOSTS = ("metadata", "metadata.google.internal", "metadata.goog")
+CALLBACK_METADATA_IPS = (ipaddress.ip_address("fd00:ec2::254"),)
+
+# 与 Go net.IP.IsPrivate 相同的私有地址段（Python 的 is_private 还包含文档地址等）
+CALLBACK_PRIVATE_NETWORKS = (
+    ipaddress.ip_network("10.0.0.0/8"),
+    ipaddress.ip_network("172.16.0.0/12"),
+    ipaddress.ip_network("192.168.0.0/16"),
+    ipaddress.ip_network("fc00::/7"),
+)
+
+
+def check_callback_ip(ip: Union[ipaddress.IPv4Address, ipaddress.IPv6Address], allow_internal: bool):
+    """拒绝链路本地、未指定、组播和元数据地址；allow_internal 为 False 时还拒绝回环和私有地址"""
+    if isinstance(ip, ipaddress.IPv6Address) and ip.ipv4_mapped is not None:
+        ip = ip.ipv4_mapped
+    if ip.is_link_local or ip.is_unspecified or ip.is_multicast or ip in CALLBACK_METADATA_IPS:
+        raise ValueError(f"address {ip} is not allowed")
+    if not allow_internal and (ip.is_loopback or any(ip in network for network in CALLBACK_PRIVATE_NETWORKS)):
+        raise ValueError(f"internal address {ip} is not allowed")
+
+
+async def resolve_callback_url(callback_url: str, allow_internal: bool) -> tuple:
+    """解析回调地址的主机并校验所有解析结果，返回连接用的 URL、Host 头和 TLS SNI 主机名
+
+    memos 服务器接收请求时已校验过回调地址，但 DNS 的解析结果可能在回调发送前改变（DNS 重绑定），
+    所以发送前重新解析并校验，并直接连接校验过的 IP，不再让 httpx 重新解析。
+    """
+    parts = urlsplit(callback_url)
+    if parts.scheme not in ("http", "https"):
+        raise ValueError(f"scheme {parts.scheme!r} is not allowed")
+    host = (parts.hostname or "").rstrip(".").lower()
+    if not host:
+        raise ValueError("empty hostname")
+    if parts.username is not None or parts.password is not None:
+        raise ValueError("credentials are not allowed")
+    if host in CALLBACK_METADATA_HOSTS:
+        raise ValueError(f"metadata service host {host} is not allowed")
+    port = parts.port or (443 if parts.scheme == "https" else 80)
+
+    loop = asyncio.get_running_loop()
+    infos = await loop.getaddrinfo(host, port, type=socket.SOCK_STREAM)
+    ips = [ipaddress.ip_address(info[4][0].split("%", 1)[0]) for info in infos]
+    if not ips:
+        raise ValueError(f"failed to resolve hostname {host}")
+    for ip in ips:
+        check_callback_ip(ip, allow_internal)
+
+    ip = ips[0]
+    ip_host = f"[{ip}]" if ip.version == 6 else str(ip)
+    pinned_url = urlunsplit((parts.scheme, f"{ip_host}:{port}", parts.path, parts.query, ""))
+    host_header = parts.netloc.rsplit("@", 1)[-1]
+    return pinned_url, host_header, host
+
+
+async def notify_rebuild_callback(creator: str, callback_url: str, task_status: dict, allow_internal: bool = False):
+    """将重建任务的最终状态 POST 到回调地址
+
+    请求体与 GET /internal/index/rebuild/{creator} 返回的状态相同，另加 creator 字段。
+    回调地址解析到不允许的地址时不发送。回调失败只记录日志，不影响任务状态。
+    """
+    payload = {"creator": creator, **task_status}
+    try:
+        pinned_url, host_header, sni_hostname = await resolve_callback_url(callback_url, allow_internal)
+        async with httpx.AsyncClient(timeout=10.0, follow_redirects=False) as client:
+            response = await client.post(
+                pinned_url,
+                json=payload,
+                headers={"Host": host_header},
+                extensions={"sni_hostname": sni_hostname},
+            )
+            response.raise_for_status()
+        logger.info(f"[Rebuild] Callback sent for {creator}: {task_status['status']}")
+    except Exception as e:
+        logger.warning(f"[Rebuild] Callback failed for {creator}: {e}")
+
+
+async def process_rebuild_index(
+    creator: str,
+    callback_url: Optional[str] = None,
+    exclude_public: bool = False,
+    allow_internal_callback: bool = False,
+):
     """后台任务：重建用户的所有索引"""
+    try:
+        await run_rebuild_index(creator, exclude_public)
+    finally:
+        if callback_url:
+            await notify_rebuild_callback(
+                creator, callback_url, _rebuild_tasks.get(creator, {}), allow_internal_callback
+            )
+
+
+async def run_rebuild_index(creator: str, exclude_public: bool = False):
+    """重建用户的所有索引，状态记录在 _rebuild_tasks 中"""
     task_status = _rebuild_tasks.get(creator, {})
     task_status.update({
         "status": "running",
@@ -141,11 +270,15 @@ async def process_rebuild_index(creator: str):
 
     try:
         logger.info(f"[Rebuild] Fetching memos for {creator}")
-        memos = await fetch_user_memos(creator)
+        memos = await fetch_user_memos(creator, exclude_public)
         task_status["total"] = len(memos)
         logger.info(f"[Rebuild] Found {len(memos)} memos for {creator}")
 
         for i, memo_dict in enumerate(memos):
+            # 取消请求在处理下一条 memo 前生效
+            if task_status["status"] == "cancelled":
+                logger.info(f"[Rebuild] Cancelled for {creator}: {task_status['completed']}/{task_status['total']} memos indexed")
+                return
             try:
                 memo_uid = memo_dict.get("name", "unknown")
                 logger.info(f"[Rebuild] [{i+1}/{len(memos)}] Processing: {memo_uid}")
@@ -157,6 +290,8 @@ async def process_rebuild_index(creator: str):
                 logger.error(f"[Rebuild] Failed to index memo: {e}")
                 task_status["failed"] += 1
 
+        if task_status["status"] == "cancelled":
+            return
         task_status["status"] = "completed"
         task_status["finished_at"] = datetime.utcnow().isoformat() + "Z"
         logger.info(f"[Rebuild] Completed for {creator}: {task_status['completed']}/{task_status['total']} memos indexed")
@@ -220,18 +355,31 @@ async def load_memo_with_async_captions(memo: Memo) -> MemoMultimodalDocs:
         return load_memo_to_llama_docs(memo, image_caption_fn=None, settings=settings)
 
 
-async def process_index_memo(memo_dict: dict):
-    """后台任务：索引Memo"""
+async def process_index_memo(memo_dict: dict, content_hash: Optional[str] = None, operation: str = "upsert"):
+    """后台任务：索引Memo
+
+    content_hash 与已索引的哈希相同时跳过，operation 为 refresh 时总是重新嵌入。
+    """
     try:
         memo = Memo.model_validate(memo_dict)
         memo_uid = memo.name
+        manager = get_index_manager()
+
+        indexed_hash = manager.memo_vector_map.get(memo_uid, {}).get("content_hash")
+        if operation != "refresh" and content_hash and content_hash == indexed_hash:
+            logger.info(f"[Index] Unchanged, skipped: {memo_uid}")
+            return
 
         logger.info(f"[Index] Processing: {memo_uid}")
         start_time = time.time()
 
         docs = await load_memo_with_async_captions(memo)
-        manager = get_index_manager()
-        text_count, image_count = manager.add_or_update_memo(docs)
+        memo_content_hash = hashlib.sha256((memo.content or "").encode("utf-8")).hexdigest()
+        text_count, image_count = manager.add_or_update_memo(
+            docs,
+            content_hash=content_hash,
+            memo_content_hash=memo_content_hash,
+        )
 
         elapsed = time.time() - start_time
         logger.info(f"[Index] Completed {memo_uid}: text={text_count}, image={image_count}, time={elapsed:.2f}s")
@@ -262,9 +410,21 @@ async def index_memo(
     request: IndexMemoRequest,
     background_tasks: BackgroundTasks,
 ):
-    """索引或更新Memo（异步处理）"""
+    """索引或更新Memo（异步处理）
+
+    create 已索引的 memo 返回 409，replace 未索引的 memo 返回 404，均不排队。
+    """
     memo_uid = request.memo.get("name", "unknown")
-    background_tasks.add_task(process_index_memo, request.memo)
+    if request.operation not in INDEX_OPERATIONS:
+        raise HTTPException(status_code=400, detail=f"Invalid operation {request.operation!r}")
+
+    indexed = memo_uid in get_index_manager().memo_vector_map
+    if request.operation == "create" and indexed:
+        raise HTTPException(status_code=409, detail=f"Memo {memo_uid} already indexed")
+    if request.operation == "replace" and not indexed:
+        raise HTTPException(status_code=404, detail=f"Memo {memo_uid} not indexed")
+
+    background_tasks.add_task(process_index_memo, request.memo, request.content_hash, request.operation)
 
     return IndexMemoResponse(
         memo_uid=memo_uid,
@@ -290,6 +450,76 @@ async def delete_memo_index(memo_uid: str):
         raise HTTPException(status_code=500, detail=str(e))
 
 
+@router.delete("/chunks/{memo_uid}/{doc_id:path}", status_code=204)
+async def delete_memo_chunk(memo_uid: str, doc_id: str):
+    """删除 Memo 的单个索引块
+
+    memo_uid 为 memo 的 UID（如 "abc123"，不带 "memos/" 前缀）；
+    doc_id 为 GET /internal/index/memo/{memo_uid} 返回的文档 ID 或向量 ID。
+    """
+    memo_name = f"memos/{memo_uid}"
+    try:
+        deleted = get_index_manager().delete_chunk(memo_name, doc_id)
+    except Exception as e:
+        raise HTTPException(status_code=500, detail=str(e))
+    if not deleted:
+        raise HTTPException(status_code=404, detail=f"Chunk {doc_id} of {memo_name} not indexed")
+
+
+@router.get("/creator/{creator:path}/memos", response_model=ListIndexedMemosResponse)
+async def list_indexed_memos(creator: str, page_token: str = ""):
+    """分页列出用户已索引 memo 的 UID（不带 "memos/" 前缀），按 UID 排序
+
+    page_token 为上一页返回的 next_page_token，即上一页最后一个 UID；最后一页返回空的 next_page_token。
+    """
+    try:
+        memo_uids = [
+            memo_uid.removeprefix("memos/")
+            for memo_uid in get_index_manager().get_creator_memo_uids(creator)
+        ]
+    except Exception as e:
+        raise HTTPException(status_code=500, detail=str(e))
+
+    memo_uids = sorted(uid for uid in memo_uids if uid > page_token)
+    page = memo_uids[:INDEXED_MEMOS_PAGE_SIZE]
+    next_page_token = page[-1] if len(memo_uids) > len(page) else ""
+    return ListIndexedMemosResponse(memo_uids=page, next_page_token=next_page_token)
+
+
+@router.delete("/creator/{creator:path}", response_model=DeleteCreatorResponse)
+async def delete_creator_index(creator: str):
+    """删除用户所有 memo 的索引
+
+    creator 为用户标识，如 "users/1"。用户没有已索引的 memo 时也返回成功。
+    """
+    try:
+        manager = get_index_manager()
+        memos_deleted, text_deleted, image_deleted = manager.delete_creator(creator)
+        logger.info(f"[Index] Deleted {memos_deleted} memos of {creator}")
+
+        return DeleteCreatorResponse(
+            creator=creator,
+            memos_deleted=memos_deleted,
+            text_vectors_removed=text_deleted,
+            image_vectors_removed=image_deleted,
+        )
+    except Exception as e:
+        raise HTTPException(status_code=500, detail=str(e))
+
+
+@router.get("/checksum/{creator:path}")
+async def get_index_checksum(creator: str):
+    """获取用户已索引 memo 的校验和
+
+    校验和为按 UID 排序的每个 memo 一行 "{uid}:{content_hash}\n" 的 SHA-256，
+    content_hash 为索引时 memo 正文的 SHA-256，与 memos 服务器的 ai.IndexChecksum 一致。
+    """
+    try:
+        return get_index_manager().get_index_checksum(creator)
+    except Exception as e:
+        raise HTTPException(status_code=500, detail=str(e))
+
+
 @router.get("/memo/{memo_uid:path}")
 async def get_memo_index_info(memo_uid: str, include_detail: bool = False):
     """获取Memo的索引信息
@@ -328,7 +558,13 @@ async def rebuild_user_index(
         )
 
     # 启动后台任务
-    background_tasks.add_task(process_rebuild_index, creator)
+    background_tasks.add_task(
+        process_rebuild_index,
+        creator,
+        request.callback_url,
+        request.exclude_public,
+        request.allow_internal_callback,
+    )
 
     return RebuildIndexResponse(
         creator=creator,
@@ -345,3 +581,16 @@ async def get_rebuild_task_status(creator: str):
     if status is None:
         raise HTTPException(status_code=404, detail=f"No rebuild task found for {creator}")
     return status
+
+
+@router.delete("/rebuild/{creator:path}", status_code=204)
+async def cancel_rebuild_task(creator: str):
+    """取消正在运行的重建任务
+
+    幂等：任务不存在或已结束时也返回成功。
+    """
+    task_status = get_rebuild_status(creator)
+    if task_status and task_status.get("status") == "running":
+        task_status["status"] = "cancelled"
+        task_status["finished_at"] = datetime.utcnow().isoformat() + "Z"
+        logger.info(f"[Rebuild] Cancel requested for {creator}")
@@ -6,7 +6,8 @@
 import logging
 from typing import List, Optional
 
-from fastapi import APIRouter, HTTPException
+from fastapi import APIRouter, HTTPException, Query
+from fastapi.responses import StreamingResponse
 from pydantic import BaseModel, Field
 
 from ai_parts.indexing.index_manager import IndexManager
@@ -20,6 +21,10 @@ from ai_parts.retrieval import (
 logger = logging.getLogger(__name__)
 
 router = APIRouter(prefix="/internal/search", tags=["search"])
+similar_router = APIRouter(prefix="/internal/similar", tags=["search"])
+
+# 相似 memo 检索时，用作查询的 memo 文本的最大长度
+MAX_SIMILAR_QUERY_CHARS = 2000
 
 # 全局索引管理器引用（由主应用注入）
 _index_manager: Optional[IndexManager] = None
@@ -44,6 +49,7 @@ def get_index_manager() -> IndexManager:
 class SearchRequest(BaseModel):
     query: str = Field(description="搜索查询文本")
     top_k: int = Field(default=10, ge=1, le=100, description="返回结果数量")
+    offset: int = Field(default=0, ge=0, le=900, description="跳过排名靠前的结果数量，用于分页")
     search_mode: str = Field(
         default="hybrid",
         description=(
@@ -56,6 +62,10 @@ class SearchRequest(BaseModel):
         default=None,
         description="用户过滤，格式如 users/1",
     )
+    visibility: Optional[str] = Field(
+        default=None,
+        description="可见性过滤，如 PUBLIC",
+    )
     # 策略特定参数
     rrf_k: int = Field(default=60, description="RRF 常数 k（rrf, bm25_vector 策略）")
     text_weight: float = Field(default=0.7, description="文本权重（weighted 策略）")
@@ -87,6 +97,11 @@ class SearchResponse(BaseModel):
     total: int
 
 
+class SimilarResponse(BaseModel):
+    memo_uid: str
+    results: List[SearchResult]
+
+
 class RetrieverInfo(BaseModel):
     name: str
     description: str
@@ -132,52 +147,135 @@ async def search_memos(request: SearchRequest):
     - adaptive: 自适应混合检索（根据查询特征动态调整权重）
     """
     try:
-        manager = get_index_manager()
-
-        # 检查策略是否存在
-        if not has_retriever(request.search_mode):
-            available = [r["name"] for r in list_retrievers()]
-            raise HTTPException(
-                status_code=400,
-                detail=f"Unknown search_mode: '{request.search_mode}'. Available: {available}",
-            )
+        results = run_search(request)
 
-        # 构建策略特定参数
-        retriever_kwargs = {"index_manager": manager}
-
-        if request.search_mode == "rrf":
-            retriever_kwargs["k"] = request.rrf_k
-        elif request.search_mode == "weighted":
-            retriever_kwargs["text_weight"] = request.text_weight
-            retriever_kwargs["image_weight"] = request.image_weight
-        elif request.search_mode == "bm25_vector":
-            retriever_kwargs["rrf_k"] = request.rrf_k
-            retriever_kwargs["bm25_weight"] = request.bm25_weight
-            retriever_kwargs["vector_weight"] = request.vector_weight
-        elif request.search_mode == "bm25_vector_alpha":
-            retriever_kwargs["alpha"] = request.alpha
-        elif request.search_mode == "adaptive":
-            retriever_kwargs["base_alpha"] = request.alpha
-
-        # 获取检索器
-        retriever = get_retriever(request.search_mode, **retriever_kwargs)
-
-        # 构建查询
-        filters = None
-        if request.creator:
-            filters = {"creator": request.creator}
-
-        query = RetrievalQuery(
+        return SearchResponse(
             query=request.query,
-            top_k=request.top_k,
-            min_score=request.min_score,
-            filters=filters,
+            search_mode=request.search_mode,
+            results=results,
+            total=len(results),
+        )
+
+    except HTTPException:
+        raise
+    except Exception as e:
+        logger.error(f"Search error: {e}", exc_info=True)
+        raise HTTPException(status_code=500, detail=str(e))
+
+
+@router.post("/stream")
+async def search_memos_stream(request: SearchRequest):
+    """
+    流式语义搜索 Memo
+
+    检索策略与 POST /internal/search 相同，结果以 NDJSON 返回，每行一个 SearchResult。
+    检索在响应开始前完成，因此错误仍以状态码返回。
+    """
+    try:
+        results = run_search(request)
+    except HTTPException:
+        raise
+    except Exception as e:
+        logger.error(f"Search stream error: {e}", exc_info=True)
+        raise HTTPException(status_code=500, detail=str(e))
+
+    def generate():
+        for result in results:
+            yield result.model_dump_json() + "\n"
+
+    return StreamingResponse(generate(), media_type="application/x-ndjson")
+
+
+def run_search(request: SearchRequest) -> List[SearchResult]:
+    """按请求的检索策略执行检索，未知策略抛出 400"""
+    manager = get_index_manager()
+
+    # 检查策略是否存在
+    if not has_retriever(request.search_mode):
+        available = [r["name"] for r in list_retrievers()]
+        raise HTTPException(
+            status_code=400,
+            detail=f"Unknown search_mode: '{request.search_mode}'. Available: {available}",
         )
 
-        # 执行检索
-        retrieval_results = retriever.retrieve(query)
+    # 构建策略特定参数
+    retriever_kwargs = {"index_manager": manager}
+
+    if request.search_mode == "rrf":
+        retriever_kwargs["k"] = request.rrf_k
+    elif request.search_mode == "weighted":
+        retriever_kwargs["text_weight"] = request.text_weight
+        retriever_kwargs["image_weight"] = request.image_weight
+    elif request.search_mode == "bm25_vector":
+        retriever_kwargs["rrf_k"] = request.rrf_k
+        retriever_kwargs["bm25_weight"] = request.bm25_weight
+        retriever_kwargs["vector_weight"] = request.vector_weight
+    elif request.search_mode == "bm25_vector_alpha":
+        retriever_kwargs["alpha"] = request.alpha
+    elif request.search_mode == "adaptive":
+        retriever_kwargs["base_alpha"] = request.alpha
+
+    # 获取检索器
+    retriever = get_retriever(request.search_mode, **retriever_kwargs)
+
+    # 构建查询
+    filters = {}
+    if request.creator:
+        filters["creator"] = request.creator
+    if request.visibility:
+        filters["visibility"] = request.visibility
+    filters = filters or None
+
+    # 检索 offset + top_k 条结果，再跳过前 offset 条，使分页的各页互不重叠
+    query = RetrievalQuery(
+        query=request.query,
+        top_k=request.offset + request.top_k,
+        min_score=request.min_score,
+        filters=filters,
+    )
+
+    # 执行检索
+    retrieval_results = retriever.retrieve(query)[request.offset:]
+
+    # 转换为响应格式
+    results = [
+        SearchResult(
+            memo_uid=r.memo_uid,
+            memo_name=r.memo_uid,
+            score=r.score,
+            content=r.content,
+            metadata=r.metadata,
+            source=r.source,
+        )
+        for r in retrieval_results
+    ]
+    return results
+
+
+@similar_router.get("/{memo_uid}", response_model=SimilarResponse)
+async def find_similar_memos(memo_uid: str, top_k: int = Query(default=10, ge=1, le=100)):
+    """
+    查找与 memo 相似的 memo
+
+    memo_uid 为 memo 的 UID（如 "abc123"，不带 "memos/" 前缀）。以 memo 已索引的正文为查询做文本向量检索，
+    结果不含该 memo 本身。
+    """
+    memo_name = f"memos/{memo_uid}"
+    try:
+        manager = get_index_manager()
+        text = manager.get_memo_text(memo_name)
+        if text is None:
+            raise HTTPException(status_code=404, detail=f"Memo {memo_name} not indexed")
+        if not text.strip():
+            return SimilarResponse(memo_uid=memo_name, results=[])
+
+        # 多取一条，以便去掉 memo 本身
+        retriever = get_retriever("text", index_manager=manager)
+        retrieval_results = retriever.retrieve(RetrievalQuery(
+            query=text[:MAX_SIMILAR_QUERY_CHARS],
+            top_k=top_k + 1,
+        ))
 
-        # 转换为响应格式
         results = [
             SearchResult(
                 memo_uid=r.memo_uid,
@@ -188,17 +286,12 @@ async def search_memos(request: SearchRequest):
                 source=r.source,
             )
             for r in retrieval_results
+            if r.memo_uid != memo_name
         ]
-
-        return SearchResponse(
-            query=request.query,
-            search_mode=request.search_mode,
-            results=results,
-            total=len(results),
-        )
+        return SimilarResponse(memo_uid=memo_name, results=results[:top_k])
 
     except HTTPException:
         raise
     except Exception as e:
-        logger.error(f"Search error: {e}", exc_info=True)
+        logger.error(f"Find similar error: {e}", exc_info=True)
         raise HTTPException(status_code=500, detail=str(e))
@@ -22,6 +22,8 @@ async def generate_tags(request: TagGenerationRequest):
             memo=request.memo,
             user_all_tags=request.user_all_tags,
             max_tags=request.max_tags,
+            exclude_tags=request.exclude_tags,
+            language=request.language,
         )
 
         existing_tags = request.memo.tags or []
@@ -2,6 +2,7 @@
 Index manager for incremental updates (add, update, delete).
 Manages persistent vector indexes for memos.
 """
+import hashlib
 import json
 from pathlib import Path
 from typing import Dict, List, Optional, Tuple
@@ -114,13 +115,20 @@ class IndexManager:
             encoding="utf-8",
         )
 
-    def add_or_update_memo(self, docs: MemoMultimodalDocs) -> Tuple[int, int]:
+    def add_or_update_memo(
+        self,
+        docs: MemoMultimodalDocs,
+        content_hash: Optional[str] = None,
+        memo_content_hash: Optional[str] = None,
+    ) -> Tuple[int, int]:
         """
         Add or update a memo in the indexes.
         If memo already exists, delete old vectors first.
 
         Args:
             docs: MemoMultimodalDocs from load_memo_to_llama_docs
+            content_hash: Hash of the memo computed by the memos server, reported by get_memo_info
+            memo_content_hash: Hex SHA-256 of the memo content, reported by get_index_checksum
 
         Returns:
             (text_vectors_added, image_vectors_added)
@@ -153,6 +161,13 @@ class IndexManager:
             "text": text_vector_ids,
             "image": image_vector_ids,
         }
+        creator = docs.base_doc.metadata.get("creator")
+        if creator:
+            self.memo_vector_map[memo_uid]["creator"] = creator
+        if content_hash:
+            self.memo_vector_map[memo_uid]["content_hash"] = content_hash
+        if memo_content_hash:
+            self.memo_vector_map[memo_uid]["memo_content_hash"] = memo_content_hash
         self._save_memo_vector_map()
 
         return len(text_vector_ids), len(image_vector_ids)
@@ -197,6 +212,118 @@ class IndexManager:
 
         return text_deleted, image_deleted
 
+    def delete_chunk(self, memo_uid: str, doc_id: str) -> bool:
+        """
+        Delete a single indexed chunk of a memo.
+
+        Args:
+            memo_uid: Memo UID (e.g., "memos/abc123")
+            doc_id: A document ID of the memo mapping, or a vector ID reported by
+                get_memo_info with include_detail
+
+        Returns:
+            Whether the chunk was found and deleted
+        """
+        if memo_uid not in self.memo_vector_map:
+            return False
+
+        mapping = self.memo_vector_map[memo_uid]
+        for kind, index in (("text", self.text_index), ("image", self.image_index)):
+            if index and doc_id in mapping.get(kind, []):
+                index.delete_ref_doc(doc_id, delete_from_docstore=True)
+                mapping[kind].remove(doc_id)
+                self._save_memo_vector_map()
+                return True
+
+        for persist_dir, collection_name in (
+            (self.text_persist_dir, self.text_collection),
+            (self.image_persist_dir, self.image_collection),
+        ):
+            chroma_client = PersistentClient(path=str(persist_dir))
+            collection = chroma_client.get_or_create_collection(name=collection_name)
+            results = collection.get(ids=[doc_id], where={"memo_uid": memo_uid}, include=[])
+            if results and results.get("ids"):
+                collection.delete(ids=[doc_id])
+                return True
+
+        return False
+
+    def get_memo_text(self, memo_uid: str) -> Optional[str]:
+        """Get the indexed text of a memo's content, or None if the memo isn't indexed."""
+        if memo_uid not in self.memo_vector_map:
+            return None
+
+        chroma_client = PersistentClient(path=str(self.text_persist_dir))
+        collection = chroma_client.get_or_create_collection(name=self.text_collection)
+        results = collection.get(where={"memo_uid": memo_uid}, include=["documents", "metadatas"])
+        chunks = []
+        for i, document in enumerate(results.get("documents") or []):
+            metadata = (results.get("metadatas") or [{}])[i] or {}
+            if metadata.get("source") == "memo" and document:
+                chunks.append(document)
+        return "\n".join(chunks)
+
+    def get_creator_memo_uids(self, creator: str) -> List[str]:
+        """
+        Get the UIDs of the indexed memos of a creator, sorted.
+
+        Memos indexed before the mapping recorded their creator are looked up
+        by the creator metadata of their text vectors.
+        """
+        memo_uids = {uid for uid, m in self.memo_vector_map.items() if m.get("creator") == creator}
+        if any("creator" not in m for m in self.memo_vector_map.values()):
+            try:
+                chroma_client = PersistentClient(path=str(self.text_persist_dir))
+                collection = chroma_client.get_or_create_collection(name=self.text_collection)
+                results = collection.get(where={"creator": creator}, include=["metadatas"])
+                for metadata in results.get("metadatas") or []:
+                    memo_uid = (metadata or {}).get("memo_uid")
+                    if memo_uid in self.memo_vector_map and "creator" not in self.memo_vector_map[memo_uid]:
+                        memo_uids.add(memo_uid)
+            except Exception as e:
+                print(f"Warning: Failed to get memos of {creator}: {e}")
+        return sorted(memo_uids)
+
+    def delete_creator(self, creator: str) -> Tuple[int, int, int]:
+        """
+        Delete all vectors of the memos of a creator.
+
+        Returns:
+            (memos_deleted, text_vectors_deleted, image_vectors_deleted)
+        """
+        memo_uids = self.get_creator_memo_uids(creator)
+        text_deleted, image_deleted = 0, 0
+        for memo_uid in memo_uids:
+            text_count, image_count = self.delete_memo(memo_uid)
+            text_deleted += text_count
+            image_deleted += image_count
+        return len(memo_uids), text_deleted, image_deleted
+
+    def get_index_checksum(self, creator: str) -> Dict:
+        """
+        Get the checksum of the indexed memos of a creator.
+
+        The checksum is the hex SHA-256 of one "{uid}:{memo_content_hash}\n" line per memo,
+        sorted by UID, where uid is the memo UID without the "memos/" prefix. Memos indexed
+        before their content hash was recorded are reported with an empty hash.
+        """
+        memos = []
+        for memo_uid in self.get_creator_memo_uids(creator):
+            memos.append({
+                "memo_uid": memo_uid.removeprefix("memos/"),
+                "content_hash": self.memo_vector_map[memo_uid].get("memo_content_hash", ""),
+            })
+        memos.sort(key=lambda m: m["memo_uid"])
+
+        checksum = hashlib.sha256()
+        for memo in memos:
+            checksum.update(f"{memo['memo_uid']}:{memo['content_hash']}\n".encode("utf-8"))
+        return {
+            "creator": creator,
+            "checksum": checksum.hexdigest(),
+            "memos": memos,
+        }
+
     def get_memo_info(self, memo_uid: str, include_detail: bool = False) -> Optional[Dict]:
         """Get indexing info for a specific memo.
 
@@ -216,6 +343,8 @@ class IndexManager:
             "text_count": len(mapping.get("text", [])),
             "image_count": len(mapping.get("image", [])),
         }
+        if mapping.get("content_hash"):
+            info["content_hash"] = mapping["content_hash"]
 
         if include_detail:
             info["detail"] = self._get_memo_detail(memo_uid, mapping)
@@ -235,6 +235,7 @@ def load_memo_to_llama_docs(
                 metadata={
                     "memo_uid": getattr(memo, "name", None),
                     "creator": getattr(memo, "creator", None),  # 用户过滤用
+                    "visibility": getattr(memo, "visibility", None),  # 可见性过滤用
                     "attachment_uid": getattr(att, "name", None),
                     "filename": getattr(att, "filename", None),
                     "type": getattr(att, "type", None),
@@ -258,6 +259,7 @@ def load_memo_to_llama_docs(
                 metadata={
                     "memo_uid": getattr(memo, "name", None),
                     "creator": getattr(memo, "creator", None),  # 用户过滤用
+                    "visibility": getattr(memo, "visibility", None),  # 可见性过滤用
                     "attachment_uid": getattr(att, "name", None),
                     "filename": getattr(att, "filename", None),
                     "type": getattr(att, "type", None),
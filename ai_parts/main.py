@@ -122,6 +122,7 @@ app.add_middleware(
 app.include_router(tags.router)
 app.include_router(indexing.router)
 app.include_router(search.router)
+app.include_router(search.similar_router)
 
 
 # ==================== 基础端点 ====================
@@ -144,6 +145,32 @@ async def health_check():
         "version": "1.0.0",
         "index_ready": index_ready,
         "total_indexed_memos": total_indexed,
+        # 可用模型，Go 端据此校验单次请求的模型覆盖
+        "models": {
+            "tag_generation": [settings.tag_generation_model],
+            "embedding": [settings.jina_text_model, settings.jina_image_model],
+        },
+    }
+
+
+@app.get("/api/v1/config")
+async def service_config():
+    """服务能力配置，Go 端据此告知前端支持的检索策略、图片索引与模型"""
+    try:
+        image_indexing = get_index_manager().image_index is not None
+    except Exception:
+        image_indexing = False
+
+    return {
+        "search_modes": [r["name"] for r in list_retrievers()],
+        "image_indexing": image_indexing,
+        "max_tags": settings.max_tags,
+        "models": {
+            "tag_generation": settings.tag_generation_model,
+            "text_embedding": settings.jina_text_model,
+            "image_embedding": settings.jina_image_model,
+            "image_caption": settings.image_caption_model,
+        },
     }
 
 
@@ -155,6 +182,7 @@ async def root():
         "version": "1.0.0",
         "endpoints": {
             "health": "/health",
+            "config": "/api/v1/config",
             "tags": "/api/v1/tags/generate",
             "search": "/internal/search",
             "index_status": "/internal/index/status",
@@ -53,6 +53,8 @@ class TagGenerationRequest(BaseModel):
     memo: Memo
     user_all_tags: List[str] = Field(default_factory=list, description="用户所有常用标签")
     max_tags: int = Field(default=5, ge=1, le=20, description="最多生成的标签数量")
+    exclude_tags: List[str] = Field(default_factory=list, description="不建议的标签（忽略大小写）")
+    language: Optional[str] = Field(default=None, description="生成标签的语言（BCP-47），auto 表示跟随备忘录语言")
 
 
 class TagGenerationResponse(BaseModel):
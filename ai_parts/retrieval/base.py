@@ -20,7 +20,7 @@ class RetrievalResult(BaseModel):
 class RetrievalQuery(BaseModel):
     """检索查询参数"""
     query: str = Field(description="查询文本")
-    top_k: int = Field(default=10, ge=1, le=100, description="返回结果数量")
+    top_k: int = Field(default=10, ge=1, le=1000, description="返回结果数量")
     min_score: float = Field(default=0.0, description="最低分数阈值")
     filters: Optional[Dict[str, Any]] = Field(default=None, description="过滤条件")
 
@@ -6,7 +6,7 @@
 """
 import re
 from functools import lru_cache
-from typing import List, Sequence
+from typing import List, Optional, Sequence
 
 from llama_index.core.base.llms.types import (
     ChatMessage,
@@ -110,13 +110,19 @@ def build_multimodal_content(
     return blocks
 
 
-def parse_tags_from_response(raw_text: str, existing_tags: List[str], max_tags: int) -> List[str]:
+def parse_tags_from_response(
+    raw_text: str,
+    existing_tags: List[str],
+    max_tags: int,
+    exclude_tags: Optional[List[str]] = None,
+) -> List[str]:
     """从 LLM 响应文本中解析标签列表。"""
     # 解析标签（支持中英文逗号分隔）
     candidates = [t.strip() for t in re.split(r"[，,]", raw_text) if t.strip()]
 
-    # 过滤已存在的标签
-    new_tags = [t for t in candidates if t not in existing_tags]
+    # 过滤已存在的标签和排除的标签（排除忽略大小写），在截断前过滤以免占用名额
+    excluded = {t.lower() for t in (exclude_tags or [])}
+    new_tags = [t for t in candidates if t not in existing_tags and t.lower() not in excluded]
 
     # 去重并保持顺序
     seen = set()
@@ -135,6 +141,8 @@ async def generate_tags_for_memo(
     memo: Memo,
     user_all_tags: List[str],
     max_tags: int = 5,
+    exclude_tags: Optional[List[str]] = None,
+    language: Optional[str] = None,
 ) -> List[str]:
     """
     异步为一条 memo 生成标签（llama_index 实现）。
@@ -143,6 +151,8 @@ async def generate_tags_for_memo(
         memo: 备忘录对象
         user_all_tags: 用户所有常用标签
         max_tags: 最多生成的标签数量
+        exclude_tags: 不建议的标签（忽略大小写）
+        language: 生成标签的语言（BCP-47），auto 表示跟随备忘录语言；为空时不限定
 
     Returns:
         只包含 AI 新建议的标签（不含 memo.tags）
@@ -154,7 +164,10 @@ async def generate_tags_for_memo(
     attachments = memo.attachments or []
 
     # 准备 prompt 变量
-    reuse_candidates = sorted(set(existing_tags + (user_all_tags or [])))
+    excluded = {t.lower() for t in (exclude_tags or [])}
+    reuse_candidates = sorted(
+        t for t in set(existing_tags + (user_all_tags or [])) if t.lower() not in excluded
+    )
     reuse_candidates_str = ", ".join(reuse_candidates) if reuse_candidates else "无"
 
     non_image_desc = build_non_image_attachment_description(
@@ -171,6 +184,12 @@ async def generate_tags_for_memo(
         max_tags=max_tags,
     )
 
+    # 语言提示附加在 prompt 末尾，未指定时 prompt 不变
+    if language == "auto":
+        prompt_text += "\n标签语言：使用与备忘录正文相同的语言。\n"
+    elif language:
+        prompt_text += f"\n标签语言：使用语言代码为 {language}（BCP-47）的语言输出标签。\n"
+
     # 提取图片 URL
     image_urls = extract_image_urls(attachments, settings.max_images)
 
@@ -194,4 +213,4 @@ async def generate_tags_for_memo(
     raw_text = (raw_text or "").strip()
 
     # 解析并返回标签
-    return parse_tags_from_response(raw_text, existing_tags, max_tags)
+    return parse_tags_from_response(raw_text, existing_tags, max_tags, exclude_tags)
@@ -34,6 +34,8 @@ var (
 				DSN:         viper.GetString("dsn"),
 				InstanceURL: viper.GetString("instance-url"),
 				Version:     version.GetCurrentVersion(viper.GetString("mode")),
+
+				UTF8SanitizeInterval: viper.GetDuration("utf8-sanitize-interval"),
 			}
 			if err := instanceProfile.Validate(); err != nil {
 				panic(err)
@@ -101,6 +103,7 @@ func init() {
 	rootCmd.PersistentFlags().String("driver", "sqlite", "database driver")
 	rootCmd.PersistentFlags().String("dsn", "", "database source name(aka. DSN)")
 	rootCmd.PersistentFlags().String("instance-url", "", "the url of your memos instance")
+	rootCmd.PersistentFlags().Duration("utf8-sanitize-interval", 0, "interval of the background sweep repairing invalid UTF-8 in stored text, 0 to disable")
 
 	if err := viper.BindPFlag("mode", rootCmd.PersistentFlags().Lookup("mode")); err != nil {
 		panic(err)
@@ -126,6 +129,9 @@ func init() {
 	if err := viper.BindPFlag("instance-url", rootCmd.PersistentFlags().Lookup("instance-url")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("utf8-sanitize-interval", rootCmd.PersistentFlags().Lookup("utf8-sanitize-interval")); err != nil {
+		panic(err)
+	}
 
 	viper.SetEnvPrefix("memos")
 	viper.AutomaticEnv()
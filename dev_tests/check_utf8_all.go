@@ -9,21 +9,64 @@ import (
 	"database/sql"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"unicode/utf8"
 
 	_ "modernc.org/sqlite"
+
+	"github.com/usememos/memos/internal/util"
 )
 
 var (
-	dbPath = flag.String("db", "memos_dev.db", "Database file path")
-	fix    = flag.Bool("fix", false, "Fix invalid UTF-8 (default is dry-run)")
+	dbPath  = flag.String("db", "memos_dev.db", "Database file path")
+	fix     = flag.Bool("fix", false, "Fix invalid UTF-8 (default is dry-run)")
+	tables  = flag.String("tables", "memo,attachment,reaction,activity", "Comma-separated list of tables to check")
+	workers = flag.Int("workers", 4, "Number of tables checked concurrently")
 )
 
+// progressInterval is the number of rows between progress logs.
+const progressInterval = 10000
+
+// fixRequest is an update queued for the single writer.
+type fixRequest struct {
+	label string
+	query string
+	args  []any
+}
+
+// tableChecker scans a table and queues the fixes for invalid rows.
+type tableChecker func(ctx context.Context, db *sql.DB, fixes chan<- fixRequest)
+
+var tableCheckers = map[string]tableChecker{
+	"memo":       checkMemoTable,
+	"attachment": checkAttachmentTable,
+	"reaction":   checkReactionTable,
+	"activity":   checkActivityTable,
+}
+
 func main() {
 	flag.Parse()
 
-	db, err := sql.Open("sqlite", *dbPath)
+	names := []string{}
+	for _, name := range strings.Split(*tables, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := tableCheckers[name]; !ok {
+			log.Fatalf("Unknown table: %s", name)
+		}
+		names = append(names, name)
+	}
+	if *workers < 1 {
+		log.Fatalf("Workers must be at least 1")
+	}
+
+	// WAL lets the readers keep scanning while the writer applies fixes.
+	db, err := sql.Open("sqlite", *dbPath+"?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)")
 	if err != nil {
 		log.Fatalf("Failed to open database: %v", err)
 	}
@@ -34,29 +77,64 @@ func main() {
 	}
 
 	log.Printf("Checking database: %s", *dbPath)
-	log.Printf("Fix mode: %v\n", *fix)
+	log.Printf("Fix mode: %v", *fix)
+	log.Printf("Tables: %s (%d workers)\n", strings.Join(names, ", "), *workers)
 
 	ctx := context.Background()
-	checkAllTables(ctx, db)
+	checkAllTables(ctx, db, names)
 }
 
-func checkAllTables(ctx context.Context, db *sql.DB) {
-	// Check memo table - all text columns
-	log.Println("\n=== Checking MEMO table ===")
-	checkMemoTable(ctx, db)
+// checkAllTables checks the named tables concurrently with a bounded worker pool.
+// Fixes are funneled to a single writer to avoid SQLite write contention.
+func checkAllTables(ctx context.Context, db *sql.DB, names []string) {
+	fixes := make(chan fixRequest, 100)
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		applyFixes(ctx, db, fixes)
+	}()
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				log.Printf("=== Checking %s table ===", strings.ToUpper(name))
+				tableCheckers[name](ctx, db, fixes)
+			}
+		}()
+	}
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
 
-	// Check other tables
-	log.Println("\n=== Checking ATTACHMENT table ===")
-	checkAttachmentTable(ctx, db)
+	close(fixes)
+	<-writerDone
+}
 
-	log.Println("\n=== Checking REACTION table ===")
-	checkReactionTable(ctx, db)
+// applyFixes runs the queued fixes one at a time.
+func applyFixes(ctx context.Context, db *sql.DB, fixes <-chan fixRequest) {
+	for f := range fixes {
+		if _, err := db.ExecContext(ctx, f.query, f.args...); err != nil {
+			log.Printf("   ERROR fixing %s: %v", f.label, err)
+		} else {
+			log.Printf("   ✓ Fixed %s", f.label)
+		}
+	}
+}
 
-	log.Println("\n=== Checking ACTIVITY table ===")
-	checkActivityTable(ctx, db)
+// logProgress logs the number of rows scanned every progressInterval rows.
+func logProgress(table string, total int) {
+	if total%progressInterval == 0 {
+		log.Printf("%s: %d rows scanned", table, total)
+	}
 }
 
-func checkMemoTable(ctx context.Context, db *sql.DB) {
+func checkMemoTable(ctx context.Context, db *sql.DB, fixes chan<- fixRequest) {
 	rows, err := db.QueryContext(ctx, `
 		SELECT id, uid, content, COALESCE(payload, ''), COALESCE(snippet, '')
 		FROM memo
@@ -80,6 +158,7 @@ func checkMemoTable(ctx context.Context, db *sql.DB) {
 		}
 
 		total++
+		logProgress("MEMO", total)
 		hasIssue := false
 
 		// Check content
@@ -88,11 +167,11 @@ func checkMemoTable(ctx context.Context, db *sql.DB) {
 			hasIssue = true
 
 			if *fix {
-				content = sanitizeUTF8(content)
-				if _, err := db.ExecContext(ctx, "UPDATE memo SET content = ? WHERE id = ?", content, id); err != nil {
-					log.Printf("   ERROR fixing content: %v", err)
-				} else {
-					log.Printf("   ✓ Fixed content")
+				content = util.SanitizeUTF8(content)
+				fixes <- fixRequest{
+					label: fmt.Sprintf("memo %d content", id),
+					query: "UPDATE memo SET content = ? WHERE id = ?",
+					args:  []any{content, id},
 				}
 			}
 		}
@@ -103,11 +182,11 @@ func checkMemoTable(ctx context.Context, db *sql.DB) {
 			hasIssue = true
 
 			if *fix {
-				snippet = sanitizeUTF8(snippet)
-				if _, err := db.ExecContext(ctx, "UPDATE memo SET snippet = ? WHERE id = ?", snippet, id); err != nil {
-					log.Printf("   ERROR fixing snippet: %v", err)
-				} else {
-					log.Printf("   ✓ Fixed snippet")
+				snippet = util.SanitizeUTF8(snippet)
+				fixes <- fixRequest{
+					label: fmt.Sprintf("memo %d snippet", id),
+					query: "UPDATE memo SET snippet = ? WHERE id = ?",
+					args:  []any{snippet, id},
 				}
 			}
 		}
@@ -119,11 +198,11 @@ func checkMemoTable(ctx context.Context, db *sql.DB) {
 				hasIssue = true
 
 				if *fix {
-					payload = sanitizeUTF8(payload)
-					if _, err := db.ExecContext(ctx, "UPDATE memo SET payload = ? WHERE id = ?", payload, id); err != nil {
-						log.Printf("   ERROR fixing payload: %v", err)
-					} else {
-						log.Printf("   ✓ Fixed payload")
+					payload = util.SanitizeUTF8(payload)
+					fixes <- fixRequest{
+						label: fmt.Sprintf("memo %d payload", id),
+						query: "UPDATE memo SET payload = ? WHERE id = ?",
+						args:  []any{payload, id},
 					}
 				}
 			}
@@ -152,10 +231,10 @@ func checkMemoTable(ctx context.Context, db *sql.DB) {
 		}
 	}
 
-	log.Printf("\nMEMO Summary: %d total, %d with issues", total, invalid)
+	log.Printf("MEMO Summary: %d total, %d with issues", total, invalid)
 }
 
-func checkAttachmentTable(ctx context.Context, db *sql.DB) {
+func checkAttachmentTable(ctx context.Context, db *sql.DB, fixes chan<- fixRequest) {
 	rows, err := db.QueryContext(ctx, `
 		SELECT id, name, external_link, type, size
 		FROM attachment
@@ -180,6 +259,7 @@ func checkAttachmentTable(ctx context.Context, db *sql.DB) {
 		}
 
 		total++
+		logProgress("ATTACHMENT", total)
 
 		if !utf8.ValidString(name) {
 			log.Printf("❌ Attachment ID=%d: Invalid UTF-8 in NAME", id)
@@ -200,7 +280,7 @@ func checkAttachmentTable(ctx context.Context, db *sql.DB) {
 	log.Printf("ATTACHMENT Summary: %d total, %d with issues", total, invalid)
 }
 
-func checkReactionTable(ctx context.Context, db *sql.DB) {
+func checkReactionTable(ctx context.Context, db *sql.DB, fixes chan<- fixRequest) {
 	rows, err := db.QueryContext(ctx, `
 		SELECT id, content_id, reaction_type
 		FROM reaction
@@ -224,6 +304,7 @@ func checkReactionTable(ctx context.Context, db *sql.DB) {
 		}
 
 		total++
+		logProgress("REACTION", total)
 
 		if !utf8.ValidString(reactionType) {
 			log.Printf("❌ Reaction ID=%d: Invalid UTF-8 in REACTION_TYPE: %s", id, reactionType)
@@ -234,7 +315,7 @@ func checkReactionTable(ctx context.Context, db *sql.DB) {
 	log.Printf("REACTION Summary: %d total, %d with issues", total, invalid)
 }
 
-func checkActivityTable(ctx context.Context, db *sql.DB) {
+func checkActivityTable(ctx context.Context, db *sql.DB, fixes chan<- fixRequest) {
 	rows, err := db.QueryContext(ctx, `
 		SELECT id, COALESCE(payload, '')
 		FROM activity
@@ -258,6 +339,7 @@ func checkActivityTable(ctx context.Context, db *sql.DB) {
 		}
 
 		total++
+		logProgress("ACTIVITY", total)
 
 		if payload != "" && !utf8.ValidString(payload) {
 			log.Printf("❌ Activity ID=%d: Invalid UTF-8 in PAYLOAD", id)
@@ -267,21 +349,3 @@ func checkActivityTable(ctx context.Context, db *sql.DB) {
 
 	log.Printf("ACTIVITY Summary: %d total, %d with issues", total, invalid)
 }
-
-func sanitizeUTF8(s string) string {
-	if utf8.ValidString(s) {
-		return s
-	}
-
-	result := make([]byte, 0, len(s))
-	for len(s) > 0 {
-		r, size := utf8.DecodeRuneInString(s)
-		if r == utf8.RuneError && size == 1 {
-			result = append(result, []byte(string(utf8.RuneError))...)
-		} else {
-			result = append(result, s[:size]...)
-		}
-		s = s[size:]
-	}
-	return string(result)
-}
@@ -7,28 +7,37 @@
 // sequences that could cause gRPC encoding errors.
 //
 // Usage:
-//   go run fix_utf8.go [--data-dir PATH] [--driver sqlite|mysql|postgres] [--dsn CONNECTION_STRING]
+//   go run fix_utf8.go [--data-dir PATH] [--driver sqlite|mysql|postgres] [--dsn CONNECTION_STRING] [--backup FILE] [--normalize]
 //
 // Examples:
 //   go run fix_utf8.go
 //   go run fix_utf8.go --data-dir ~/.memos
 //   go run fix_utf8.go --driver mysql --dsn "user:pass@tcp(localhost:3306)/memos"
+//   go run fix_utf8.go --backup utf8_backup.csv
+//   go run fix_utf8.go --normalize --dry-run
 
 package main
 
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
 	_ "modernc.org/sqlite"
+
+	"github.com/usememos/memos/internal/util"
 )
 
 var (
@@ -36,8 +45,20 @@ var (
 	driver  = flag.String("driver", "sqlite", "Database driver: sqlite, mysql, postgres")
 	dsn     = flag.String("dsn", "", "Database connection string (for mysql/postgres)")
 	dryRun  = flag.Bool("dry-run", false, "Only check for invalid UTF-8 without fixing")
+	backup  = flag.String("backup", "", "Write the original values of fixed rows to FILE before updating (.csv for CSV, otherwise JSON)")
+	// normalize also rewrites valid text that isn't in NFC, so tags differing only by composition
+	// are merged. Off by default, since it changes memos that have no invalid UTF-8.
+	normalize = flag.Bool("normalize", false, "Also normalize content and tags to Unicode NFC")
 )
 
+// backupRow is the original value of a column about to be fixed.
+type backupRow struct {
+	ID     int    `json:"id"`
+	Column string `json:"column"`
+	// Value is base64-encoded in JSON so that the invalid bytes survive the round trip.
+	Value []byte `json:"value"`
+}
+
 func main() {
 	flag.Parse()
 
@@ -76,6 +97,18 @@ func main() {
 		log.Fatalf("Unknown driver: %s", *driver)
 	}
 
+	// Create the backup file up front so a bad path aborts before any change
+	var backupFile *os.File
+	if *backup != "" && !*dryRun {
+		f, err := os.Create(*backup)
+		if err != nil {
+			log.Fatalf("Failed to create backup file: %v", err)
+		}
+		defer f.Close()
+		backupFile = f
+		log.Printf("Backing up original values to: %s", *backup)
+	}
+
 	// Open database
 	db, err := sql.Open(*driver, connStr)
 	if err != nil {
@@ -91,25 +124,37 @@ func main() {
 
 	// Run the fix
 	ctx := context.Background()
-	if err := fixInvalidUTF8(ctx, db); err != nil {
+	if err := fixInvalidUTF8(ctx, db, backupFile); err != nil {
 		log.Fatalf("Failed to fix UTF-8: %v", err)
 	}
 
 	log.Println("Done!")
 }
 
-func fixInvalidUTF8(ctx context.Context, db *sql.DB) error {
+// memoFix is a memo whose content or payload is about to be updated.
+type memoFix struct {
+	id      int
+	content string
+	payload string
+	// sanitized is true if the content had invalid UTF-8, and false if the memo changed only due to normalization.
+	sanitized bool
+}
+
+func fixInvalidUTF8(ctx context.Context, db *sql.DB, backupFile *os.File) error {
 	// Query all memos
-	rows, err := db.QueryContext(ctx, "SELECT id, uid, content FROM memo")
+	rows, err := db.QueryContext(ctx, "SELECT id, uid, content, payload FROM memo")
 	if err != nil {
 		return fmt.Errorf("failed to query memos: %w", err)
 	}
 	defer rows.Close()
 
 	var (
-		totalCount   int
-		invalidCount int
-		fixedCount   int
+		totalCount      int
+		invalidCount    int
+		normalizedCount int
+		fixedCount      int
+		fixes           = []memoFix{}
+		originalRows    = []backupRow{}
 	)
 
 	for rows.Next() {
@@ -117,47 +162,82 @@ func fixInvalidUTF8(ctx context.Context, db *sql.DB) error {
 			id      int
 			uid     string
 			content string
+			payload string
 		)
 
-		if err := rows.Scan(&id, &uid, &content); err != nil {
+		if err := rows.Scan(&id, &uid, &content, &payload); err != nil {
 			return fmt.Errorf("failed to scan row: %w", err)
 		}
 
 		totalCount++
 
+		fix := memoFix{id: id, content: content, payload: payload}
 		// Check if content is valid UTF-8
 		if !utf8.ValidString(content) {
 			invalidCount++
+			fix.sanitized = true
+			fix.content = sanitizeContent(content)
 
 			log.Printf("Found invalid UTF-8 in memo %s (ID: %d)", uid, id)
 			log.Printf("  Content length: %d bytes", len(content))
-
-			if *dryRun {
-				log.Printf("  [DRY-RUN] Would sanitize this memo")
-				continue
-			}
-
-			// Sanitize the content
-			sanitized := sanitizeUTF8(content)
-
-			// Update the database
-			_, err := db.ExecContext(ctx,
-				"UPDATE memo SET content = ? WHERE id = ?",
-				sanitized, id,
-			)
+		} else if *normalize {
+			fix.content = util.NormalizeUTF8(content)
+		}
+		if *normalize {
+			normalizedPayload, err := normalizePayloadTags(payload)
 			if err != nil {
-				log.Printf("  ERROR: Failed to update memo: %v", err)
-				continue
+				log.Printf("WARNING: Skipping tags of memo %s (ID: %d): %v", uid, id, err)
+			} else {
+				fix.payload = normalizedPayload
 			}
+		}
+		if fix.content == content && fix.payload == payload {
+			continue
+		}
+		if !fix.sanitized {
+			normalizedCount++
+			log.Printf("Found non-NFC text in memo %s (ID: %d)", uid, id)
+		}
 
-			fixedCount++
-			log.Printf("  ✓ Fixed and saved")
+		if *dryRun {
+			log.Printf("  [DRY-RUN] Would fix this memo")
+			continue
+		}
+		fixes = append(fixes, fix)
+		if fix.content != content {
+			originalRows = append(originalRows, backupRow{ID: id, Column: "content", Value: []byte(content)})
+		}
+		if fix.payload != payload {
+			originalRows = append(originalRows, backupRow{ID: id, Column: "payload", Value: []byte(payload)})
 		}
 	}
 
 	if err := rows.Err(); err != nil {
 		return fmt.Errorf("error iterating rows: %w", err)
 	}
+	rows.Close()
+
+	// Back up every row before the first update
+	if backupFile != nil {
+		if err := writeBackup(backupFile, originalRows); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+		log.Printf("Backed up %d rows to %s", len(originalRows), backupFile.Name())
+	}
+
+	updateQuery := "UPDATE memo SET content = ?, payload = ? WHERE id = ?"
+	if *driver == "postgres" {
+		updateQuery = "UPDATE memo SET content = $1, payload = $2 WHERE id = $3"
+	}
+	for _, fix := range fixes {
+		if _, err := db.ExecContext(ctx, updateQuery, fix.content, fix.payload, fix.id); err != nil {
+			log.Printf("ERROR: Failed to update memo %d: %v", fix.id, err)
+			continue
+		}
+
+		fixedCount++
+		log.Printf("✓ Fixed and saved memo %d", fix.id)
+	}
 
 	// Print summary
 	fmt.Println()
@@ -165,6 +245,9 @@ func fixInvalidUTF8(ctx context.Context, db *sql.DB) error {
 	fmt.Printf("Summary:\n")
 	fmt.Printf("  Total memos scanned: %d\n", totalCount)
 	fmt.Printf("  Invalid UTF-8 found: %d\n", invalidCount)
+	if *normalize {
+		fmt.Printf("  Changed by NFC normalization only: %d\n", normalizedCount)
+	}
 	if *dryRun {
 		fmt.Printf("  [DRY-RUN] No changes made\n")
 	} else {
@@ -175,26 +258,87 @@ func fixInvalidUTF8(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
-// sanitizeUTF8 removes invalid UTF-8 sequences from a string.
-// Invalid sequences are replaced with the Unicode replacement character (�).
-func sanitizeUTF8(s string) string {
-	if utf8.ValidString(s) {
-		return s
+// sanitizeContent replaces the invalid UTF-8 in content, and normalizes it to NFC if --normalize is set.
+func sanitizeContent(content string) string {
+	if *normalize {
+		return util.SanitizeUTF8(content, util.WithNormalization())
 	}
+	return util.SanitizeUTF8(content)
+}
 
-	// String contains invalid UTF-8, need to clean it
-	result := make([]byte, 0, len(s))
-
-	for len(s) > 0 {
-		r, size := utf8.DecodeRuneInString(s)
-		if r == utf8.RuneError && size == 1 {
-			// Invalid UTF-8 sequence, replace with replacement character
-			result = append(result, []byte(string(utf8.RuneError))...)
-		} else {
-			result = append(result, s[:size]...)
+// normalizePayloadTags normalizes the manual and AI tags in a memo payload to NFC, dropping tags
+// that become duplicates. The payload is returned unchanged if no tag changes, so that memos
+// without tags to normalize aren't rewritten.
+func normalizePayloadTags(payload string) (string, error) {
+	if payload == "" {
+		return payload, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+		return "", fmt.Errorf("failed to parse payload: %w", err)
+	}
+	changed := false
+	for _, key := range []string{"tags", "aiTags"} {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		var tags []string
+		if err := json.Unmarshal(raw, &tags); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", key, err)
+		}
+		normalized := make([]string, 0, len(tags))
+		seen := make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			tag = util.SanitizeUTF8(tag, util.WithNormalization())
+			if seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+		if slices.Equal(normalized, tags) {
+			continue
 		}
-		s = s[size:]
+		data, err := json.Marshal(normalized)
+		if err != nil {
+			return "", err
+		}
+		fields[key] = data
+		changed = true
 	}
+	if !changed {
+		return payload, nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
 
-	return string(result)
+// writeBackup writes rows to f as CSV if its name ends in .csv, and as JSON otherwise.
+func writeBackup(f *os.File, rows []backupRow) error {
+	if strings.EqualFold(filepath.Ext(f.Name()), ".csv") {
+		writer := csv.NewWriter(f)
+		if err := writer.Write([]string{"id", "column", "value"}); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := writer.Write([]string{strconv.Itoa(row.ID), row.Column, string(row.Value)}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+	} else {
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rows); err != nil {
+			return err
+		}
+	}
+	return f.Sync()
 }
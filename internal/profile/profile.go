@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -32,6 +33,9 @@ type Profile struct {
 	Version string
 	// InstanceURL is the url of your memos instance.
 	InstanceURL string
+	// UTF8SanitizeInterval is how often stored text is swept for invalid UTF-8.
+	// Zero disables the sweep.
+	UTF8SanitizeInterval time.Duration
 }
 
 func (p *Profile) IsDev() bool {
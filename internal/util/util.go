@@ -6,9 +6,14 @@ import (
 	"net/mail"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 // ConvertStringToInt32 converts a string to int32.
@@ -42,27 +47,92 @@ func GenUUID() string {
 	return uuid.New().String()
 }
 
-var letters = []rune("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+var letters = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
 // RandomString returns a random string with length n.
 func RandomString(n int) (string, error) {
+	return RandomStringFromCharset(n, letters)
+}
+
+// RandomStringFromCharset returns a random string of n runes drawn uniformly from charset.
+// It returns an error if charset is empty.
+func RandomStringFromCharset(n int, charset string) (string, error) {
+	runes := []rune(charset)
+	if len(runes) == 0 {
+		return "", errors.New("charset is empty")
+	}
+
 	var sb strings.Builder
 	sb.Grow(n)
 	for i := 0; i < n; i++ {
 		// The reason for using crypto/rand instead of math/rand is that
 		// the former relies on hardware to generate random numbers and
 		// thus has a stronger source of random numbers.
-		randNum, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		randNum, err := rand.Int(rand.Reader, big.NewInt(int64(len(runes))))
 		if err != nil {
 			return "", err
 		}
-		if _, err := sb.WriteRune(letters[randNum.Uint64()]); err != nil {
+		if _, err := sb.WriteRune(runes[randNum.Uint64()]); err != nil {
 			return "", err
 		}
 	}
 	return sb.String(), nil
 }
 
+// slugFallbackLength is the length of the random slug used when the input has no letters or numbers.
+const slugFallbackLength = 8
+
+// stripMarks decomposes text and removes its nonspacing marks, e.g. "é" becomes "e".
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// Slugify returns a URL slug of s: lowercased, with accents stripped and runs of characters
+// other than letters and numbers replaced by single hyphens, without leading or trailing hyphens.
+// The slug is truncated to at most maxLen bytes on a rune boundary; non-positive maxLen means no limit.
+// If s has no letters or numbers, a short random slug is returned.
+func Slugify(s string, maxLen int) string {
+	stripped, _, err := transform.String(stripMarks, s)
+	if err != nil {
+		stripped = s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(stripped))
+	pendingHyphen := false
+	for _, r := range strings.ToLower(stripped) {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			pendingHyphen = sb.Len() > 0
+			continue
+		}
+		if pendingHyphen {
+			sb.WriteByte('-')
+			pendingHyphen = false
+		}
+		sb.WriteRune(r)
+	}
+	slug := sb.String()
+
+	if maxLen > 0 && len(slug) > maxLen {
+		cut := maxLen
+		for cut > 0 && !utf8.RuneStart(slug[cut]) {
+			cut--
+		}
+		slug = strings.TrimRight(slug[:cut], "-")
+	}
+
+	if slug == "" {
+		n := slugFallbackLength
+		if maxLen > 0 && maxLen < n {
+			n = maxLen
+		}
+		random, err := RandomString(n)
+		if err != nil {
+			return ""
+		}
+		slug = strings.ToLower(random)
+	}
+	return slug
+}
+
 // ReplaceString replaces all occurrences of old in slice with new.
 func ReplaceString(slice []string, old, new string) []string {
 	for i, s := range slice {
@@ -73,10 +143,44 @@ func ReplaceString(slice []string, old, new string) []string {
 	return slice
 }
 
+// SanitizeOption configures SanitizeUTF8.
+type SanitizeOption func(*sanitizeOptions)
+
+type sanitizeOptions struct {
+	normalize bool
+}
+
+// WithNormalization makes SanitizeUTF8 also NFC-normalize the sanitized string, see NormalizeUTF8.
+func WithNormalization() SanitizeOption {
+	return func(o *sanitizeOptions) {
+		o.normalize = true
+	}
+}
+
 // SanitizeUTF8 removes invalid UTF-8 sequences from a string.
 // This is critical for gRPC which requires valid UTF-8 in all string fields.
 // Invalid sequences are replaced with the Unicode replacement character (�).
-func SanitizeUTF8(s string) string {
+func SanitizeUTF8(s string, opts ...SanitizeOption) string {
+	options := &sanitizeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	s = sanitizeUTF8(s)
+	if options.normalize {
+		s = NormalizeUTF8(s)
+	}
+	return s
+}
+
+// NormalizeUTF8 returns s in Unicode Normalization Form C, so precomposed and decomposed
+// forms of the same text (e.g. "é" and "e" + U+0301) become equal.
+// Unlike SanitizeUTF8, which fixes invalid bytes, it canonicalizes valid text; invalid
+// bytes are left as they are, so sanitize first if s may be invalid.
+func NormalizeUTF8(s string) string {
+	return norm.NFC.String(s)
+}
+
+func sanitizeUTF8(s string) string {
 	if utf8.ValidString(s) {
 		return s
 	}
@@ -99,7 +203,138 @@ func SanitizeUTF8(s string) string {
 	return sb.String()
 }
 
+// StripControlChars removes C0 and C1 control characters, including NUL and DEL, from s.
+// If keepNewlinesTabs is true, \n, \r and \t are kept.
+// It is separate from SanitizeUTF8 so callers can compose them; invalid UTF-8 is left as is.
+func StripControlChars(s string, keepNewlinesTabs bool) string {
+	isStripped := func(r rune) bool {
+		if keepNewlinesTabs && (r == '\n' || r == '\r' || r == '\t') {
+			return false
+		}
+		return unicode.IsControl(r)
+	}
+
+	// Fast path: most content has no control characters.
+	i := strings.IndexFunc(s, isStripped)
+	if i < 0 {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	sb.WriteString(s[:i])
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			// Keep invalid bytes for SanitizeUTF8 to handle.
+			sb.WriteByte(s[i])
+		} else if !isStripped(r) {
+			sb.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return sb.String()
+}
+
 // IsValidUTF8 checks if a string contains only valid UTF-8.
 func IsValidUTF8(s string) bool {
 	return utf8.ValidString(s)
 }
+
+const (
+	// maxReactionShortcodeLength is the max length of a reaction shortcode such as "+1" or ":tada:",
+	// colons included.
+	maxReactionShortcodeLength = 32
+	// zeroWidthJoiner joins emoji into a single sequence, e.g. 👩‍💻.
+	zeroWidthJoiner = '\u200d'
+	// emojiPresentationSelector and combiningKeycap turn a preceding character into an emoji,
+	// e.g. ‼️ and 1️⃣.
+	emojiPresentationSelector = '\ufe0f'
+	combiningKeycap           = '\u20e3'
+)
+
+// IsValidReactionType reports whether s is valid as the reaction type of a memo reaction,
+// see ValidateReactionType.
+func IsValidReactionType(s string) bool {
+	return ValidateReactionType(s) == nil
+}
+
+// ValidateReactionType checks that s is well-formed UTF-8 without control characters, and that it is
+// either a single emoji, including sequences such as 👍🏽, 👩‍💻, 🇫🇷 and 1️⃣, or a short shortcode
+// of ASCII letters, digits, _, + and -, optionally wrapped in colons, e.g. "+1" or ":tada:".
+func ValidateReactionType(s string) error {
+	if s == "" {
+		return errors.New("reaction type is empty")
+	}
+	if !utf8.ValidString(s) {
+		return errors.New("reaction type is not valid UTF-8")
+	}
+	if strings.IndexFunc(s, unicode.IsControl) >= 0 {
+		return errors.New("reaction type contains control characters")
+	}
+	if isReactionShortcode(s) {
+		return nil
+	}
+
+	runes := []rune(s)
+	i := 1
+	switch r := runes[0]; {
+	case isRegionalIndicator(r):
+		// A flag is a pair of regional indicators.
+		if i < len(runes) && isRegionalIndicator(runes[i]) {
+			i++
+		}
+	case unicode.Is(unicode.So, r):
+	case !unicode.IsLetter(r) && len(runes) > 1 && (runes[1] == emojiPresentationSelector || runes[1] == combiningKeycap):
+	default:
+		return errors.Errorf("reaction type %q is neither an emoji nor a shortcode", s)
+	}
+	// Like emoji in tags, the rest may only continue the first emoji.
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case isSkinToneModifier(r), unicode.IsMark(r), isEmojiTagChar(r):
+			i++
+		case r == zeroWidthJoiner && i+1 < len(runes) && unicode.Is(unicode.So, runes[i+1]):
+			i += 2
+		default:
+			return errors.Errorf("reaction type %q must be a single emoji", s)
+		}
+	}
+	return nil
+}
+
+// isReactionShortcode reports whether s is a reaction shortcode, e.g. "+1" or ":tada:".
+func isReactionShortcode(s string) bool {
+	if len(s) > maxReactionShortcodeLength {
+		return false
+	}
+	if len(s) > 2 && strings.HasPrefix(s, ":") && strings.HasSuffix(s, ":") {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '+' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// isRegionalIndicator reports whether r is one of the regional indicator symbols pairs of which make flags.
+func isRegionalIndicator(r rune) bool {
+	return r >= '\U0001F1E6' && r <= '\U0001F1FF'
+}
+
+// isSkinToneModifier reports whether r is an emoji skin tone modifier.
+func isSkinToneModifier(r rune) bool {
+	return r >= '\U0001F3FB' && r <= '\U0001F3FF'
+}
+
+// isEmojiTagChar reports whether r is a tag character, which spell out subdivision flags such as
+// the flag of Scotland.
+func isEmojiTagChar(r rune) bool {
+	return r >= '\U000E0020' && r <= '\U000E007F'
+}
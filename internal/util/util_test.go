@@ -1,7 +1,9 @@
 package util //nolint:revive // util is an appropriate package name for utility functions
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestValidateEmail(t *testing.T) {
@@ -29,3 +31,309 @@ func TestValidateEmail(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeUTF8(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{
+			s:    "cafe\u0301",
+			want: "caf\u00e9",
+		},
+		{
+			s:    "caf\u00e9",
+			want: "caf\u00e9",
+		},
+		{
+			// Hangul syllable from conjoining jamo.
+			s:    "\u1100\u1161",
+			want: "\uac00",
+		},
+		{
+			// Angstrom sign is canonically equivalent to Å.
+			s:    "\u212b",
+			want: "\u00c5",
+		},
+		{
+			s:    "plain ascii",
+			want: "plain ascii",
+		},
+	}
+	for _, test := range tests {
+		result := NormalizeUTF8(test.s)
+		if result != test.want {
+			t.Errorf("NormalizeUTF8 %q: got result %q, want %q.", test.s, result, test.want)
+		}
+	}
+}
+
+func TestSanitizeUTF8(t *testing.T) {
+	tests := []struct {
+		s         string
+		normalize bool
+		want      string
+	}{
+		{
+			s:    "hello \xff world",
+			want: "hello \ufffd world",
+		},
+		{
+			s:    "cafe\u0301",
+			want: "cafe\u0301",
+		},
+		{
+			s:         "cafe\u0301 \xff",
+			normalize: true,
+			want:      "caf\u00e9 \ufffd",
+		},
+	}
+	for _, test := range tests {
+		opts := []SanitizeOption{}
+		if test.normalize {
+			opts = append(opts, WithNormalization())
+		}
+		result := SanitizeUTF8(test.s, opts...)
+		if result != test.want {
+			t.Errorf("SanitizeUTF8 %q (normalize %v): got result %q, want %q.", test.s, test.normalize, result, test.want)
+		}
+	}
+}
+
+// TestSanitizeUTF8BadBytes runs the kinds of invalid UTF-8 found in memo databases through
+// SanitizeUTF8, which the server and the dev_tests repair tools share.
+func TestSanitizeUTF8BadBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "empty", s: "", want: ""},
+		{name: "valid multi-byte", s: "中文 émoji 🎉", want: "中文 émoji 🎉"},
+		{name: "NUL is valid", s: "a\x00b", want: "a\x00b"},
+		{name: "invalid bytes", s: "\xfe\xff", want: "\ufffd\ufffd"},
+		{name: "lone continuation byte", s: "a\x80b", want: "a\ufffdb"},
+		{name: "truncated 2-byte sequence", s: "caf\xc3", want: "caf\ufffd"},
+		{name: "truncated 3-byte sequence", s: "\xe4\xb8 middle", want: "\ufffd\ufffd middle"},
+		{name: "truncated 4-byte sequence", s: "party \xf0\x9f\x8e", want: "party \ufffd\ufffd\ufffd"},
+		{name: "overlong encoding", s: "\xc0\x80", want: "\ufffd\ufffd"},
+		{name: "surrogate half", s: "\xed\xa0\x80", want: "\ufffd\ufffd\ufffd"},
+		{name: "beyond U+10FFFF", s: "\xf4\x90\x80\x80", want: "\ufffd\ufffd\ufffd\ufffd"},
+		{name: "Latin-1 text", s: "na\xefve r\xe9sum\xe9", want: "na\ufffdve r\ufffdsum\ufffd"},
+		{name: "invalid between valid runes", s: "中\xff文", want: "中\ufffd文"},
+	}
+	for _, test := range tests {
+		result := SanitizeUTF8(test.s)
+		if result != test.want {
+			t.Errorf("SanitizeUTF8 %s %q: got result %q, want %q.", test.name, test.s, result, test.want)
+		}
+		if !utf8.ValidString(result) {
+			t.Errorf("SanitizeUTF8 %s %q: got invalid result %q.", test.name, test.s, result)
+		}
+		if again := SanitizeUTF8(result); again != result {
+			t.Errorf("SanitizeUTF8 %s %q: not idempotent, got %q then %q.", test.name, test.s, result, again)
+		}
+	}
+}
+
+func TestStripControlChars(t *testing.T) {
+	tests := []struct {
+		s                string
+		keepNewlinesTabs bool
+		want             string
+	}{
+		{
+			s:    "plain text",
+			want: "plain text",
+		},
+		{
+			s:    "nul\x00byte",
+			want: "nulbyte",
+		},
+		{
+			s:    "bell\x07 escape\x1b[0m del\x7f",
+			want: "bell escape[0m del",
+		},
+		{
+			s:    "c1\u0085\u009f chars",
+			want: "c1 chars",
+		},
+		{
+			s:    "line\r\nnext\tcol",
+			want: "linenextcol",
+		},
+		{
+			s:                "line\r\nnext\tcol\x00",
+			keepNewlinesTabs: true,
+			want:             "line\r\nnext\tcol",
+		},
+		{
+			s:                "unicode 日本語 \u200b kept",
+			keepNewlinesTabs: true,
+			want:             "unicode 日本語 \u200b kept",
+		},
+		{
+			s:    "invalid \xff\x00 kept",
+			want: "invalid \xff kept",
+		},
+	}
+	for _, test := range tests {
+		result := StripControlChars(test.s, test.keepNewlinesTabs)
+		if result != test.want {
+			t.Errorf("StripControlChars %q (keep %v): got result %q, want %q.", test.s, test.keepNewlinesTabs, result, test.want)
+		}
+	}
+}
+
+func BenchmarkStripControlChars(b *testing.B) {
+	clean := strings.Repeat("A typical memo line with #tags and some 日本語 text.\n", 200)
+	dirty := strings.Repeat("A memo line\x00 imported with\x1b control characters.\n", 200)
+	b.Run("clean", func(b *testing.B) {
+		b.SetBytes(int64(len(clean)))
+		for i := 0; i < b.N; i++ {
+			StripControlChars(clean, true)
+		}
+	})
+	b.Run("dirty", func(b *testing.B) {
+		b.SetBytes(int64(len(dirty)))
+		for i := 0; i < b.N; i++ {
+			StripControlChars(dirty, true)
+		}
+	})
+}
+
+func TestRandomStringFromCharset(t *testing.T) {
+	const charset = "23456789abcdefghjkmnpqrstuvwxyz"
+	const n = 31000
+
+	s, err := RandomStringFromCharset(n, charset)
+	if err != nil {
+		t.Fatalf("RandomStringFromCharset: unexpected error %v.", err)
+	}
+	counts := map[rune]int{}
+	for _, r := range s {
+		if !strings.ContainsRune(charset, r) {
+			t.Fatalf("RandomStringFromCharset: got rune %q outside of the charset.", r)
+		}
+		counts[r]++
+	}
+	// Each of the 31 runes is expected 1000 times; allow a generous margin.
+	for _, r := range charset {
+		if counts[r] < 800 || counts[r] > 1200 {
+			t.Errorf("RandomStringFromCharset: got rune %q %d times, want about 1000.", r, counts[r])
+		}
+	}
+
+	s, err = RandomStringFromCharset(5, "日本")
+	if err != nil || len([]rune(s)) != 5 || strings.Trim(s, "日本") != "" {
+		t.Errorf("RandomStringFromCharset with multibyte charset: got result %q, error %v.", s, err)
+	}
+
+	if _, err := RandomStringFromCharset(5, ""); err == nil {
+		t.Error("RandomStringFromCharset with empty charset: got no error, want an error.")
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		s      string
+		maxLen int
+		want   string
+	}{
+		{
+			s:    "Hello, World!",
+			want: "hello-world",
+		},
+		{
+			s:    "  Crème Brûlée -- recipe #2  ",
+			want: "creme-brulee-recipe-2",
+		},
+		{
+			s:    "cafe\u0301 notes",
+			want: "cafe-notes",
+		},
+		{
+			s:    "日本語のメモ",
+			want: "日本語のメモ",
+		},
+		{
+			s:      "Meeting notes for the quarterly review",
+			maxLen: 16,
+			want:   "meeting-notes-fo",
+		},
+		{
+			s:      "Meeting notes",
+			maxLen: 8,
+			want:   "meeting",
+		},
+		{
+			// Each rune is 3 bytes; the cut must not split one.
+			s:      "日本語",
+			maxLen: 7,
+			want:   "日本",
+		},
+	}
+	for _, test := range tests {
+		result := Slugify(test.s, test.maxLen)
+		if result != test.want {
+			t.Errorf("Slugify %q (maxLen %d): got result %q, want %q.", test.s, test.maxLen, result, test.want)
+		}
+	}
+
+	for _, s := range []string{"", "!!! ---", "   "} {
+		result := Slugify(s, 0)
+		if len(result) != slugFallbackLength || result != strings.ToLower(result) {
+			t.Errorf("Slugify %q: got result %q, want a random lowercase slug of length %d.", s, result, slugFallbackLength)
+		}
+	}
+	if result := Slugify("...", 4); len(result) != 4 {
+		t.Errorf("Slugify with maxLen 4: got result %q, want a random slug of length 4.", result)
+	}
+}
+
+func TestValidateReactionType(t *testing.T) {
+	valid := []string{
+		"👍",
+		"❤️",
+		"👍🏽",
+		"👩‍💻",
+		"👨‍👩‍👧",
+		"🇫🇷",
+		"1️⃣",
+		"🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F",
+		"+1",
+		":tada:",
+		"thumbs_up",
+	}
+	for _, s := range valid {
+		if err := ValidateReactionType(s); err != nil {
+			t.Errorf("ValidateReactionType %q: got error %v, want nil.", s, err)
+		}
+		if !IsValidReactionType(s) {
+			t.Errorf("IsValidReactionType %q: got false, want true.", s)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"\xff",
+		"👍\xff",
+		"👍\n",
+		"\x00",
+		"👍👎",
+		"🎉 ",
+		"👍‍",
+		"日本",
+		"hello world",
+		"::",
+		":" + strings.Repeat("a", 32) + ":",
+	}
+	for _, s := range invalid {
+		if err := ValidateReactionType(s); err == nil {
+			t.Errorf("ValidateReactionType %q: got nil, want an error.", s)
+		}
+		if IsValidReactionType(s) {
+			t.Errorf("IsValidReactionType %q: got true, want false.", s)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package ast
 
 import (
+	"strings"
+
 	gast "github.com/yuin/goldmark/ast"
 )
 
@@ -10,6 +12,13 @@ type TagNode struct {
 
 	// Tag name without the # prefix
 	Tag []byte
+
+	// Segments of a hierarchical tag split on "/", e.g. [work project] for #work/project.
+	// Empty segments from leading, trailing or doubled slashes are dropped.
+	Segments []string
+
+	// Bracketed reports whether the tag was written as #[tag name], which allows spaces.
+	Bracketed bool
 }
 
 // KindTag is the NodeKind for TagNode.
@@ -23,6 +32,7 @@ func (*TagNode) Kind() gast.NodeKind {
 // Dump implements Node.Dump for debugging.
 func (n *TagNode) Dump(source []byte, level int) {
 	gast.DumpHelper(n, source, level, map[string]string{
-		"Tag": string(n.Tag),
+		"Tag":      string(n.Tag),
+		"Segments": strings.Join(n.Segments, ", "),
 	}, nil)
 }
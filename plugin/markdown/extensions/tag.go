@@ -8,17 +8,24 @@ import (
 	mparser "github.com/usememos/memos/plugin/markdown/parser"
 )
 
-type tagExtension struct{}
+type tagExtension struct {
+	options []mparser.TagParserOption
+}
 
 // TagExtension is a goldmark extension for #tag syntax.
 var TagExtension = &tagExtension{}
 
+// NewTagExtension returns a goldmark extension for #tag syntax whose parser is configured with options.
+func NewTagExtension(options ...mparser.TagParserOption) goldmark.Extender {
+	return &tagExtension{options: options}
+}
+
 // Extend extends the goldmark parser with tag support.
-func (*tagExtension) Extend(m goldmark.Markdown) {
+func (e *tagExtension) Extend(m goldmark.Markdown) {
 	m.Parser().AddOptions(
 		parser.WithInlineParsers(
 			// Priority 200 - run before standard link parser (500)
-			util.Prioritized(mparser.NewTagParser(), 200),
+			util.Prioritized(mparser.NewTagParser(e.options...), 200),
 		),
 	)
 }
@@ -3,6 +3,7 @@ package markdown
 import (
 	"bytes"
 	"strings"
+	"unicode"
 
 	"github.com/yuin/goldmark"
 	gast "github.com/yuin/goldmark/ast"
@@ -10,9 +11,13 @@ import (
 	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
 
 	mast "github.com/usememos/memos/plugin/markdown/ast"
 	"github.com/usememos/memos/plugin/markdown/extensions"
+	mparser "github.com/usememos/memos/plugin/markdown/parser"
 	"github.com/usememos/memos/plugin/markdown/renderer"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
@@ -31,7 +36,9 @@ type Service interface {
 	// ExtractAll extracts tags, properties, and references in a single parse (most efficient)
 	ExtractAll(content []byte) (*ExtractedData, error)
 
-	// ExtractTags returns all #tags found in content
+	// ExtractTags returns the unique #tags found in content, without the #, normalized and
+	// in order of first appearance. Tags inside code spans and code blocks are skipped.
+	// This is the canonical tag extraction used for memo payloads.
 	ExtractTags(content []byte) ([]string, error)
 
 	// ExtractProperties computes boolean properties
@@ -51,11 +58,15 @@ type Service interface {
 
 	// RenameTag renames all occurrences of oldTag to newTag in content
 	RenameTag(content []byte, oldTag, newTag string) (string, error)
+
+	// WithTagNormalization returns a service sharing this parser that normalizes extracted tags with normalization
+	WithTagNormalization(normalization TagNormalization) Service
 }
 
 // service implements the Service interface.
 type service struct {
-	md goldmark.Markdown
+	md               goldmark.Markdown
+	tagNormalization TagNormalization
 }
 
 // Option configures the markdown service.
@@ -63,6 +74,7 @@ type Option func(*config)
 
 type config struct {
 	enableTags bool
+	tagOptions []mparser.TagParserOption
 }
 
 // WithTagExtension enables #tag parsing.
@@ -72,6 +84,41 @@ func WithTagExtension() Option {
 	}
 }
 
+// WithTagSymbols allows symbol runes in any of tables in #tags, e.g. unicode.So for emoji such as #🔥hot.
+// Multi-codepoint emoji (ZWJ sequences, skin tone modifiers) are kept whole. It requires WithTagExtension.
+func WithTagSymbols(tables ...*unicode.RangeTable) Option {
+	return func(c *config) {
+		c.tagOptions = append(c.tagOptions, mparser.WithTagSymbols(tables...))
+	}
+}
+
+// WithExtraTagChars allows runes in #tags besides letters, numbers, -, _ and /, e.g. '.' and ':' for #v1.2 and #ns:scope.
+// It requires WithTagExtension.
+func WithExtraTagChars(runes ...rune) Option {
+	return func(c *config) {
+		c.tagOptions = append(c.tagOptions, mparser.WithExtraTagChars(runes...))
+	}
+}
+
+// WithoutNumericTags leaves #tags made of digits only, such as the issue reference #1234, as literal text.
+// Tags mixing digits with other characters, e.g. #v2, are still tags. It requires WithTagExtension.
+func WithoutNumericTags() Option {
+	return func(c *config) {
+		c.tagOptions = append(c.tagOptions, mparser.WithoutNumericTags())
+	}
+}
+
+// WithKnownTags only recognizes #tags in set, leaving other tags as literal text, matched ignoring case
+// if foldCase is set. A nil set recognizes every tag. It requires WithTagExtension.
+func WithKnownTags(set map[string]struct{}, foldCase bool) Option {
+	return func(c *config) {
+		c.tagOptions = append(c.tagOptions, mparser.WithKnownTags(set))
+		if foldCase {
+			c.tagOptions = append(c.tagOptions, mparser.WithCaseInsensitiveKnownTags())
+		}
+	}
+}
+
 // NewService creates a new markdown service with the given options.
 func NewService(opts ...Option) Service {
 	cfg := &config{}
@@ -85,7 +132,7 @@ func NewService(opts ...Option) Service {
 
 	// Add custom extensions based on config
 	if cfg.enableTags {
-		exts = append(exts, extensions.TagExtension)
+		exts = append(exts, extensions.NewTagExtension(cfg.tagOptions...))
 	}
 
 	md := goldmark.New(
@@ -100,6 +147,14 @@ func NewService(opts ...Option) Service {
 	}
 }
 
+// WithTagNormalization returns a service sharing this parser that normalizes extracted tags with normalization.
+func (s *service) WithTagNormalization(normalization TagNormalization) Service {
+	return &service{
+		md:               s.md,
+		tagNormalization: normalization,
+	}
+}
+
 // parse is an internal helper to parse content into AST.
 func (s *service) parse(content []byte) (gast.Node, error) {
 	reader := text.NewReader(content)
@@ -107,7 +162,7 @@ func (s *service) parse(content []byte) (gast.Node, error) {
 	return doc, nil
 }
 
-// ExtractTags returns all #tags found in content.
+// ExtractTags returns the unique, normalized #tags found in content in order of first appearance.
 func (s *service) ExtractTags(content []byte) ([]string, error) {
 	root, err := s.parse(content)
 	if err != nil {
@@ -135,7 +190,7 @@ func (s *service) ExtractTags(content []byte) ([]string, error) {
 	}
 
 	// Deduplicate and normalize tags
-	return uniqueLowercase(tags), nil
+	return NormalizeTags(tags, s.tagNormalization), nil
 }
 
 // ExtractProperties computes boolean properties about the content.
@@ -335,7 +390,7 @@ func (s *service) ExtractAll(content []byte) (*ExtractedData, error) {
 	}
 
 	// Deduplicate and normalize tags
-	data.Tags = uniqueLowercase(data.Tags)
+	data.Tags = NormalizeTags(data.Tags, s.tagNormalization)
 
 	return data, nil
 }
@@ -372,16 +427,42 @@ func (s *service) RenameTag(content []byte, oldTag, newTag string) (string, erro
 	return mdRenderer.Render(root, content), nil
 }
 
-// uniqueLowercase returns unique lowercase strings from input.
-func uniqueLowercase(strs []string) []string {
+// TagNormalization configures how extracted tags are normalized.
+// The zero value lowercases tags with the language-neutral Unicode case mapping.
+type TagNormalization struct {
+	// Locale is a BCP 47 language tag selecting language-specific case mapping,
+	// e.g. "tr" lowercases "I" to dotless "ı" and "İ" to "i". Empty or unknown uses the neutral mapping.
+	Locale string
+	// PreserveCase keeps the original case of tags.
+	PreserveCase bool
+}
+
+// NormalizeTag returns the normalized form of tag.
+// Tags are always NFC-normalized so composed and decomposed forms of the same text are equal.
+// Runes of scripts without case, e.g. CJK, are left untouched by case mapping.
+func NormalizeTag(tag string, normalization TagNormalization) string {
+	tag = norm.NFC.String(tag)
+	if normalization.PreserveCase {
+		return tag
+	}
+	lang, err := language.Parse(normalization.Locale)
+	if err != nil {
+		lang = language.Und
+	}
+	// Lowercasing may produce decomposed sequences, e.g. "İ" becomes "i̇" in the neutral mapping.
+	return norm.NFC.String(cases.Lower(lang).String(tag))
+}
+
+// NormalizeTags returns the unique normalized tags from input, in order of first occurrence.
+func NormalizeTags(tags []string, normalization TagNormalization) []string {
 	seen := make(map[string]bool)
 	var result []string
 
-	for _, s := range strs {
-		lower := strings.ToLower(s)
-		if !seen[lower] {
-			seen[lower] = true
-			result = append(result, lower)
+	for _, tag := range tags {
+		normalized := NormalizeTag(tag, normalization)
+		if !seen[normalized] {
+			seen[normalized] = true
+			result = append(result, normalized)
 		}
 	}
 
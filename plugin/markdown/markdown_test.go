@@ -2,6 +2,7 @@ package markdown
 
 import (
 	"testing"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -255,6 +256,48 @@ func TestExtractTags(t *testing.T) {
 			withExt:  true,
 			expected: []string{"todo", "done"},
 		},
+		{
+			name:     "tags in inline code",
+			content:  "Run `curl -H '#comment' #flag` then #done",
+			withExt:  true,
+			expected: []string{"done"},
+		},
+		{
+			name:     "tags in fenced code block",
+			content:  "#script\n\n```bash\n#!/bin/bash\necho hi #inline\n#comment\n```\n",
+			withExt:  true,
+			expected: []string{"script"},
+		},
+		{
+			name:     "tags in indented code block",
+			content:  "Example:\n\n    #comment\n    echo #flag\n\nAfter #real",
+			withExt:  true,
+			expected: []string{"real"},
+		},
+		{
+			name:     "bracketed tags",
+			content:  "Plans for #[Project Alpha] and #[project alpha] #beta",
+			withExt:  true,
+			expected: []string{"project alpha", "beta"},
+		},
+		{
+			name:     "unterminated bracketed tag",
+			content:  "Not a tag: #[project alpha",
+			withExt:  true,
+			expected: []string{},
+		},
+		{
+			name:     "punctuation around tags",
+			content:  "(#paren) \"#quoted\" #bang! #golang's #nested/tag/.",
+			withExt:  true,
+			expected: []string{"paren", "quoted", "bang", "golang", "nested/tag"},
+		},
+		{
+			name:     "shebang is not a tag",
+			content:  "#!/bin/bash",
+			withExt:  true,
+			expected: []string{},
+		},
 		{
 			name:     "no extension enabled",
 			content:  "Text with #tag",
@@ -279,11 +322,12 @@ func TestExtractTags(t *testing.T) {
 	}
 }
 
-func TestUniqueLowercase(t *testing.T) {
+func TestNormalizeTags(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    []string
-		expected []string
+		name          string
+		input         []string
+		normalization TagNormalization
+		expected      []string
 	}{
 		{
 			name:     "empty",
@@ -305,16 +349,120 @@ func TestUniqueLowercase(t *testing.T) {
 			input:    []string{"Work", "work", "Important", "work"},
 			expected: []string{"work", "important"},
 		},
+		{
+			name:     "turkish dotted i with neutral mapping",
+			input:    []string{"İstanbul", "ISPARTA"},
+			expected: []string{"i\u0307stanbul", "isparta"},
+		},
+		{
+			name:          "turkish dotted i with turkish mapping",
+			input:         []string{"İstanbul", "ISPARTA", "istanbul"},
+			normalization: TagNormalization{Locale: "tr"},
+			expected:      []string{"istanbul", "ısparta"},
+		},
+		{
+			name:          "unknown locale falls back to neutral mapping",
+			input:         []string{"Work"},
+			normalization: TagNormalization{Locale: "not a locale!"},
+			expected:      []string{"work"},
+		},
+		{
+			name:     "cjk is left untouched",
+			input:    []string{"工作", "日本語", "한국어", "工作"},
+			expected: []string{"工作", "日本語", "한국어"},
+		},
+		{
+			name:     "combining characters are composed",
+			input:    []string{"cafe\u0301", "café", "CAFÉ"},
+			expected: []string{"café"},
+		},
+		{
+			name:          "preserve case",
+			input:         []string{"Work", "work", "cafe\u0301", "café"},
+			normalization: TagNormalization{PreserveCase: true},
+			expected:      []string{"Work", "work", "café"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := uniqueLowercase(tt.input)
+			result := NormalizeTags(tt.input, tt.normalization)
 			assert.ElementsMatch(t, tt.expected, result)
 		})
 	}
 }
 
+func TestExtractTagsWithTagNormalization(t *testing.T) {
+	svc := NewService(WithTagExtension())
+	content := []byte("#İzmir #Work #work")
+
+	tags, err := svc.ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"i\u0307zmir", "work"}, tags)
+
+	tags, err = svc.WithTagNormalization(TagNormalization{Locale: "tr"}).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"izmir", "work"}, tags)
+
+	data, err := svc.WithTagNormalization(TagNormalization{PreserveCase: true}).ExtractAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"İzmir", "Work", "work"}, data.Tags)
+}
+
+func TestExtractTagsWithTagSymbols(t *testing.T) {
+	content := []byte("#🔥hot #👩\u200d💻 #work")
+
+	tags, err := NewService(WithTagExtension()).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"work"}, tags)
+
+	tags, err = NewService(WithTagExtension(), WithTagSymbols(unicode.So)).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"🔥hot", "👩\u200d💻", "work"}, tags)
+}
+
+func TestExtractTagsWithExtraTagChars(t *testing.T) {
+	content := []byte("Released #v1.2. See #ns:scope")
+
+	tags, err := NewService(WithTagExtension()).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1", "ns"}, tags)
+
+	tags, err = NewService(WithTagExtension(), WithExtraTagChars('.', ':')).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.2", "ns:scope"}, tags)
+}
+
+func TestExtractTagsWithoutNumericTags(t *testing.T) {
+	content := []byte("Fixes issue #1234 in #v2 for #2024plan")
+
+	tags, err := NewService(WithTagExtension()).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1234", "v2", "2024plan"}, tags)
+
+	tags, err = NewService(WithTagExtension(), WithoutNumericTags()).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v2", "2024plan"}, tags)
+}
+
+func TestExtractTagsWithKnownTags(t *testing.T) {
+	content := []byte("#work on #Project with a #wrok typo")
+	known := map[string]struct{}{"work": {}, "project": {}}
+
+	tags, err := NewService(WithTagExtension(), WithKnownTags(known, false)).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"work"}, tags)
+
+	tags, err = NewService(WithTagExtension(), WithKnownTags(known, true)).ExtractTags(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"work", "project"}, tags)
+
+	// Unknown tags stay in the text.
+	html, err := NewService(WithTagExtension(), WithKnownTags(known, true)).RenderHTML(content)
+	require.NoError(t, err)
+	assert.Contains(t, html, "#wrok typo")
+}
+
 func TestTruncateAtWord(t *testing.T) {
 	tests := []struct {
 		name      string
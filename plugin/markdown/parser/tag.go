@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"bytes"
+	"slices"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -11,7 +14,71 @@ import (
 	mast "github.com/usememos/memos/plugin/markdown/ast"
 )
 
-type tagParser struct{}
+type tagParser struct {
+	// symbols are the range tables of symbol runes allowed in tags. Empty disallows symbols.
+	symbols []*unicode.RangeTable
+	// extraChars are the runes allowed in tags besides the default tag characters.
+	extraChars []rune
+	// rejectNumeric rejects tags made of digits only, e.g. #1234.
+	rejectNumeric bool
+	// knownTags are the only tags parsed, if not nil. With foldKnownTags, its keys are lower case
+	// and tags are matched case-insensitively.
+	knownTags     map[string]struct{}
+	foldKnownTags bool
+}
+
+// TagParserOption configures the tag parser.
+type TagParserOption func(*tagParser)
+
+// WithTagSymbols allows runes in any of tables as tag characters, e.g. unicode.So for emoji.
+// Emoji sequences are kept whole: skin tone modifiers and zero-width joiners are accepted
+// when they continue a symbol of tables.
+func WithTagSymbols(tables ...*unicode.RangeTable) TagParserOption {
+	return func(p *tagParser) {
+		p.symbols = append(p.symbols, tables...)
+	}
+}
+
+// WithExtraTagChars allows runes in tags besides letters, numbers, -, _ and /, e.g. '.' and ':'.
+// They are not allowed at the end of a tag, so sentence punctuation after #v1.2. is not part of the tag.
+func WithExtraTagChars(runes ...rune) TagParserOption {
+	return func(p *tagParser) {
+		p.extraChars = append(p.extraChars, runes...)
+	}
+}
+
+// WithoutNumericTags rejects tags made of digits only, such as the issue reference #1234, leaving
+// them as literal text. Tags mixing digits with other characters, e.g. #v2 and #2024plan, and
+// bracketed tags such as #[1234] are still parsed.
+func WithoutNumericTags() TagParserOption {
+	return func(p *tagParser) {
+		p.rejectNumeric = true
+	}
+}
+
+// WithKnownTags only parses tags in set, leaving other tags as literal text, so typos don't create new tags.
+// Hierarchical tags must be in set as a whole, e.g. "work/project". A nil set parses every tag.
+func WithKnownTags(set map[string]struct{}) TagParserOption {
+	return func(p *tagParser) {
+		p.knownTags = set
+	}
+}
+
+// WithCaseInsensitiveKnownTags matches tags against the set of WithKnownTags ignoring case, e.g. #Work
+// is parsed if "work" is known. Parsed tags keep their casing.
+func WithCaseInsensitiveKnownTags() TagParserOption {
+	return func(p *tagParser) {
+		p.foldKnownTags = true
+	}
+}
+
+const (
+	// zeroWidthJoiner joins emoji into a single sequence, e.g. 👩‍💻.
+	zeroWidthJoiner = '\u200d'
+	// skinToneModifierFirst and skinToneModifierLast bound the emoji skin tone modifiers.
+	skinToneModifierFirst = '\U0001F3FB'
+	skinToneModifierLast  = '\U0001F3FF'
+)
 
 // decodeRune decodes the first rune from a byte slice and returns it with its size.
 // Returns (0, 0) if the slice is empty or contains invalid UTF-8.
@@ -29,9 +96,105 @@ func decodeRune(b []byte) (rune, int) {
 	return r, size
 }
 
+// parseBracketedTag parses #[tag name] syntax, which allows spaces in tags.
+// The tag ends at the matching ] on the same line; unterminated or empty brackets and unknown tags
+// are not a tag.
+func (p *tagParser) parseBracketedTag(block text.Reader, line []byte) gast.Node {
+	depth := 0
+	end := -1
+	for i := 1; i < len(line) && end < 0; i++ {
+		switch line[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		case '\n', '\r':
+			return nil
+		}
+	}
+	if end < 0 {
+		return nil
+	}
+
+	tagName := bytes.TrimSpace(line[2:end])
+	if len(tagName) == 0 || !utf8.Valid(tagName) || !p.isKnown(tagName) {
+		return nil
+	}
+
+	tagCopy := make([]byte, len(tagName))
+	copy(tagCopy, tagName)
+
+	block.Advance(end + 1)
+
+	return &mast.TagNode{
+		Tag:       tagCopy,
+		Segments:  splitTagSegments(tagCopy),
+		Bracketed: true,
+	}
+}
+
+// splitTagSegments splits a hierarchical tag on "/", dropping empty segments.
+func splitTagSegments(tag []byte) []string {
+	segments := []string{}
+	for _, segment := range bytes.Split(tag, []byte{'/'}) {
+		if len(segment) > 0 {
+			segments = append(segments, string(segment))
+		}
+	}
+	return segments
+}
+
 // NewTagParser creates a new inline parser for #tag syntax.
-func NewTagParser() parser.InlineParser {
-	return &tagParser{}
+func NewTagParser(opts ...TagParserOption) parser.InlineParser {
+	p := &tagParser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.knownTags != nil && p.foldKnownTags {
+		// The set is copied rather than lower cased in place, as it belongs to the caller.
+		folded := make(map[string]struct{}, len(p.knownTags))
+		for tag := range p.knownTags {
+			folded[strings.ToLower(tag)] = struct{}{}
+		}
+		p.knownTags = folded
+	}
+	return p
+}
+
+// isKnown reports whether tag may be parsed under the known tags of p.
+func (p *tagParser) isKnown(tag []byte) bool {
+	if p.knownTags == nil {
+		return true
+	}
+	key := string(tag)
+	if p.foldKnownTags {
+		key = strings.ToLower(key)
+	}
+	_, ok := p.knownTags[key]
+	return ok
+}
+
+// isSymbol reports whether r is a symbol rune allowed in tags.
+func (p *tagParser) isSymbol(r rune) bool {
+	return len(p.symbols) > 0 && unicode.IsOneOf(p.symbols, r)
+}
+
+// isNumeric reports whether tag consists of digits only.
+func isNumeric(tag []byte) bool {
+	for _, r := range string(tag) {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isExtraChar reports whether r is an extra rune allowed in tags.
+func (p *tagParser) isExtraChar(r rune) bool {
+	return slices.Contains(p.extraChars, r)
 }
 
 // Trigger returns the characters that trigger this parser.
@@ -40,7 +203,12 @@ func (*tagParser) Trigger() []byte {
 }
 
 // Parse parses #tag syntax.
-func (*tagParser) Parse(_ gast.Node, block text.Reader, _ parser.Context) gast.Node {
+//
+// A tag runs from the # over letters, numbers, -, _, / and any configured extra characters or symbols,
+// and ends at the first other character, so surrounding punctuation such as (#tag), "#tag", #tag! and
+// #golang's is not part of it. Combining marks belong to the tag only when attached to a letter,
+// number or symbol. Trailing slashes and extra characters are trimmed, e.g. #work/ is the tag "work".
+func (p *tagParser) Parse(_ gast.Node, block text.Reader, _ parser.Context) gast.Node {
 	line, _ := block.PeekLine()
 
 	// Must start with #
@@ -48,24 +216,36 @@ func (*tagParser) Parse(_ gast.Node, block text.Reader, _ parser.Context) gast.N
 		return nil
 	}
 
+	// A lone # at the end of the input or line is not a tag
+	if len(line) == 1 || line[1] == '\n' || line[1] == '\r' {
+		return nil
+	}
+
 	// Check if it's a heading (## or space after #)
-	if len(line) > 1 {
-		if line[1] == '#' {
-			// It's a heading (##), not a tag
-			return nil
-		}
-		if line[1] == ' ' {
-			// Space after # - heading or just a hash
-			return nil
-		}
-	} else {
-		// Just a lone #
+	if line[1] == '#' {
+		// It's a heading (##), not a tag
+		return nil
+	}
+	if line[1] == ' ' {
+		// Space after # - heading or just a hash
 		return nil
 	}
+	if line[1] == '[' {
+		// Bracketed tag, e.g. #[project alpha]
+		return p.parseBracketedTag(block, line)
+	}
 
 	// Scan tag characters
 	// Valid: Unicode letters, numbers, dash, underscore, forward slash
 	tagEnd := 1 // Start after #
+	// inSymbol tracks whether the previous character continues a symbol, so emoji
+	// modifiers and joiners are only accepted inside an emoji sequence.
+	inSymbol := false
+	// afterBase tracks whether the previous character is a letter, number or symbol,
+	// or a combining mark attached to one, so combining marks are only accepted attached.
+	afterBase := false
+	// coreEnd is the end of the tag without trailing slashes and extra characters.
+	coreEnd := tagEnd
 	for tagEnd < len(line) {
 		// Convert byte sequence to rune for Unicode support
 		r, size := decodeRune(line[tagEnd:])
@@ -74,17 +254,39 @@ func (*tagParser) Parse(_ gast.Node, block text.Reader, _ parser.Context) gast.N
 		}
 
 		// Check if character is valid for tags
-		// Valid: Unicode letters, numbers, dash, underscore, forward slash
-		isValid := unicode.IsLetter(r) ||
-			unicode.IsNumber(r) ||
-			r == '-' || r == '_' || r == '/'
+		// Valid: Unicode letters, numbers, dash, underscore, forward slash,
+		// and combining marks attached to a base character (e.g. decomposed accents)
+		isBase := unicode.IsLetter(r) || unicode.IsNumber(r)
+		isAttachedMark := afterBase && unicode.IsMark(r)
+		isValid := isBase ||
+			r == '-' || r == '_' || r == '/' ||
+			isAttachedMark
+		isExtra := !isValid && p.isExtraChar(r)
+		isSymbol := p.isSymbol(r) ||
+			(inSymbol && r >= skinToneModifierFirst && r <= skinToneModifierLast)
+		if !isValid && !isSymbol && inSymbol && r == zeroWidthJoiner {
+			// A joiner must be followed by another symbol, otherwise it ends the tag.
+			next, nextSize := decodeRune(line[tagEnd+size:])
+			if nextSize == 0 || !p.isSymbol(next) {
+				break
+			}
+			isSymbol = true
+		}
 
-		if !isValid {
+		if !isValid && !isSymbol && !isExtra {
 			break
 		}
+		// Combining marks such as variation selectors keep the current symbol going.
+		inSymbol = isSymbol || (inSymbol && unicode.IsMark(r))
+		afterBase = isBase || isSymbol || isAttachedMark
 
 		tagEnd += size
+		if (isValid && r != '/') || isSymbol {
+			coreEnd = tagEnd
+		}
 	}
+	// Trim trailing slashes and extra characters
+	tagEnd = coreEnd
 
 	// Must have at least one character after #
 	if tagEnd == 1 {
@@ -93,6 +295,9 @@ func (*tagParser) Parse(_ gast.Node, block text.Reader, _ parser.Context) gast.N
 
 	// Extract tag (without #)
 	tagName := line[1:tagEnd]
+	if (p.rejectNumeric && isNumeric(tagName)) || !p.isKnown(tagName) {
+		return nil
+	}
 
 	// Make a copy of the tag name
 	tagCopy := make([]byte, len(tagName))
@@ -103,7 +308,8 @@ func (*tagParser) Parse(_ gast.Node, block text.Reader, _ parser.Context) gast.N
 
 	// Create node
 	node := &mast.TagNode{
-		Tag: tagCopy,
+		Tag:      tagCopy,
+		Segments: splitTagSegments(tagCopy),
 	}
 
 	return node
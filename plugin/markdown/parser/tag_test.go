@@ -2,6 +2,7 @@ package parser
 
 import (
 	"testing"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -78,6 +79,30 @@ func TestTagParser(t *testing.T) {
 			expectedTag: "",
 			shouldParse: false,
 		},
+		{
+			name:        "lone hash followed by newline",
+			input:       "#\n",
+			expectedTag: "",
+			shouldParse: false,
+		},
+		{
+			name:        "lone hash followed by CRLF",
+			input:       "#\r\n",
+			expectedTag: "",
+			shouldParse: false,
+		},
+		{
+			name:        "tag followed by newline",
+			input:       "#tag\n",
+			expectedTag: "tag",
+			shouldParse: true,
+		},
+		{
+			name:        "tag followed by CRLF",
+			input:       "#tag\r\n",
+			expectedTag: "tag",
+			shouldParse: true,
+		},
 		{
 			name:        "hash with space",
 			input:       "# ",
@@ -186,6 +211,24 @@ func TestTagParser(t *testing.T) {
 			expectedTag: "项目-管理",
 			shouldParse: true,
 		},
+		{
+			name:        "tag with combining character",
+			input:       "#cafe\u0301 ",
+			expectedTag: "cafe\u0301",
+			shouldParse: true,
+		},
+		{
+			name:        "devanagari tag with vowel signs",
+			input:       "#हिन्दी",
+			expectedTag: "हिन्दी",
+			shouldParse: true,
+		},
+		{
+			name:        "combining character after hash",
+			input:       "#\u0301abc",
+			expectedTag: "",
+			shouldParse: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -210,6 +253,467 @@ func TestTagParser(t *testing.T) {
 	}
 }
 
+func TestTagParser_Symbols(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		symbols     []*unicode.RangeTable
+		expectedTag string
+		shouldParse bool
+	}{
+		{
+			name:        "emoji-initial tag is not parsed by default",
+			input:       "#🔥hot",
+			shouldParse: false,
+		},
+		{
+			name:        "emoji ends the tag by default",
+			input:       "#hot🔥",
+			expectedTag: "hot",
+			shouldParse: true,
+		},
+		{
+			name:        "emoji-initial tag",
+			input:       "#🔥hot",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "🔥hot",
+			shouldParse: true,
+		},
+		{
+			name:        "emoji-only tag",
+			input:       "#🎉 party",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "🎉",
+			shouldParse: true,
+		},
+		{
+			name:        "ZWJ emoji sequence",
+			input:       "#👩\u200d💻dev ",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "👩\u200d💻dev",
+			shouldParse: true,
+		},
+		{
+			name:        "skin tone modifier",
+			input:       "#👍🏽 ",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "👍🏽",
+			shouldParse: true,
+		},
+		{
+			name:        "variation selector",
+			input:       "#❤\ufe0flove",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "❤\ufe0flove",
+			shouldParse: true,
+		},
+		{
+			name:        "flag",
+			input:       "#🇯🇵",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "🇯🇵",
+			shouldParse: true,
+		},
+		{
+			name:        "trailing joiner ends the tag",
+			input:       "#🔥\u200d ",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "🔥",
+			shouldParse: true,
+		},
+		{
+			name:        "joiner after a letter ends the tag",
+			input:       "#hot\u200d🔥",
+			symbols:     []*unicode.RangeTable{unicode.So},
+			expectedTag: "hot",
+			shouldParse: true,
+		},
+		{
+			name:        "other symbol tables",
+			input:       "#c++",
+			symbols:     []*unicode.RangeTable{unicode.Sm},
+			expectedTag: "c++",
+			shouldParse: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTagParser(WithTagSymbols(tt.symbols...))
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+
+			if tt.shouldParse {
+				require.NotNil(t, node, "Expected tag to be parsed")
+				tagNode, ok := node.(*mast.TagNode)
+				require.True(t, ok, "Expected node to be *mast.TagNode")
+				assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+			} else {
+				assert.Nil(t, node, "Expected tag NOT to be parsed")
+			}
+		})
+	}
+}
+
+func TestTagParser_Segments(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		expectedTag      string
+		expectedSegments []string
+	}{
+		{
+			name:             "flat tag",
+			input:            "#work",
+			expectedTag:      "work",
+			expectedSegments: []string{"work"},
+		},
+		{
+			name:             "nested tag",
+			input:            "#a/b/c",
+			expectedTag:      "a/b/c",
+			expectedSegments: []string{"a", "b", "c"},
+		},
+		{
+			name:             "leading slash",
+			input:            "#/foo",
+			expectedTag:      "/foo",
+			expectedSegments: []string{"foo"},
+		},
+		{
+			name:             "trailing slash",
+			input:            "#foo/ bar",
+			expectedTag:      "foo",
+			expectedSegments: []string{"foo"},
+		},
+		{
+			name:             "doubled slash",
+			input:            "#a//b",
+			expectedTag:      "a//b",
+			expectedSegments: []string{"a", "b"},
+		},
+		{
+			name:             "leading and doubled slashes",
+			input:            "#//a//b",
+			expectedTag:      "//a//b",
+			expectedSegments: []string{"a", "b"},
+		},
+		{
+			name:             "unicode segments",
+			input:            "#工作/项目",
+			expectedTag:      "工作/项目",
+			expectedSegments: []string{"工作", "项目"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTagParser()
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+			require.NotNil(t, node, "Expected tag to be parsed")
+			tagNode, ok := node.(*mast.TagNode)
+			require.True(t, ok, "Expected node to be *mast.TagNode")
+			assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+			assert.Equal(t, tt.expectedSegments, tagNode.Segments)
+		})
+	}
+}
+
+func TestTagParser_Bracketed(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		expectedTag      string
+		expectedSegments []string
+		shouldParse      bool
+	}{
+		{
+			name:             "multi-word tag",
+			input:            "#[project alpha] notes",
+			expectedTag:      "project alpha",
+			expectedSegments: []string{"project alpha"},
+			shouldParse:      true,
+		},
+		{
+			name:             "surrounding spaces are trimmed",
+			input:            "#[  spaced out  ]",
+			expectedTag:      "spaced out",
+			expectedSegments: []string{"spaced out"},
+			shouldParse:      true,
+		},
+		{
+			name:             "nested tag",
+			input:            "#[work/project alpha]",
+			expectedTag:      "work/project alpha",
+			expectedSegments: []string{"work", "project alpha"},
+			shouldParse:      true,
+		},
+		{
+			name:             "nested brackets",
+			input:            "#[a [b] c]d",
+			expectedTag:      "a [b] c",
+			expectedSegments: []string{"a [b] c"},
+			shouldParse:      true,
+		},
+		{
+			name:        "unterminated",
+			input:       "#[project alpha",
+			shouldParse: false,
+		},
+		{
+			name:        "closed on the next line",
+			input:       "#[project\nalpha]",
+			shouldParse: false,
+		},
+		{
+			name:        "empty",
+			input:       "#[ ]",
+			shouldParse: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTagParser()
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+
+			if tt.shouldParse {
+				require.NotNil(t, node, "Expected tag to be parsed")
+				tagNode, ok := node.(*mast.TagNode)
+				require.True(t, ok, "Expected node to be *mast.TagNode")
+				assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+				assert.Equal(t, tt.expectedSegments, tagNode.Segments)
+				assert.True(t, tagNode.Bracketed)
+			} else {
+				assert.Nil(t, node, "Expected tag NOT to be parsed")
+			}
+		})
+	}
+}
+
+func TestTagParser_ExtraChars(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		extraChars  []rune
+		expectedTag string
+		shouldParse bool
+	}{
+		{
+			name:        "dot ends the tag by default",
+			input:       "#v1.2",
+			expectedTag: "v1",
+			shouldParse: true,
+		},
+		{
+			name:        "colon ends the tag by default",
+			input:       "#ns:scope",
+			expectedTag: "ns",
+			shouldParse: true,
+		},
+		{
+			name:        "version tag",
+			input:       "#v1.2 released",
+			extraChars:  []rune{'.', ':'},
+			expectedTag: "v1.2",
+			shouldParse: true,
+		},
+		{
+			name:        "namespaced tag",
+			input:       "#ns:scope",
+			extraChars:  []rune{'.', ':'},
+			expectedTag: "ns:scope",
+			shouldParse: true,
+		},
+		{
+			name:        "trailing punctuation is not part of the tag",
+			input:       "#v1.2. Next",
+			extraChars:  []rune{'.', ':'},
+			expectedTag: "v1.2",
+			shouldParse: true,
+		},
+		{
+			name:        "unicode letters still work",
+			input:       "#版本:二",
+			extraChars:  []rune{':'},
+			expectedTag: "版本:二",
+			shouldParse: true,
+		},
+		{
+			name:        "extra characters alone are not a tag",
+			input:       "#...",
+			extraChars:  []rune{'.'},
+			shouldParse: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTagParser(WithExtraTagChars(tt.extraChars...))
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+
+			if tt.shouldParse {
+				require.NotNil(t, node, "Expected tag to be parsed")
+				tagNode, ok := node.(*mast.TagNode)
+				require.True(t, ok, "Expected node to be *mast.TagNode")
+				assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+			} else {
+				assert.Nil(t, node, "Expected tag NOT to be parsed")
+			}
+		})
+	}
+}
+
+func TestTagParser_WithoutNumericTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedTag string
+		shouldParse bool
+	}{
+		{name: "digits only", input: "#123", shouldParse: false},
+		{name: "digits only before punctuation", input: "#1234, see", shouldParse: false},
+		{name: "digit first", input: "#1a", expectedTag: "1a", shouldParse: true},
+		{name: "letter first", input: "#a1", expectedTag: "a1", shouldParse: true},
+		{name: "version", input: "#v2", expectedTag: "v2", shouldParse: true},
+		{name: "year prefix", input: "#2024plan", expectedTag: "2024plan", shouldParse: true},
+		{name: "hierarchical", input: "#2024/01", expectedTag: "2024/01", shouldParse: true},
+		{name: "bracketed", input: "#[123]", expectedTag: "123", shouldParse: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTagParser(WithoutNumericTags())
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+
+			if tt.shouldParse {
+				require.NotNil(t, node, "Expected tag to be parsed")
+				tagNode, ok := node.(*mast.TagNode)
+				require.True(t, ok, "Expected node to be *mast.TagNode")
+				assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+			} else {
+				assert.Nil(t, node, "Expected tag NOT to be parsed")
+			}
+		})
+	}
+
+	// Numeric tags are parsed by default.
+	node := NewTagParser().Parse(nil, text.NewReader([]byte("#123")), parser.NewContext())
+	require.NotNil(t, node)
+	assert.Equal(t, "123", string(node.(*mast.TagNode).Tag))
+}
+
+func TestTagParser_KnownTags(t *testing.T) {
+	known := map[string]struct{}{"work": {}, "Project": {}, "team/alpha": {}, "project alpha": {}}
+	tests := []struct {
+		name        string
+		input       string
+		foldCase    bool
+		expectedTag string
+		shouldParse bool
+	}{
+		{name: "known tag", input: "#work", expectedTag: "work", shouldParse: true},
+		{name: "unknown tag", input: "#wrok", shouldParse: false},
+		{name: "case differs", input: "#Work", shouldParse: false},
+		{name: "case differs ignoring case", input: "#Work", foldCase: true, expectedTag: "Work", shouldParse: true},
+		{name: "known tag with other case ignoring case", input: "#project", foldCase: true, expectedTag: "project", shouldParse: true},
+		{name: "known hierarchical tag", input: "#team/alpha", expectedTag: "team/alpha", shouldParse: true},
+		{name: "parent of known tag", input: "#team", shouldParse: false},
+		{name: "known bracketed tag", input: "#[project alpha]", expectedTag: "project alpha", shouldParse: true},
+		{name: "unknown bracketed tag", input: "#[project beta]", shouldParse: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []TagParserOption{WithKnownTags(known)}
+			if tt.foldCase {
+				opts = append(opts, WithCaseInsensitiveKnownTags())
+			}
+			p := NewTagParser(opts...)
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+
+			if tt.shouldParse {
+				require.NotNil(t, node, "Expected tag to be parsed")
+				tagNode, ok := node.(*mast.TagNode)
+				require.True(t, ok, "Expected node to be *mast.TagNode")
+				assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+			} else {
+				assert.Nil(t, node, "Expected tag NOT to be parsed")
+			}
+		})
+	}
+
+	// The set of the caller is left untouched by case-insensitive matching.
+	NewTagParser(WithKnownTags(known), WithCaseInsensitiveKnownTags())
+	assert.Contains(t, known, "Project")
+	assert.NotContains(t, known, "project")
+
+	// Without a set, every tag is parsed.
+	node := NewTagParser(WithKnownTags(nil)).Parse(nil, text.NewReader([]byte("#anything")), parser.NewContext())
+	require.NotNil(t, node)
+}
+
+func TestTagParser_Boundaries(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedTag string
+		shouldParse bool
+	}{
+		{name: "period", input: "#golang.", expectedTag: "golang", shouldParse: true},
+		{name: "exclamation mark", input: "#tag!", expectedTag: "tag", shouldParse: true},
+		{name: "question mark", input: "#tag?", expectedTag: "tag", shouldParse: true},
+		{name: "comma", input: "#tag, more", expectedTag: "tag", shouldParse: true},
+		{name: "closing parenthesis", input: "#tag)", expectedTag: "tag", shouldParse: true},
+		{name: "closing quote", input: "#tag\"", expectedTag: "tag", shouldParse: true},
+		{name: "apostrophe", input: "#golang's docs", expectedTag: "golang", shouldParse: true},
+		{name: "trailing slash", input: "#work/", expectedTag: "work", shouldParse: true},
+		{name: "trailing slashes", input: "#work//)", expectedTag: "work", shouldParse: true},
+		{name: "nested tag with trailing slash", input: "#work/project/.", expectedTag: "work/project", shouldParse: true},
+		{name: "only slashes", input: "#//", shouldParse: false},
+		{name: "trailing hyphen is kept", input: "#work-", expectedTag: "work-", shouldParse: true},
+		{name: "attached combining mark is kept", input: "#cafe\u0301.", expectedTag: "cafe\u0301", shouldParse: true},
+		{name: "combining mark after slash", input: "#work/\u0301", expectedTag: "work", shouldParse: true},
+		{name: "combining mark after hyphen", input: "#work-\u0301x", expectedTag: "work-", shouldParse: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTagParser()
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+
+			if tt.shouldParse {
+				require.NotNil(t, node, "Expected tag to be parsed")
+				tagNode, ok := node.(*mast.TagNode)
+				require.True(t, ok, "Expected node to be *mast.TagNode")
+				assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+			} else {
+				assert.Nil(t, node, "Expected tag NOT to be parsed")
+			}
+		})
+	}
+}
+
 func TestTagParser_Trigger(t *testing.T) {
 	p := NewTagParser()
 	triggers := p.Trigger()
@@ -243,6 +747,72 @@ func TestTagParser_MultipleTags(t *testing.T) {
 	assert.Equal(t, "tag2", string(tagNode2.Tag))
 }
 
+func TestTagParser_EndOfInput(t *testing.T) {
+	// The reader must stop exactly at the end of the tag, never consuming the line ending.
+	tests := []struct {
+		name          string
+		input         string
+		expectedTag   string
+		shouldParse   bool
+		remainingLine string
+	}{
+		{
+			name:          "tag at end of input",
+			input:         "#tag",
+			expectedTag:   "tag",
+			shouldParse:   true,
+			remainingLine: "",
+		},
+		{
+			name:          "tag followed by newline",
+			input:         "#tag\n",
+			expectedTag:   "tag",
+			shouldParse:   true,
+			remainingLine: "\n",
+		},
+		{
+			name:          "multi-byte tag at end of input",
+			input:         "#标签",
+			expectedTag:   "标签",
+			shouldParse:   true,
+			remainingLine: "",
+		},
+		{
+			name:          "lone hash at end of input",
+			input:         "#",
+			shouldParse:   false,
+			remainingLine: "#",
+		},
+		{
+			name:          "lone hash followed by newline",
+			input:         "#\n",
+			shouldParse:   false,
+			remainingLine: "#\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTagParser()
+			reader := text.NewReader([]byte(tt.input))
+			ctx := parser.NewContext()
+
+			node := p.Parse(nil, reader, ctx)
+
+			if tt.shouldParse {
+				tagNode, ok := node.(*mast.TagNode)
+				require.True(t, ok, "Expected node to be *mast.TagNode")
+				assert.Equal(t, tt.expectedTag, string(tagNode.Tag))
+			} else {
+				assert.Nil(t, node, "Expected tag NOT to be parsed")
+			}
+
+			line, _ := reader.PeekLine()
+			assert.Equal(t, tt.remainingLine, string(line))
+		})
+	}
+}
+
 func TestTagNode_Kind(t *testing.T) {
 	node := &mast.TagNode{
 		Tag: []byte("test"),
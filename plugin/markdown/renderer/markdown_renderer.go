@@ -153,8 +153,7 @@ func (r *MarkdownRenderer) renderNode(node gast.Node, source []byte, depth int)
 
 	// Custom Memos nodes
 	case *mast.TagNode:
-		r.buf.WriteByte('#')
-		r.buf.Write(n.Tag)
+		r.buf.WriteString(RenderTag(n))
 
 	default:
 		// For unknown nodes, try to render children
@@ -162,6 +161,15 @@ func (r *MarkdownRenderer) renderNode(node gast.Node, source []byte, depth int)
 	}
 }
 
+// RenderTag returns the markdown of a tag node, e.g. "#work/project". Bracketed tags and tags
+// containing whitespace, which the plain form can't hold, are rendered as "#[project alpha]".
+func RenderTag(node *mast.TagNode) string {
+	if node.Bracketed || bytes.ContainsAny(node.Tag, " \t") {
+		return "#[" + string(node.Tag) + "]"
+	}
+	return "#" + string(node.Tag)
+}
+
 // renderChildren renders all children of a node.
 func (r *MarkdownRenderer) renderChildren(node gast.Node, source []byte, depth int) {
 	child := node.FirstChild()
@@ -6,10 +6,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yuin/goldmark"
+	gast "github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
 
+	mast "github.com/usememos/memos/plugin/markdown/ast"
 	"github.com/usememos/memos/plugin/markdown/extensions"
 )
 
@@ -110,6 +112,11 @@ func TestMarkdownRenderer(t *testing.T) {
 			input:    "#work #important meeting notes",
 			expected: "#work #important meeting notes",
 		},
+		{
+			name:     "bracketed tag",
+			input:    "Notes for #[project alpha] and #[beta]",
+			expected: "Notes for #[project alpha] and #[beta]",
+		},
 		{
 			name:     "complex mixed content",
 			input:    "# Meeting Notes\n\n**Date**: 2024-01-01\n\n## Attendees\n- Alice\n- Bob\n\n## Discussion\n\nWe discussed #project status.\n\n```python\nprint('hello')\n```",
@@ -174,3 +181,43 @@ func TestMarkdownRendererPreservesStructure(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderTag(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extensions.TagExtension,
+		),
+	)
+
+	// Each tag renders back to the markdown it was parsed from.
+	inputs := []string{
+		"#work",
+		"#work/project/alpha",
+		"#日本語",
+		"#2024-plan_v2",
+		"#[project alpha]",
+		"#[beta]",
+		"#[a [nested] tag]",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			source := []byte(input)
+			doc := md.Parser().Parse(text.NewReader(source))
+
+			var tags []*mast.TagNode
+			require.NoError(t, gast.Walk(doc, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
+				if tag, ok := n.(*mast.TagNode); ok && entering {
+					tags = append(tags, tag)
+				}
+				return gast.WalkContinue, nil
+			}))
+			require.Len(t, tags, 1)
+			assert.Equal(t, input, RenderTag(tags[0]))
+			assert.Equal(t, input, NewMarkdownRenderer().Render(doc, source))
+		})
+	}
+
+	// Tags with whitespace can only be written bracketed.
+	assert.Equal(t, "#[project alpha]", RenderTag(&mast.TagNode{Tag: []byte("project alpha")}))
+}
@@ -477,6 +477,116 @@ func (x *UpdateInstanceSettingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	return nil
 }
 
+// Request to sanitize invalid UTF-8 in the database.
+type SanitizeDatabaseUTF8Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If true, invalid memos are counted but not changed.
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SanitizeDatabaseUTF8Request) Reset() {
+	*x = SanitizeDatabaseUTF8Request{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SanitizeDatabaseUTF8Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizeDatabaseUTF8Request) ProtoMessage() {}
+
+func (x *SanitizeDatabaseUTF8Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizeDatabaseUTF8Request.ProtoReflect.Descriptor instead.
+func (*SanitizeDatabaseUTF8Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SanitizeDatabaseUTF8Request) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// Response of sanitizing invalid UTF-8 in the database.
+type SanitizeDatabaseUTF8Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of memos scanned.
+	ScannedCount int32 `protobuf:"varint,1,opt,name=scanned_count,json=scannedCount,proto3" json:"scanned_count,omitempty"`
+	// The number of memos with invalid UTF-8.
+	InvalidCount int32 `protobuf:"varint,2,opt,name=invalid_count,json=invalidCount,proto3" json:"invalid_count,omitempty"`
+	// The number of memos fixed. Zero for a dry run.
+	FixedCount    int32 `protobuf:"varint,3,opt,name=fixed_count,json=fixedCount,proto3" json:"fixed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SanitizeDatabaseUTF8Response) Reset() {
+	*x = SanitizeDatabaseUTF8Response{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SanitizeDatabaseUTF8Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizeDatabaseUTF8Response) ProtoMessage() {}
+
+func (x *SanitizeDatabaseUTF8Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizeDatabaseUTF8Response.ProtoReflect.Descriptor instead.
+func (*SanitizeDatabaseUTF8Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SanitizeDatabaseUTF8Response) GetScannedCount() int32 {
+	if x != nil {
+		return x.ScannedCount
+	}
+	return 0
+}
+
+func (x *SanitizeDatabaseUTF8Response) GetInvalidCount() int32 {
+	if x != nil {
+		return x.InvalidCount
+	}
+	return 0
+}
+
+func (x *SanitizeDatabaseUTF8Response) GetFixedCount() int32 {
+	if x != nil {
+		return x.FixedCount
+	}
+	return 0
+}
+
 // General instance settings configuration.
 type InstanceSetting_GeneralSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -507,7 +617,7 @@ type InstanceSetting_GeneralSetting struct {
 
 func (x *InstanceSetting_GeneralSetting) Reset() {
 	*x = InstanceSetting_GeneralSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[5]
+	mi := &file_api_v1_instance_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -519,7 +629,7 @@ func (x *InstanceSetting_GeneralSetting) String() string {
 func (*InstanceSetting_GeneralSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_GeneralSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[5]
+	mi := &file_api_v1_instance_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -616,7 +726,7 @@ type InstanceSetting_StorageSetting struct {
 
 func (x *InstanceSetting_StorageSetting) Reset() {
 	*x = InstanceSetting_StorageSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[6]
+	mi := &file_api_v1_instance_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -628,7 +738,7 @@ func (x *InstanceSetting_StorageSetting) String() string {
 func (*InstanceSetting_StorageSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_StorageSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[6]
+	mi := &file_api_v1_instance_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,14 +802,19 @@ type InstanceSetting_MemoRelatedSetting struct {
 	// enable_blur_nsfw_content enables blurring of content marked as not safe for work (NSFW).
 	EnableBlurNsfwContent bool `protobuf:"varint,9,opt,name=enable_blur_nsfw_content,json=enableBlurNsfwContent,proto3" json:"enable_blur_nsfw_content,omitempty"`
 	// nsfw_tags is the list of tags that mark content as NSFW for blurring.
-	NsfwTags      []string `protobuf:"bytes,10,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	NsfwTags []string `protobuf:"bytes,10,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
+	// tag_locale is a BCP 47 language tag for language-specific tag case mapping, e.g. "tr".
+	// Default: "" (language-neutral)
+	TagLocale string `protobuf:"bytes,11,opt,name=tag_locale,json=tagLocale,proto3" json:"tag_locale,omitempty"`
+	// preserve_tag_case keeps the original case of tags instead of lowercasing them.
+	PreserveTagCase bool `protobuf:"varint,12,opt,name=preserve_tag_case,json=preserveTagCase,proto3" json:"preserve_tag_case,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *InstanceSetting_MemoRelatedSetting) Reset() {
 	*x = InstanceSetting_MemoRelatedSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[7]
+	mi := &file_api_v1_instance_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -711,7 +826,7 @@ func (x *InstanceSetting_MemoRelatedSetting) String() string {
 func (*InstanceSetting_MemoRelatedSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_MemoRelatedSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[7]
+	mi := &file_api_v1_instance_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -790,19 +905,74 @@ func (x *InstanceSetting_MemoRelatedSetting) GetNsfwTags() []string {
 	return nil
 }
 
+func (x *InstanceSetting_MemoRelatedSetting) GetTagLocale() string {
+	if x != nil {
+		return x.TagLocale
+	}
+	return ""
+}
+
+func (x *InstanceSetting_MemoRelatedSetting) GetPreserveTagCase() bool {
+	if x != nil {
+		return x.PreserveTagCase
+	}
+	return false
+}
+
 // AI-related instance settings configuration.
 type InstanceSetting_AiSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// ai_service_url is the URL of the AI service.
 	// Default: http://127.0.0.1:8000
-	AiServiceUrl  string `protobuf:"bytes,1,opt,name=ai_service_url,json=aiServiceUrl,proto3" json:"ai_service_url,omitempty"`
+	AiServiceUrl string `protobuf:"bytes,1,opt,name=ai_service_url,json=aiServiceUrl,proto3" json:"ai_service_url,omitempty"`
+	// exclude_public_memos excludes memos with public visibility from AI indexing.
+	ExcludePublicMemos bool `protobuf:"varint,2,opt,name=exclude_public_memos,json=excludePublicMemos,proto3" json:"exclude_public_memos,omitempty"`
+	// tag_cache_ttl_seconds is how long a user's tag set is cached for AI tag generation.
+	// Default: 60
+	TagCacheTtlSeconds int32 `protobuf:"varint,3,opt,name=tag_cache_ttl_seconds,json=tagCacheTtlSeconds,proto3" json:"tag_cache_ttl_seconds,omitempty"`
+	// max_attachment_size_mb is the max size of a local attachment embedded in AI requests.
+	// Larger attachments are sent with metadata only. Default: 5
+	MaxAttachmentSizeMb int64 `protobuf:"varint,4,opt,name=max_attachment_size_mb,json=maxAttachmentSizeMb,proto3" json:"max_attachment_size_mb,omitempty"`
+	// index_delete_grace_period_seconds defers deleting the index of a deleted memo, so it is kept
+	// if the memo is restored within the period. Default: 0 (delete immediately)
+	IndexDeleteGracePeriodSeconds int32 `protobuf:"varint,5,opt,name=index_delete_grace_period_seconds,json=indexDeleteGracePeriodSeconds,proto3" json:"index_delete_grace_period_seconds,omitempty"`
+	// ai_requests_per_minute limits the GenerateAiTags and AiSearch requests of each user other than
+	// admins and the host, per method. Default: 0 (unlimited)
+	AiRequestsPerMinute int32 `protobuf:"varint,6,opt,name=ai_requests_per_minute,json=aiRequestsPerMinute,proto3" json:"ai_requests_per_minute,omitempty"`
+	// max_content_chars is the max number of characters of memo content sent to the AI service for
+	// tag generation and indexing. Longer content is truncated from the end. Default: 20000
+	MaxContentChars int32 `protobuf:"varint,7,opt,name=max_content_chars,json=maxContentChars,proto3" json:"max_content_chars,omitempty"`
+	// fold_tag_case treats tags differing only in case, e.g. "Work" and "work", as one tag when collecting
+	// a user's tags for AI tag generation, keeping the most used casing. Suggested tags are matched the
+	// same way. Default: false (tags are case-sensitive)
+	FoldTagCase bool `protobuf:"varint,8,opt,name=fold_tag_case,json=foldTagCase,proto3" json:"fold_tag_case,omitempty"`
+	// max_attachments is the max number of attachments of a memo sent to the AI service. Images are
+	// kept first when a memo has more. Default: 10
+	MaxAttachments int32 `protobuf:"varint,9,opt,name=max_attachments,json=maxAttachments,proto3" json:"max_attachments,omitempty"`
+	// allow_internal_callback_urls allows rebuild callback URLs pointing to loopback, private or
+	// link-local addresses, e.g. when the AI service and the callback receiver share a private network.
+	// Default: false
+	AllowInternalCallbackUrls bool `protobuf:"varint,10,opt,name=allow_internal_callback_urls,json=allowInternalCallbackUrls,proto3" json:"allow_internal_callback_urls,omitempty"`
+	// default_search_top_k is the max number of results of AI searches not specifying one.
+	// Default: 0 (10 results)
+	DefaultSearchTopK int32 `protobuf:"varint,11,opt,name=default_search_top_k,json=defaultSearchTopK,proto3" json:"default_search_top_k,omitempty"`
+	// default_search_min_score is the min score of AI searches not specifying one.
+	// Default: 0 (0.5)
+	DefaultSearchMinScore float32 `protobuf:"fixed32,12,opt,name=default_search_min_score,json=defaultSearchMinScore,proto3" json:"default_search_min_score,omitempty"`
+	// default_search_mode is the search mode of AI searches not specifying one, one of those listed by
+	// ListAiSearchModes. Default: empty ("hybrid")
+	DefaultSearchMode string `protobuf:"bytes,13,opt,name=default_search_mode,json=defaultSearchMode,proto3" json:"default_search_mode,omitempty"`
+	// index_mode is what of a memo is sent to the AI service for indexing: "both" the text and the
+	// attachments, "text_only" without attachment data, e.g. for AI services without an image model,
+	// or "images_only" only image attachments. Default: empty ("both")
+	IndexMode     string `protobuf:"bytes,14,opt,name=index_mode,json=indexMode,proto3" json:"index_mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InstanceSetting_AiSetting) Reset() {
 	*x = InstanceSetting_AiSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	mi := &file_api_v1_instance_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -814,7 +984,7 @@ func (x *InstanceSetting_AiSetting) String() string {
 func (*InstanceSetting_AiSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_AiSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	mi := &file_api_v1_instance_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -837,6 +1007,97 @@ func (x *InstanceSetting_AiSetting) GetAiServiceUrl() string {
 	return ""
 }
 
+func (x *InstanceSetting_AiSetting) GetExcludePublicMemos() bool {
+	if x != nil {
+		return x.ExcludePublicMemos
+	}
+	return false
+}
+
+func (x *InstanceSetting_AiSetting) GetTagCacheTtlSeconds() int32 {
+	if x != nil {
+		return x.TagCacheTtlSeconds
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetMaxAttachmentSizeMb() int64 {
+	if x != nil {
+		return x.MaxAttachmentSizeMb
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetIndexDeleteGracePeriodSeconds() int32 {
+	if x != nil {
+		return x.IndexDeleteGracePeriodSeconds
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetAiRequestsPerMinute() int32 {
+	if x != nil {
+		return x.AiRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetMaxContentChars() int32 {
+	if x != nil {
+		return x.MaxContentChars
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetFoldTagCase() bool {
+	if x != nil {
+		return x.FoldTagCase
+	}
+	return false
+}
+
+func (x *InstanceSetting_AiSetting) GetMaxAttachments() int32 {
+	if x != nil {
+		return x.MaxAttachments
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetAllowInternalCallbackUrls() bool {
+	if x != nil {
+		return x.AllowInternalCallbackUrls
+	}
+	return false
+}
+
+func (x *InstanceSetting_AiSetting) GetDefaultSearchTopK() int32 {
+	if x != nil {
+		return x.DefaultSearchTopK
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetDefaultSearchMinScore() float32 {
+	if x != nil {
+		return x.DefaultSearchMinScore
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AiSetting) GetDefaultSearchMode() string {
+	if x != nil {
+		return x.DefaultSearchMode
+	}
+	return ""
+}
+
+func (x *InstanceSetting_AiSetting) GetIndexMode() string {
+	if x != nil {
+		return x.IndexMode
+	}
+	return ""
+}
+
 // Custom profile configuration for instance branding.
 type InstanceSetting_GeneralSetting_CustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -850,7 +1111,7 @@ type InstanceSetting_GeneralSetting_CustomProfile struct {
 
 func (x *InstanceSetting_GeneralSetting_CustomProfile) Reset() {
 	*x = InstanceSetting_GeneralSetting_CustomProfile{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	mi := &file_api_v1_instance_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -862,7 +1123,7 @@ func (x *InstanceSetting_GeneralSetting_CustomProfile) String() string {
 func (*InstanceSetting_GeneralSetting_CustomProfile) ProtoMessage() {}
 
 func (x *InstanceSetting_GeneralSetting_CustomProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	mi := &file_api_v1_instance_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -922,7 +1183,7 @@ type InstanceSetting_StorageSetting_S3Config struct {
 
 func (x *InstanceSetting_StorageSetting_S3Config) Reset() {
 	*x = InstanceSetting_StorageSetting_S3Config{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[10]
+	mi := &file_api_v1_instance_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -934,7 +1195,7 @@ func (x *InstanceSetting_StorageSetting_S3Config) String() string {
 func (*InstanceSetting_StorageSetting_S3Config) ProtoMessage() {}
 
 func (x *InstanceSetting_StorageSetting_S3Config) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[10]
+	mi := &file_api_v1_instance_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1002,7 +1263,7 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
 	"\x04mode\x18\x03 \x01(\tR\x04mode\x12!\n" +
 	"\finstance_url\x18\x06 \x01(\tR\vinstanceUrl\"\x1b\n" +
-	"\x19GetInstanceProfileRequest\"\x90\x12\n" +
+	"\x19GetInstanceProfileRequest\"\xe8\x17\n" +
 	"\x0fInstanceSetting\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12W\n" +
 	"\x0fgeneral_setting\x18\x02 \x01(\v2,.memos.api.v1.InstanceSetting.GeneralSettingH\x00R\x0egeneralSetting\x12W\n" +
@@ -1041,7 +1302,7 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\x1a\xd8\x03\n" +
+	"\x02S3\x10\x03\x1a\xa3\x04\n" +
 	"\x12MemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -1052,9 +1313,27 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\x1adisable_markdown_shortcuts\x18\b \x01(\bR\x18disableMarkdownShortcuts\x127\n" +
 	"\x18enable_blur_nsfw_content\x18\t \x01(\bR\x15enableBlurNsfwContent\x12\x1b\n" +
 	"\tnsfw_tags\x18\n" +
-	" \x03(\tR\bnsfwTags\x1a1\n" +
+	" \x03(\tR\bnsfwTags\x12\x1d\n" +
+	"\n" +
+	"tag_locale\x18\v \x01(\tR\ttagLocale\x12*\n" +
+	"\x11preserve_tag_case\x18\f \x01(\bR\x0fpreserveTagCase\x1a\xbd\x05\n" +
 	"\tAiSetting\x12$\n" +
-	"\x0eai_service_url\x18\x01 \x01(\tR\faiServiceUrl\"N\n" +
+	"\x0eai_service_url\x18\x01 \x01(\tR\faiServiceUrl\x120\n" +
+	"\x14exclude_public_memos\x18\x02 \x01(\bR\x12excludePublicMemos\x121\n" +
+	"\x15tag_cache_ttl_seconds\x18\x03 \x01(\x05R\x12tagCacheTtlSeconds\x123\n" +
+	"\x16max_attachment_size_mb\x18\x04 \x01(\x03R\x13maxAttachmentSizeMb\x12H\n" +
+	"!index_delete_grace_period_seconds\x18\x05 \x01(\x05R\x1dindexDeleteGracePeriodSeconds\x123\n" +
+	"\x16ai_requests_per_minute\x18\x06 \x01(\x05R\x13aiRequestsPerMinute\x12*\n" +
+	"\x11max_content_chars\x18\a \x01(\x05R\x0fmaxContentChars\x12\"\n" +
+	"\rfold_tag_case\x18\b \x01(\bR\vfoldTagCase\x12'\n" +
+	"\x0fmax_attachments\x18\t \x01(\x05R\x0emaxAttachments\x12?\n" +
+	"\x1callow_internal_callback_urls\x18\n" +
+	" \x01(\bR\x19allowInternalCallbackUrls\x12/\n" +
+	"\x14default_search_top_k\x18\v \x01(\x05R\x11defaultSearchTopK\x127\n" +
+	"\x18default_search_min_score\x18\f \x01(\x02R\x15defaultSearchMinScore\x12.\n" +
+	"\x13default_search_mode\x18\r \x01(\tR\x11defaultSearchMode\x12\x1d\n" +
+	"\n" +
+	"index_mode\x18\x0e \x01(\tR\tindexMode\"N\n" +
 	"\x03Key\x12\x13\n" +
 	"\x0fKEY_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aGENERAL\x10\x01\x12\v\n" +
@@ -1069,11 +1348,19 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\x1cUpdateInstanceSettingRequest\x12<\n" +
 	"\asetting\x18\x01 \x01(\v2\x1d.memos.api.v1.InstanceSettingB\x03\xe0A\x02R\asetting\x12@\n" +
 	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\n" +
-	"updateMask2\xdb\x03\n" +
+	"updateMask\";\n" +
+	"\x1bSanitizeDatabaseUTF8Request\x12\x1c\n" +
+	"\adry_run\x18\x01 \x01(\bB\x03\xe0A\x01R\x06dryRun\"\x89\x01\n" +
+	"\x1cSanitizeDatabaseUTF8Response\x12#\n" +
+	"\rscanned_count\x18\x01 \x01(\x05R\fscannedCount\x12#\n" +
+	"\rinvalid_count\x18\x02 \x01(\x05R\finvalidCount\x12\x1f\n" +
+	"\vfixed_count\x18\x03 \x01(\x05R\n" +
+	"fixedCount2\xf5\x04\n" +
 	"\x0fInstanceService\x12~\n" +
 	"\x12GetInstanceProfile\x12'.memos.api.v1.GetInstanceProfileRequest\x1a\x1d.memos.api.v1.InstanceProfile\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/instance/profile\x12\x8f\x01\n" +
 	"\x12GetInstanceSetting\x12'.memos.api.v1.GetInstanceSettingRequest\x1a\x1d.memos.api.v1.InstanceSetting\"1\xdaA\x04name\x82\xd3\xe4\x93\x02$\x12\"/api/v1/{name=instance/settings/*}\x12\xb5\x01\n" +
-	"\x15UpdateInstanceSetting\x12*.memos.api.v1.UpdateInstanceSettingRequest\x1a\x1d.memos.api.v1.InstanceSetting\"Q\xdaA\x13setting,update_mask\x82\xd3\xe4\x93\x025:\asetting2*/api/v1/{setting.name=instance/settings/*}B\xac\x01\n" +
+	"\x15UpdateInstanceSetting\x12*.memos.api.v1.UpdateInstanceSettingRequest\x1a\x1d.memos.api.v1.InstanceSetting\"Q\xdaA\x13setting,update_mask\x82\xd3\xe4\x93\x025:\asetting2*/api/v1/{setting.name=instance/settings/*}\x12\x97\x01\n" +
+	"\x14SanitizeDatabaseUTF8\x12).memos.api.v1.SanitizeDatabaseUTF8Request\x1a*.memos.api.v1.SanitizeDatabaseUTF8Response\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/instance:sanitizeUtf8B\xac\x01\n" +
 	"\x10com.memos.api.v1B\x14InstanceServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -1089,7 +1376,7 @@ func file_api_v1_instance_service_proto_rawDescGZIP() []byte {
 }
 
 var file_api_v1_instance_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_v1_instance_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_v1_instance_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_api_v1_instance_service_proto_goTypes = []any{
 	(InstanceSetting_Key)(0),                             // 0: memos.api.v1.InstanceSetting.Key
 	(InstanceSetting_StorageSetting_StorageType)(0),      // 1: memos.api.v1.InstanceSetting.StorageSetting.StorageType
@@ -1098,32 +1385,36 @@ var file_api_v1_instance_service_proto_goTypes = []any{
 	(*InstanceSetting)(nil),                              // 4: memos.api.v1.InstanceSetting
 	(*GetInstanceSettingRequest)(nil),                    // 5: memos.api.v1.GetInstanceSettingRequest
 	(*UpdateInstanceSettingRequest)(nil),                 // 6: memos.api.v1.UpdateInstanceSettingRequest
-	(*InstanceSetting_GeneralSetting)(nil),               // 7: memos.api.v1.InstanceSetting.GeneralSetting
-	(*InstanceSetting_StorageSetting)(nil),               // 8: memos.api.v1.InstanceSetting.StorageSetting
-	(*InstanceSetting_MemoRelatedSetting)(nil),           // 9: memos.api.v1.InstanceSetting.MemoRelatedSetting
-	(*InstanceSetting_AiSetting)(nil),                    // 10: memos.api.v1.InstanceSetting.AiSetting
-	(*InstanceSetting_GeneralSetting_CustomProfile)(nil), // 11: memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
-	(*InstanceSetting_StorageSetting_S3Config)(nil),      // 12: memos.api.v1.InstanceSetting.StorageSetting.S3Config
-	(*fieldmaskpb.FieldMask)(nil),                        // 13: google.protobuf.FieldMask
+	(*SanitizeDatabaseUTF8Request)(nil),                  // 7: memos.api.v1.SanitizeDatabaseUTF8Request
+	(*SanitizeDatabaseUTF8Response)(nil),                 // 8: memos.api.v1.SanitizeDatabaseUTF8Response
+	(*InstanceSetting_GeneralSetting)(nil),               // 9: memos.api.v1.InstanceSetting.GeneralSetting
+	(*InstanceSetting_StorageSetting)(nil),               // 10: memos.api.v1.InstanceSetting.StorageSetting
+	(*InstanceSetting_MemoRelatedSetting)(nil),           // 11: memos.api.v1.InstanceSetting.MemoRelatedSetting
+	(*InstanceSetting_AiSetting)(nil),                    // 12: memos.api.v1.InstanceSetting.AiSetting
+	(*InstanceSetting_GeneralSetting_CustomProfile)(nil), // 13: memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
+	(*InstanceSetting_StorageSetting_S3Config)(nil),      // 14: memos.api.v1.InstanceSetting.StorageSetting.S3Config
+	(*fieldmaskpb.FieldMask)(nil),                        // 15: google.protobuf.FieldMask
 }
 var file_api_v1_instance_service_proto_depIdxs = []int32{
-	7,  // 0: memos.api.v1.InstanceSetting.general_setting:type_name -> memos.api.v1.InstanceSetting.GeneralSetting
-	8,  // 1: memos.api.v1.InstanceSetting.storage_setting:type_name -> memos.api.v1.InstanceSetting.StorageSetting
-	9,  // 2: memos.api.v1.InstanceSetting.memo_related_setting:type_name -> memos.api.v1.InstanceSetting.MemoRelatedSetting
-	10, // 3: memos.api.v1.InstanceSetting.ai_setting:type_name -> memos.api.v1.InstanceSetting.AiSetting
+	9,  // 0: memos.api.v1.InstanceSetting.general_setting:type_name -> memos.api.v1.InstanceSetting.GeneralSetting
+	10, // 1: memos.api.v1.InstanceSetting.storage_setting:type_name -> memos.api.v1.InstanceSetting.StorageSetting
+	11, // 2: memos.api.v1.InstanceSetting.memo_related_setting:type_name -> memos.api.v1.InstanceSetting.MemoRelatedSetting
+	12, // 3: memos.api.v1.InstanceSetting.ai_setting:type_name -> memos.api.v1.InstanceSetting.AiSetting
 	4,  // 4: memos.api.v1.UpdateInstanceSettingRequest.setting:type_name -> memos.api.v1.InstanceSetting
-	13, // 5: memos.api.v1.UpdateInstanceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
-	11, // 6: memos.api.v1.InstanceSetting.GeneralSetting.custom_profile:type_name -> memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
+	15, // 5: memos.api.v1.UpdateInstanceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	13, // 6: memos.api.v1.InstanceSetting.GeneralSetting.custom_profile:type_name -> memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
 	1,  // 7: memos.api.v1.InstanceSetting.StorageSetting.storage_type:type_name -> memos.api.v1.InstanceSetting.StorageSetting.StorageType
-	12, // 8: memos.api.v1.InstanceSetting.StorageSetting.s3_config:type_name -> memos.api.v1.InstanceSetting.StorageSetting.S3Config
+	14, // 8: memos.api.v1.InstanceSetting.StorageSetting.s3_config:type_name -> memos.api.v1.InstanceSetting.StorageSetting.S3Config
 	3,  // 9: memos.api.v1.InstanceService.GetInstanceProfile:input_type -> memos.api.v1.GetInstanceProfileRequest
 	5,  // 10: memos.api.v1.InstanceService.GetInstanceSetting:input_type -> memos.api.v1.GetInstanceSettingRequest
 	6,  // 11: memos.api.v1.InstanceService.UpdateInstanceSetting:input_type -> memos.api.v1.UpdateInstanceSettingRequest
-	2,  // 12: memos.api.v1.InstanceService.GetInstanceProfile:output_type -> memos.api.v1.InstanceProfile
-	4,  // 13: memos.api.v1.InstanceService.GetInstanceSetting:output_type -> memos.api.v1.InstanceSetting
-	4,  // 14: memos.api.v1.InstanceService.UpdateInstanceSetting:output_type -> memos.api.v1.InstanceSetting
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
+	7,  // 12: memos.api.v1.InstanceService.SanitizeDatabaseUTF8:input_type -> memos.api.v1.SanitizeDatabaseUTF8Request
+	2,  // 13: memos.api.v1.InstanceService.GetInstanceProfile:output_type -> memos.api.v1.InstanceProfile
+	4,  // 14: memos.api.v1.InstanceService.GetInstanceSetting:output_type -> memos.api.v1.InstanceSetting
+	4,  // 15: memos.api.v1.InstanceService.UpdateInstanceSetting:output_type -> memos.api.v1.InstanceSetting
+	8,  // 16: memos.api.v1.InstanceService.SanitizeDatabaseUTF8:output_type -> memos.api.v1.SanitizeDatabaseUTF8Response
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
 	9,  // [9:9] is the sub-list for extension type_name
 	9,  // [9:9] is the sub-list for extension extendee
 	0,  // [0:9] is the sub-list for field type_name
@@ -1146,7 +1437,7 @@ func file_api_v1_instance_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_instance_service_proto_rawDesc), len(file_api_v1_instance_service_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   11,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -127,6 +127,61 @@ func (MemoRelation_Type) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{12, 0}
 }
 
+type AiSearchRequest_Scope int32
+
+const (
+	AiSearchRequest_SCOPE_UNSPECIFIED AiSearchRequest_Scope = 0
+	// Memos of the creator, which defaults to the current user.
+	AiSearchRequest_SCOPE_OWN AiSearchRequest_Scope = 1
+	// Public memos of any user, or of the creator if set.
+	AiSearchRequest_SCOPE_PUBLIC AiSearchRequest_Scope = 2
+	// Memos of the current user and public memos of other users.
+	AiSearchRequest_SCOPE_ALL_ACCESSIBLE AiSearchRequest_Scope = 3
+)
+
+// Enum value maps for AiSearchRequest_Scope.
+var (
+	AiSearchRequest_Scope_name = map[int32]string{
+		0: "SCOPE_UNSPECIFIED",
+		1: "SCOPE_OWN",
+		2: "SCOPE_PUBLIC",
+		3: "SCOPE_ALL_ACCESSIBLE",
+	}
+	AiSearchRequest_Scope_value = map[string]int32{
+		"SCOPE_UNSPECIFIED":    0,
+		"SCOPE_OWN":            1,
+		"SCOPE_PUBLIC":         2,
+		"SCOPE_ALL_ACCESSIBLE": 3,
+	}
+)
+
+func (x AiSearchRequest_Scope) Enum() *AiSearchRequest_Scope {
+	p := new(AiSearchRequest_Scope)
+	*p = x
+	return p
+}
+
+func (x AiSearchRequest_Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AiSearchRequest_Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_memo_service_proto_enumTypes[2].Descriptor()
+}
+
+func (AiSearchRequest_Scope) Type() protoreflect.EnumType {
+	return &file_api_v1_memo_service_proto_enumTypes[2]
+}
+
+func (x AiSearchRequest_Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AiSearchRequest_Scope.Descriptor instead.
+func (AiSearchRequest_Scope) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{45, 0}
+}
+
 type Reaction struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The resource name of the reaction.
@@ -140,6 +195,8 @@ type Reaction struct {
 	// Format: memos/{memo}
 	ContentId string `protobuf:"bytes,3,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
 	// Required. The type of reaction (e.g., "👍", "❤️", "😄").
+	// Must be a single emoji or a shortcode of up to 32 ASCII letters, digits, _, + and -,
+	// optionally wrapped in colons (e.g., "+1", ":tada:").
 	ReactionType string `protobuf:"bytes,4,opt,name=reaction_type,json=reactionType,proto3" json:"reaction_type,omitempty"`
 	// Output only. The creation timestamp.
 	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
@@ -1683,7 +1740,19 @@ type GenerateAiTagsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The resource name of the memo.
 	// Format: memos/{memo}
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional. The maximum number of tags to generate.
+	// Defaults to 5 when unset; values above 20 are clamped to 20.
+	MaxTags int32 `protobuf:"varint,2,opt,name=max_tags,json=maxTags,proto3" json:"max_tags,omitempty"`
+	// Optional. Overrides the tag generation model for this request.
+	// Only honored for admins and when the AI service advertises the model; otherwise the default model is used.
+	Model string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	// Optional. Tags never to suggest, e.g. overly generic ones, matched ignoring case and a leading #.
+	// Tags the memo already has are kept in merged_tags.
+	ExcludeTags []string `protobuf:"bytes,4,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+	// Optional. The BCP-47 code of the language to generate tags in, e.g. "de", or "auto" to let the
+	// AI service detect the language of the memo. Unset leaves the language to the AI service.
+	Language      string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1725,10 +1794,42 @@ func (x *GenerateAiTagsRequest) GetName() string {
 	return ""
 }
 
+func (x *GenerateAiTagsRequest) GetMaxTags() int32 {
+	if x != nil {
+		return x.MaxTags
+	}
+	return 0
+}
+
+func (x *GenerateAiTagsRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GenerateAiTagsRequest) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+func (x *GenerateAiTagsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 type GenerateAiTagsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The generated AI tags.
-	Tags          []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The generated AI tags, as suggested by the AI service.
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The suggested tags that were consolidated with the user's existing tags.
+	MergedTags []string `protobuf:"bytes,2,rep,name=merged_tags,json=mergedTags,proto3" json:"merged_tags,omitempty"`
+	// The token usage of the tag generation, if reported by the AI service.
+	Usage         *AiTokenUsage `protobuf:"bytes,3,opt,name=usage,proto3" json:"usage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1770,30 +1871,50 @@ func (x *GenerateAiTagsResponse) GetTags() []string {
 	return nil
 }
 
-// IndexMemoRequest is the request to index a memo.
-type IndexMemoRequest struct {
+func (x *GenerateAiTagsResponse) GetMergedTags() []string {
+	if x != nil {
+		return x.MergedTags
+	}
+	return nil
+}
+
+func (x *GenerateAiTagsResponse) GetUsage() *AiTokenUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type BatchGenerateAiTagsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The resource name of the memo.
+	// Required. The resource names of the memos, at most 50.
 	// Format: memos/{memo}
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	// Optional. The maximum number of tags to generate per memo.
+	// Defaults to 5 when unset; values above 20 are clamped to 20.
+	MaxTags int32 `protobuf:"varint,2,opt,name=max_tags,json=maxTags,proto3" json:"max_tags,omitempty"`
+	// Optional. Tags never to suggest for any of the memos, as in GenerateAiTagsRequest.exclude_tags.
+	ExcludeTags []string `protobuf:"bytes,3,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+	// Optional. The language to generate tags in, as in GenerateAiTagsRequest.language.
+	Language      string `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IndexMemoRequest) Reset() {
-	*x = IndexMemoRequest{}
+func (x *BatchGenerateAiTagsRequest) Reset() {
+	*x = BatchGenerateAiTagsRequest{}
 	mi := &file_api_v1_memo_service_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IndexMemoRequest) String() string {
+func (x *BatchGenerateAiTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndexMemoRequest) ProtoMessage() {}
+func (*BatchGenerateAiTagsRequest) ProtoMessage() {}
 
-func (x *IndexMemoRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGenerateAiTagsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_memo_service_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1805,45 +1926,61 @@ func (x *IndexMemoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndexMemoRequest.ProtoReflect.Descriptor instead.
-func (*IndexMemoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGenerateAiTagsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGenerateAiTagsRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *IndexMemoRequest) GetName() string {
+func (x *BatchGenerateAiTagsRequest) GetNames() []string {
 	if x != nil {
-		return x.Name
+		return x.Names
+	}
+	return nil
+}
+
+func (x *BatchGenerateAiTagsRequest) GetMaxTags() int32 {
+	if x != nil {
+		return x.MaxTags
+	}
+	return 0
+}
+
+func (x *BatchGenerateAiTagsRequest) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+func (x *BatchGenerateAiTagsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
 	}
 	return ""
 }
 
-// IndexMemoResponse is the response after indexing a memo.
-type IndexMemoResponse struct {
+type BatchGenerateAiTagsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The memo uid that was indexed.
-	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
-	// The status of the indexing operation.
-	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	// The timestamp of the operation.
-	Timestamp     string `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The result of each requested memo, keyed by memo name.
+	Results       map[string]*BatchGenerateAiTagsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IndexMemoResponse) Reset() {
-	*x = IndexMemoResponse{}
+func (x *BatchGenerateAiTagsResponse) Reset() {
+	*x = BatchGenerateAiTagsResponse{}
 	mi := &file_api_v1_memo_service_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IndexMemoResponse) String() string {
+func (x *BatchGenerateAiTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndexMemoResponse) ProtoMessage() {}
+func (*BatchGenerateAiTagsResponse) ProtoMessage() {}
 
-func (x *IndexMemoResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchGenerateAiTagsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_memo_service_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1855,56 +1992,44 @@ func (x *IndexMemoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndexMemoResponse.ProtoReflect.Descriptor instead.
-func (*IndexMemoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGenerateAiTagsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGenerateAiTagsResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *IndexMemoResponse) GetMemoUid() string {
-	if x != nil {
-		return x.MemoUid
-	}
-	return ""
-}
-
-func (x *IndexMemoResponse) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-func (x *IndexMemoResponse) GetTimestamp() string {
+func (x *BatchGenerateAiTagsResponse) GetResults() map[string]*BatchGenerateAiTagsResponse_Result {
 	if x != nil {
-		return x.Timestamp
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-// DeleteMemoIndexRequest is the request to delete memo index.
-type DeleteMemoIndexRequest struct {
+type ApplyAiTagsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The resource name of the memo.
 	// Format: memos/{memo}
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional. The tags to apply.
+	// If empty, tags are generated by the AI service and all suggestions are applied.
+	Tags          []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMemoIndexRequest) Reset() {
-	*x = DeleteMemoIndexRequest{}
+func (x *ApplyAiTagsRequest) Reset() {
+	*x = ApplyAiTagsRequest{}
 	mi := &file_api_v1_memo_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMemoIndexRequest) String() string {
+func (x *ApplyAiTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMemoIndexRequest) ProtoMessage() {}
+func (*ApplyAiTagsRequest) ProtoMessage() {}
 
-func (x *DeleteMemoIndexRequest) ProtoReflect() protoreflect.Message {
+func (x *ApplyAiTagsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_memo_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1916,41 +2041,47 @@ func (x *DeleteMemoIndexRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMemoIndexRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMemoIndexRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApplyAiTagsRequest.ProtoReflect.Descriptor instead.
+func (*ApplyAiTagsRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *DeleteMemoIndexRequest) GetName() string {
+func (x *ApplyAiTagsRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-// DeleteMemoIndexResponse is the response after deleting memo index.
-type DeleteMemoIndexResponse struct {
+func (x *ApplyAiTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ApplyAiTagsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Success status.
-	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// The AI tags of the memo after applying.
+	AiTags        []string `protobuf:"bytes,1,rep,name=ai_tags,json=aiTags,proto3" json:"ai_tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMemoIndexResponse) Reset() {
-	*x = DeleteMemoIndexResponse{}
+func (x *ApplyAiTagsResponse) Reset() {
+	*x = ApplyAiTagsResponse{}
 	mi := &file_api_v1_memo_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMemoIndexResponse) String() string {
+func (x *ApplyAiTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMemoIndexResponse) ProtoMessage() {}
+func (*ApplyAiTagsResponse) ProtoMessage() {}
 
-func (x *DeleteMemoIndexResponse) ProtoReflect() protoreflect.Message {
+func (x *ApplyAiTagsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_memo_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1962,44 +2093,46 @@ func (x *DeleteMemoIndexResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMemoIndexResponse.ProtoReflect.Descriptor instead.
-func (*DeleteMemoIndexResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApplyAiTagsResponse.ProtoReflect.Descriptor instead.
+func (*ApplyAiTagsResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *DeleteMemoIndexResponse) GetSuccess() bool {
+func (x *ApplyAiTagsResponse) GetAiTags() []string {
 	if x != nil {
-		return x.Success
+		return x.AiTags
 	}
-	return false
+	return nil
 }
 
-// GetMemoIndexInfoRequest is the request to get memo index info.
-type GetMemoIndexInfoRequest struct {
+type AiSummarizeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The resource name of the memo.
 	// Format: memos/{memo}
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Optional. If true, include detailed information (text chunks, image captions).
-	IncludeDetail bool `protobuf:"varint,2,opt,name=include_detail,json=includeDetail,proto3" json:"include_detail,omitempty"`
+	// Optional. The maximum number of words of the summary.
+	MaxWords int32 `protobuf:"varint,2,opt,name=max_words,json=maxWords,proto3" json:"max_words,omitempty"`
+	// Optional. The language of the summary, e.g. "en" or "zh".
+	// Defaults to the language of the memo.
+	Language      string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMemoIndexInfoRequest) Reset() {
-	*x = GetMemoIndexInfoRequest{}
+func (x *AiSummarizeRequest) Reset() {
+	*x = AiSummarizeRequest{}
 	mi := &file_api_v1_memo_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMemoIndexInfoRequest) String() string {
+func (x *AiSummarizeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMemoIndexInfoRequest) ProtoMessage() {}
+func (*AiSummarizeRequest) ProtoMessage() {}
 
-func (x *GetMemoIndexInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *AiSummarizeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_memo_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2011,56 +2144,56 @@ func (x *GetMemoIndexInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMemoIndexInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetMemoIndexInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AiSummarizeRequest.ProtoReflect.Descriptor instead.
+func (*AiSummarizeRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GetMemoIndexInfoRequest) GetName() string {
+func (x *AiSummarizeRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *GetMemoIndexInfoRequest) GetIncludeDetail() bool {
+func (x *AiSummarizeRequest) GetMaxWords() int32 {
 	if x != nil {
-		return x.IncludeDetail
+		return x.MaxWords
 	}
-	return false
+	return 0
 }
 
-// MemoIndexInfo contains the index information of a memo.
-type MemoIndexInfo struct {
+func (x *AiSummarizeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type AiSummarizeResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The memo uid.
-	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
-	// Whether the memo is indexed.
-	Indexed bool `protobuf:"varint,2,opt,name=indexed,proto3" json:"indexed,omitempty"`
-	// Number of text vectors.
-	TextVectors int32 `protobuf:"varint,3,opt,name=text_vectors,json=textVectors,proto3" json:"text_vectors,omitempty"`
-	// Number of image vectors.
-	ImageVectors int32 `protobuf:"varint,4,opt,name=image_vectors,json=imageVectors,proto3" json:"image_vectors,omitempty"`
-	// Detailed index information (only returned when include_detail is true).
-	Detail        *MemoIndexDetail `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	// The generated summary.
+	Summary string `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	// The token usage of the summarization.
+	Usage         *AiTokenUsage `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MemoIndexInfo) Reset() {
-	*x = MemoIndexInfo{}
+func (x *AiSummarizeResponse) Reset() {
+	*x = AiSummarizeResponse{}
 	mi := &file_api_v1_memo_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MemoIndexInfo) String() string {
+func (x *AiSummarizeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MemoIndexInfo) ProtoMessage() {}
+func (*AiSummarizeResponse) ProtoMessage() {}
 
-func (x *MemoIndexInfo) ProtoReflect() protoreflect.Message {
+func (x *AiSummarizeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_memo_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2072,72 +2205,119 @@ func (x *MemoIndexInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MemoIndexInfo.ProtoReflect.Descriptor instead.
-func (*MemoIndexInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use AiSummarizeResponse.ProtoReflect.Descriptor instead.
+func (*AiSummarizeResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *MemoIndexInfo) GetMemoUid() string {
+func (x *AiSummarizeResponse) GetSummary() string {
 	if x != nil {
-		return x.MemoUid
+		return x.Summary
 	}
 	return ""
 }
 
-func (x *MemoIndexInfo) GetIndexed() bool {
+func (x *AiSummarizeResponse) GetUsage() *AiTokenUsage {
 	if x != nil {
-		return x.Indexed
+		return x.Usage
 	}
-	return false
+	return nil
 }
 
-func (x *MemoIndexInfo) GetTextVectors() int32 {
+// AiTokenUsage is the token usage of an AI model call.
+type AiTokenUsage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PromptTokens     int32                  `protobuf:"varint,1,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int32                  `protobuf:"varint,2,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	TotalTokens      int32                  `protobuf:"varint,3,opt,name=total_tokens,json=totalTokens,proto3" json:"total_tokens,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AiTokenUsage) Reset() {
+	*x = AiTokenUsage{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiTokenUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiTokenUsage) ProtoMessage() {}
+
+func (x *AiTokenUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[31]
 	if x != nil {
-		return x.TextVectors
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiTokenUsage.ProtoReflect.Descriptor instead.
+func (*AiTokenUsage) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AiTokenUsage) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
 	}
 	return 0
 }
 
-func (x *MemoIndexInfo) GetImageVectors() int32 {
+func (x *AiTokenUsage) GetCompletionTokens() int32 {
 	if x != nil {
-		return x.ImageVectors
+		return x.CompletionTokens
 	}
 	return 0
 }
 
-func (x *MemoIndexInfo) GetDetail() *MemoIndexDetail {
+func (x *AiTokenUsage) GetTotalTokens() int32 {
 	if x != nil {
-		return x.Detail
+		return x.TotalTokens
 	}
-	return nil
+	return 0
 }
 
-// MemoIndexDetail contains detailed information about a memo's index.
-type MemoIndexDetail struct {
+// IndexMemoRequest is the request to index a memo.
+type IndexMemoRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Text chunks indexed for this memo.
-	TextChunks []*TextChunk `protobuf:"bytes,1,rep,name=text_chunks,json=textChunks,proto3" json:"text_chunks,omitempty"`
-	// Image descriptions indexed for this memo.
-	Images        []*ImageInfo `protobuf:"bytes,2,rep,name=images,proto3" json:"images,omitempty"`
+	// Required. The resource name of the memo.
+	// Format: memos/{memo}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional. The index operation: "upsert" (default) indexes the memo whether or not it is
+	// already indexed, "create" fails if it is already indexed, and "replace" fails if it isn't.
+	Operation string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Optional. Send the memo to the AI service even if it is unchanged since it was last indexed,
+	// e.g. to restore an index the AI service lost. Without it, an upsert of an unchanged memo is
+	// skipped. The AI service still skips re-embedding a memo it holds with the same content; use
+	// RefreshMemoIndex to force that.
+	Force         bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MemoIndexDetail) Reset() {
-	*x = MemoIndexDetail{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[31]
+func (x *IndexMemoRequest) Reset() {
+	*x = IndexMemoRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MemoIndexDetail) String() string {
+func (x *IndexMemoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MemoIndexDetail) ProtoMessage() {}
+func (*IndexMemoRequest) ProtoMessage() {}
 
-func (x *MemoIndexDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[31]
+func (x *IndexMemoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2148,53 +2328,63 @@ func (x *MemoIndexDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MemoIndexDetail.ProtoReflect.Descriptor instead.
-func (*MemoIndexDetail) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use IndexMemoRequest.ProtoReflect.Descriptor instead.
+func (*IndexMemoRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *MemoIndexDetail) GetTextChunks() []*TextChunk {
+func (x *IndexMemoRequest) GetName() string {
 	if x != nil {
-		return x.TextChunks
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *MemoIndexDetail) GetImages() []*ImageInfo {
+func (x *IndexMemoRequest) GetOperation() string {
 	if x != nil {
-		return x.Images
+		return x.Operation
 	}
-	return nil
+	return ""
 }
 
-// TextChunk represents a text segment that was indexed.
-type TextChunk struct {
+func (x *IndexMemoRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// IndexMemoResponse is the response after indexing a memo.
+type IndexMemoResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The document ID.
-	DocId string `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
-	// The text content.
-	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	// The type of content (e.g., "memo_content", "attachment").
-	ContentType   string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// The memo uid that was indexed.
+	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
+	// The status of the indexing operation.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// The timestamp of the operation.
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Whether the memo was not sent to the AI service, because it is unchanged since it was last
+	// indexed or excluded from indexing.
+	Skipped       bool `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TextChunk) Reset() {
-	*x = TextChunk{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[32]
+func (x *IndexMemoResponse) Reset() {
+	*x = IndexMemoResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TextChunk) String() string {
+func (x *IndexMemoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TextChunk) ProtoMessage() {}
+func (*IndexMemoResponse) ProtoMessage() {}
 
-func (x *TextChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[32]
+func (x *IndexMemoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2205,60 +2395,64 @@ func (x *TextChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TextChunk.ProtoReflect.Descriptor instead.
-func (*TextChunk) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use IndexMemoResponse.ProtoReflect.Descriptor instead.
+func (*IndexMemoResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *TextChunk) GetDocId() string {
+func (x *IndexMemoResponse) GetMemoUid() string {
 	if x != nil {
-		return x.DocId
+		return x.MemoUid
 	}
 	return ""
 }
 
-func (x *TextChunk) GetContent() string {
+func (x *IndexMemoResponse) GetStatus() string {
 	if x != nil {
-		return x.Content
+		return x.Status
 	}
 	return ""
 }
 
-func (x *TextChunk) GetContentType() string {
+func (x *IndexMemoResponse) GetTimestamp() string {
 	if x != nil {
-		return x.ContentType
+		return x.Timestamp
 	}
 	return ""
 }
 
-// ImageInfo represents indexed image information.
-type ImageInfo struct {
+func (x *IndexMemoResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+// RefreshMemoIndexRequest is the request to force re-indexing a memo.
+type RefreshMemoIndexRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The document ID.
-	DocId string `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
-	// The attachment name/filename.
-	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	// The AI-generated caption/description.
-	Caption       string `protobuf:"bytes,3,opt,name=caption,proto3" json:"caption,omitempty"`
+	// Required. The resource name of the memo.
+	// Format: memos/{memo}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ImageInfo) Reset() {
-	*x = ImageInfo{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[33]
+func (x *RefreshMemoIndexRequest) Reset() {
+	*x = RefreshMemoIndexRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ImageInfo) String() string {
+func (x *RefreshMemoIndexRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImageInfo) ProtoMessage() {}
+func (*RefreshMemoIndexRequest) ProtoMessage() {}
 
-func (x *ImageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[33]
+func (x *RefreshMemoIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2269,65 +2463,50 @@ func (x *ImageInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImageInfo.ProtoReflect.Descriptor instead.
-func (*ImageInfo) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{33}
-}
-
-func (x *ImageInfo) GetDocId() string {
-	if x != nil {
-		return x.DocId
-	}
-	return ""
-}
-
-func (x *ImageInfo) GetFilename() string {
-	if x != nil {
-		return x.Filename
-	}
-	return ""
+// Deprecated: Use RefreshMemoIndexRequest.ProtoReflect.Descriptor instead.
+func (*RefreshMemoIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ImageInfo) GetCaption() string {
+func (x *RefreshMemoIndexRequest) GetName() string {
 	if x != nil {
-		return x.Caption
+		return x.Name
 	}
 	return ""
 }
 
-// AiSearchRequest is the request for AI semantic search.
-type AiSearchRequest struct {
+// RefreshMemoIndexResponse is the response after refreshing a memo index.
+type RefreshMemoIndexResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The search query.
-	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	// Maximum number of results to return.
-	TopK int32 `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
-	// Search mode: "text", "image", or "hybrid".
-	SearchMode string `protobuf:"bytes,3,opt,name=search_mode,json=searchMode,proto3" json:"search_mode,omitempty"`
-	// Minimum score threshold.
-	MinScore float32 `protobuf:"fixed32,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
-	// The creator to filter results by.
-	// Format: users/{user}
-	Creator       string `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+	// The memo uid that was refreshed.
+	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
+	// The status of the refresh operation.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// The timestamp of the operation.
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Number of text vectors after the refresh.
+	TextVectors int32 `protobuf:"varint,4,opt,name=text_vectors,json=textVectors,proto3" json:"text_vectors,omitempty"`
+	// Number of image vectors after the refresh.
+	ImageVectors  int32 `protobuf:"varint,5,opt,name=image_vectors,json=imageVectors,proto3" json:"image_vectors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AiSearchRequest) Reset() {
-	*x = AiSearchRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[34]
+func (x *RefreshMemoIndexResponse) Reset() {
+	*x = RefreshMemoIndexResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AiSearchRequest) String() string {
+func (x *RefreshMemoIndexResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AiSearchRequest) ProtoMessage() {}
+func (*RefreshMemoIndexResponse) ProtoMessage() {}
 
-func (x *AiSearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[34]
+func (x *RefreshMemoIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2338,76 +2517,71 @@ func (x *AiSearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AiSearchRequest.ProtoReflect.Descriptor instead.
-func (*AiSearchRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use RefreshMemoIndexResponse.ProtoReflect.Descriptor instead.
+func (*RefreshMemoIndexResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *AiSearchRequest) GetQuery() string {
+func (x *RefreshMemoIndexResponse) GetMemoUid() string {
 	if x != nil {
-		return x.Query
+		return x.MemoUid
 	}
 	return ""
 }
 
-func (x *AiSearchRequest) GetTopK() int32 {
+func (x *RefreshMemoIndexResponse) GetStatus() string {
 	if x != nil {
-		return x.TopK
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *AiSearchRequest) GetSearchMode() string {
+func (x *RefreshMemoIndexResponse) GetTimestamp() string {
 	if x != nil {
-		return x.SearchMode
+		return x.Timestamp
 	}
 	return ""
 }
 
-func (x *AiSearchRequest) GetMinScore() float32 {
+func (x *RefreshMemoIndexResponse) GetTextVectors() int32 {
 	if x != nil {
-		return x.MinScore
+		return x.TextVectors
 	}
 	return 0
 }
 
-func (x *AiSearchRequest) GetCreator() string {
+func (x *RefreshMemoIndexResponse) GetImageVectors() int32 {
 	if x != nil {
-		return x.Creator
+		return x.ImageVectors
 	}
-	return ""
+	return 0
 }
 
-// AiSearchResponse is the response of AI semantic search.
-type AiSearchResponse struct {
+// DeleteMemoIndexRequest is the request to delete memo index.
+type DeleteMemoIndexRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The search results.
-	Results []*AiSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
-	// The original query.
-	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	// The search mode used.
-	SearchMode string `protobuf:"bytes,3,opt,name=search_mode,json=searchMode,proto3" json:"search_mode,omitempty"`
-	// Total number of results.
-	TotalResults  int32 `protobuf:"varint,4,opt,name=total_results,json=totalResults,proto3" json:"total_results,omitempty"`
+	// Required. The resource name of the memo.
+	// Format: memos/{memo}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AiSearchResponse) Reset() {
-	*x = AiSearchResponse{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[35]
+func (x *DeleteMemoIndexRequest) Reset() {
+	*x = DeleteMemoIndexRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AiSearchResponse) String() string {
+func (x *DeleteMemoIndexRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AiSearchResponse) ProtoMessage() {}
+func (*DeleteMemoIndexRequest) ProtoMessage() {}
 
-func (x *AiSearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[35]
+func (x *DeleteMemoIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2418,69 +2592,45 @@ func (x *AiSearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AiSearchResponse.ProtoReflect.Descriptor instead.
-func (*AiSearchResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{35}
-}
-
-func (x *AiSearchResponse) GetResults() []*AiSearchResult {
-	if x != nil {
-		return x.Results
-	}
-	return nil
-}
-
-func (x *AiSearchResponse) GetQuery() string {
-	if x != nil {
-		return x.Query
-	}
-	return ""
+// Deprecated: Use DeleteMemoIndexRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMemoIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *AiSearchResponse) GetSearchMode() string {
+func (x *DeleteMemoIndexRequest) GetName() string {
 	if x != nil {
-		return x.SearchMode
+		return x.Name
 	}
 	return ""
 }
 
-func (x *AiSearchResponse) GetTotalResults() int32 {
-	if x != nil {
-		return x.TotalResults
-	}
-	return 0
-}
-
-// AiSearchResult represents a single search result.
-type AiSearchResult struct {
+// DeleteMemoIndexResponse is the response after deleting memo index.
+type DeleteMemoIndexResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The memo uid.
-	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
-	// The memo name.
-	MemoName string `protobuf:"bytes,2,opt,name=memo_name,json=memoName,proto3" json:"memo_name,omitempty"`
-	// The relevance score.
-	Score float32 `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`
-	// The match type.
-	MatchType     string `protobuf:"bytes,4,opt,name=match_type,json=matchType,proto3" json:"match_type,omitempty"`
+	// Success status.
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Whether the deletion is deferred by the index delete grace period.
+	// A pending deletion is cancelled if the memo is restored within the period.
+	Pending       bool `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AiSearchResult) Reset() {
-	*x = AiSearchResult{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[36]
+func (x *DeleteMemoIndexResponse) Reset() {
+	*x = DeleteMemoIndexResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AiSearchResult) String() string {
+func (x *DeleteMemoIndexResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AiSearchResult) ProtoMessage() {}
+func (*DeleteMemoIndexResponse) ProtoMessage() {}
 
-func (x *AiSearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[36]
+func (x *DeleteMemoIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2491,64 +2641,2194 @@ func (x *AiSearchResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AiSearchResult.ProtoReflect.Descriptor instead.
-func (*AiSearchResult) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use DeleteMemoIndexResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMemoIndexResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *AiSearchResult) GetMemoUid() string {
+func (x *DeleteMemoIndexResponse) GetSuccess() bool {
 	if x != nil {
-		return x.MemoUid
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *AiSearchResult) GetMemoName() string {
+func (x *DeleteMemoIndexResponse) GetPending() bool {
 	if x != nil {
-		return x.MemoName
+		return x.Pending
+	}
+	return false
+}
+
+// DeleteMemoIndexChunkRequest is the request to delete a single indexed chunk of a memo.
+type DeleteMemoIndexChunkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the memo.
+	// Format: memos/{memo}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Required. The document ID of the chunk, as returned in the memo index detail.
+	DocId         string `protobuf:"bytes,2,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMemoIndexChunkRequest) Reset() {
+	*x = DeleteMemoIndexChunkRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMemoIndexChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMemoIndexChunkRequest) ProtoMessage() {}
+
+func (x *DeleteMemoIndexChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMemoIndexChunkRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMemoIndexChunkRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteMemoIndexChunkRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteMemoIndexChunkRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+// DeleteMemoIndexChunkResponse is the response after deleting a memo index chunk.
+type DeleteMemoIndexChunkResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Success status.
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMemoIndexChunkResponse) Reset() {
+	*x = DeleteMemoIndexChunkResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMemoIndexChunkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMemoIndexChunkResponse) ProtoMessage() {}
+
+func (x *DeleteMemoIndexChunkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMemoIndexChunkResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMemoIndexChunkResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteMemoIndexChunkResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// GetMemoIndexInfoRequest is the request to get memo index info.
+type GetMemoIndexInfoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the memo.
+	// Format: memos/{memo}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional. If true, include detailed information (text chunks, image captions).
+	IncludeDetail bool `protobuf:"varint,2,opt,name=include_detail,json=includeDetail,proto3" json:"include_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemoIndexInfoRequest) Reset() {
+	*x = GetMemoIndexInfoRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemoIndexInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemoIndexInfoRequest) ProtoMessage() {}
+
+func (x *GetMemoIndexInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemoIndexInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetMemoIndexInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetMemoIndexInfoRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetMemoIndexInfoRequest) GetIncludeDetail() bool {
+	if x != nil {
+		return x.IncludeDetail
+	}
+	return false
+}
+
+// MemoIndexInfo contains the index information of a memo.
+type MemoIndexInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The memo uid.
+	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
+	// Whether the memo is indexed.
+	Indexed bool `protobuf:"varint,2,opt,name=indexed,proto3" json:"indexed,omitempty"`
+	// Number of text vectors.
+	TextVectors int32 `protobuf:"varint,3,opt,name=text_vectors,json=textVectors,proto3" json:"text_vectors,omitempty"`
+	// Number of image vectors.
+	ImageVectors int32 `protobuf:"varint,4,opt,name=image_vectors,json=imageVectors,proto3" json:"image_vectors,omitempty"`
+	// Detailed index information (only returned when include_detail is true).
+	Detail *MemoIndexDetail `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	// When the memo was last indexed. Unset if the AI service doesn't report it.
+	IndexedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	// Whether the memo was updated after it was last indexed. Always false when indexed_at is unset.
+	Stale         bool `protobuf:"varint,7,opt,name=stale,proto3" json:"stale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoIndexInfo) Reset() {
+	*x = MemoIndexInfo{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoIndexInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoIndexInfo) ProtoMessage() {}
+
+func (x *MemoIndexInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoIndexInfo.ProtoReflect.Descriptor instead.
+func (*MemoIndexInfo) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MemoIndexInfo) GetMemoUid() string {
+	if x != nil {
+		return x.MemoUid
+	}
+	return ""
+}
+
+func (x *MemoIndexInfo) GetIndexed() bool {
+	if x != nil {
+		return x.Indexed
+	}
+	return false
+}
+
+func (x *MemoIndexInfo) GetTextVectors() int32 {
+	if x != nil {
+		return x.TextVectors
+	}
+	return 0
+}
+
+func (x *MemoIndexInfo) GetImageVectors() int32 {
+	if x != nil {
+		return x.ImageVectors
+	}
+	return 0
+}
+
+func (x *MemoIndexInfo) GetDetail() *MemoIndexDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+func (x *MemoIndexInfo) GetIndexedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return nil
+}
+
+func (x *MemoIndexInfo) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+// MemoIndexDetail contains detailed information about a memo's index.
+type MemoIndexDetail struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Text chunks indexed for this memo.
+	TextChunks []*TextChunk `protobuf:"bytes,1,rep,name=text_chunks,json=textChunks,proto3" json:"text_chunks,omitempty"`
+	// Image descriptions indexed for this memo.
+	Images        []*ImageInfo `protobuf:"bytes,2,rep,name=images,proto3" json:"images,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoIndexDetail) Reset() {
+	*x = MemoIndexDetail{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoIndexDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoIndexDetail) ProtoMessage() {}
+
+func (x *MemoIndexDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoIndexDetail.ProtoReflect.Descriptor instead.
+func (*MemoIndexDetail) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MemoIndexDetail) GetTextChunks() []*TextChunk {
+	if x != nil {
+		return x.TextChunks
+	}
+	return nil
+}
+
+func (x *MemoIndexDetail) GetImages() []*ImageInfo {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+// TextChunk represents a text segment that was indexed.
+type TextChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The document ID.
+	DocId string `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	// The text content.
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// The type of content (e.g., "memo_content", "attachment").
+	ContentType   string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextChunk) Reset() {
+	*x = TextChunk{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextChunk) ProtoMessage() {}
+
+func (x *TextChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextChunk.ProtoReflect.Descriptor instead.
+func (*TextChunk) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TextChunk) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *TextChunk) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *TextChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// ImageInfo represents indexed image information.
+type ImageInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The document ID.
+	DocId string `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	// The attachment name/filename.
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// The AI-generated caption/description.
+	Caption       string `protobuf:"bytes,3,opt,name=caption,proto3" json:"caption,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageInfo) Reset() {
+	*x = ImageInfo{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageInfo) ProtoMessage() {}
+
+func (x *ImageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageInfo.ProtoReflect.Descriptor instead.
+func (*ImageInfo) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ImageInfo) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *ImageInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ImageInfo) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+// AiSearchRequest is the request for AI semantic search.
+type AiSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The search query.
+	// Phrases in double quotes, e.g. "E1234", must appear verbatim in the matched memos.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Maximum number of results to return.
+	// Unset fields among top_k, search_mode and min_score take the defaults of the instance AI setting,
+	// or if those are unset too, the built-in defaults: 10 results, "hybrid" and 0.5.
+	TopK int32 `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	// Search mode, one of those listed by ListAiSearchModes.
+	SearchMode string `protobuf:"bytes,3,opt,name=search_mode,json=searchMode,proto3" json:"search_mode,omitempty"`
+	// Minimum score threshold.
+	MinScore float32 `protobuf:"fixed32,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// The creator to filter results by.
+	// Format: users/{user}
+	Creator string `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Optional. The maximum number of results to return per page.
+	// When neither page_size nor page_token is set, top_k bounds the whole result set.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. A page token, received from a previous `AiSearch` call.
+	// Provide this to retrieve the subsequent page.
+	PageToken string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Optional. Overrides the embedding model for this request.
+	// Only honored for admins and when the AI service advertises the model; otherwise the default model is used.
+	Model string `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`
+	// Optional. Only memos carrying all of these tags are returned.
+	Tags []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Optional. Only memos created at or after this time are returned.
+	CreatedAfter *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Optional. Only memos created before this time are returned.
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Optional. When paging, the first page takes a snapshot of the ranked results and its
+	// next_page_token refers to it, so memos added or re-ranked later don't shift subsequent pages.
+	// The snapshot holds at most top_k results (100 if unset) and expires after 10 minutes.
+	Snapshot bool `protobuf:"varint,12,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Optional. Which memos are searched. Defaults to SCOPE_OWN.
+	Scope AiSearchRequest_Scope `protobuf:"varint,13,opt,name=scope,proto3,enum=memos.api.v1.AiSearchRequest_Scope" json:"scope,omitempty"`
+	// Optional. Embeds the memo of each result, so clients can render results without fetching the memos.
+	// The memo is left unset for results whose memo the current user can't see.
+	IncludeMemo bool `protobuf:"varint,14,opt,name=include_memo,json=includeMemo,proto3" json:"include_memo,omitempty"`
+	// Optional. Returns the score_breakdown of each result, to debug relevance. Only admins can
+	// request it.
+	Explain       bool `protobuf:"varint,15,opt,name=explain,proto3" json:"explain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiSearchRequest) Reset() {
+	*x = AiSearchRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiSearchRequest) ProtoMessage() {}
+
+func (x *AiSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiSearchRequest.ProtoReflect.Descriptor instead.
+func (*AiSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AiSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AiSearchRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *AiSearchRequest) GetSearchMode() string {
+	if x != nil {
+		return x.SearchMode
+	}
+	return ""
+}
+
+func (x *AiSearchRequest) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *AiSearchRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *AiSearchRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *AiSearchRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *AiSearchRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *AiSearchRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AiSearchRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *AiSearchRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *AiSearchRequest) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *AiSearchRequest) GetScope() AiSearchRequest_Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return AiSearchRequest_SCOPE_UNSPECIFIED
+}
+
+func (x *AiSearchRequest) GetIncludeMemo() bool {
+	if x != nil {
+		return x.IncludeMemo
+	}
+	return false
+}
+
+func (x *AiSearchRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+// AiSearchResponse is the response of AI semantic search.
+type AiSearchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The search results.
+	Results []*AiSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// The original query.
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// The search mode used.
+	SearchMode string `protobuf:"bytes,3,opt,name=search_mode,json=searchMode,proto3" json:"search_mode,omitempty"`
+	// Total number of results, counting each memo once.
+	TotalResults int32 `protobuf:"varint,4,opt,name=total_results,json=totalResults,proto3" json:"total_results,omitempty"`
+	// A token to retrieve the next page of results.
+	// If empty, there are no more results.
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// How the tags filter was applied: "service" if the AI service filtered the results,
+	// "post_filter" if they were filtered afterwards against the memos' tags.
+	// Empty when no tags filter was requested.
+	TagFilterMode string `protobuf:"bytes,6,opt,name=tag_filter_mode,json=tagFilterMode,proto3" json:"tag_filter_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiSearchResponse) Reset() {
+	*x = AiSearchResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiSearchResponse) ProtoMessage() {}
+
+func (x *AiSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiSearchResponse.ProtoReflect.Descriptor instead.
+func (*AiSearchResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AiSearchResponse) GetResults() []*AiSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *AiSearchResponse) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AiSearchResponse) GetSearchMode() string {
+	if x != nil {
+		return x.SearchMode
+	}
+	return ""
+}
+
+func (x *AiSearchResponse) GetTotalResults() int32 {
+	if x != nil {
+		return x.TotalResults
+	}
+	return 0
+}
+
+func (x *AiSearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *AiSearchResponse) GetTagFilterMode() string {
+	if x != nil {
+		return x.TagFilterMode
+	}
+	return ""
+}
+
+// AiSearchResult represents a single search result.
+type AiSearchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The memo uid.
+	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
+	// The memo name.
+	MemoName string `protobuf:"bytes,2,opt,name=memo_name,json=memoName,proto3" json:"memo_name,omitempty"`
+	// The relevance score.
+	Score float32 `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`
+	// The match type, e.g. "text" or "image".
+	// If the memo matched in several ways, the distinct match types are joined by "+" in sorted order,
+	// e.g. "image+text", and the other fields are those of the highest scoring match.
+	MatchType string `protobuf:"bytes,4,opt,name=match_type,json=matchType,proto3" json:"match_type,omitempty"`
+	// The chunk text that matched the query, if reported by the AI service.
+	// Empty when unavailable.
+	MatchedText string `protobuf:"bytes,5,opt,name=matched_text,json=matchedText,proto3" json:"matched_text,omitempty"`
+	// A short excerpt showing why the memo matched: the matched text if reported,
+	// otherwise the line of the memo content that best matches the query.
+	Snippet string `protobuf:"bytes,6,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	// The ranges of the snippet that match the query terms, in ascending order.
+	Highlights []*AiSearchResult_HighlightRange `protobuf:"bytes,7,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	// The index the memo matched in: "text", "image" or "both".
+	// Empty if not reported by the AI service.
+	IndexType string `protobuf:"bytes,8,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`
+	// The doc ID of the chunk that matched the query, as listed in MemoIndexDetail, to link to it.
+	// Empty if not reported by the AI service.
+	MatchedChunkId string `protobuf:"bytes,9,opt,name=matched_chunk_id,json=matchedChunkId,proto3" json:"matched_chunk_id,omitempty"`
+	// The matched memo, only set if include_memo was requested.
+	Memo *AiSearchResult_MemoInfo `protobuf:"bytes,10,opt,name=memo,proto3" json:"memo,omitempty"`
+	// How the score was computed, only set if explain was requested and the AI service reported it.
+	ScoreBreakdown *AiSearchResult_ScoreBreakdown `protobuf:"bytes,11,opt,name=score_breakdown,json=scoreBreakdown,proto3" json:"score_breakdown,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AiSearchResult) Reset() {
+	*x = AiSearchResult{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiSearchResult) ProtoMessage() {}
+
+func (x *AiSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiSearchResult.ProtoReflect.Descriptor instead.
+func (*AiSearchResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AiSearchResult) GetMemoUid() string {
+	if x != nil {
+		return x.MemoUid
+	}
+	return ""
+}
+
+func (x *AiSearchResult) GetMemoName() string {
+	if x != nil {
+		return x.MemoName
+	}
+	return ""
+}
+
+func (x *AiSearchResult) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *AiSearchResult) GetMatchType() string {
+	if x != nil {
+		return x.MatchType
+	}
+	return ""
+}
+
+func (x *AiSearchResult) GetMatchedText() string {
+	if x != nil {
+		return x.MatchedText
+	}
+	return ""
+}
+
+func (x *AiSearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *AiSearchResult) GetHighlights() []*AiSearchResult_HighlightRange {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+func (x *AiSearchResult) GetIndexType() string {
+	if x != nil {
+		return x.IndexType
+	}
+	return ""
+}
+
+func (x *AiSearchResult) GetMatchedChunkId() string {
+	if x != nil {
+		return x.MatchedChunkId
+	}
+	return ""
+}
+
+func (x *AiSearchResult) GetMemo() *AiSearchResult_MemoInfo {
+	if x != nil {
+		return x.Memo
+	}
+	return nil
+}
+
+func (x *AiSearchResult) GetScoreBreakdown() *AiSearchResult_ScoreBreakdown {
+	if x != nil {
+		return x.ScoreBreakdown
+	}
+	return nil
+}
+
+// GetRelatedMemosRequest is the request to find memos similar to a memo.
+type GetRelatedMemosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the memo.
+	// Format: memos/{memo}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional. Maximum number of related memos to return.
+	// Defaults to 5; values above 50 are clamped to 50.
+	TopK          int32 `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedMemosRequest) Reset() {
+	*x = GetRelatedMemosRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedMemosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedMemosRequest) ProtoMessage() {}
+
+func (x *GetRelatedMemosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedMemosRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedMemosRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetRelatedMemosRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetRelatedMemosRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+// GetRelatedMemosResponse is the response of finding related memos.
+type GetRelatedMemosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The related memos, ordered by similarity. The source memo is never included.
+	Results       []*AiSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedMemosResponse) Reset() {
+	*x = GetRelatedMemosResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedMemosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedMemosResponse) ProtoMessage() {}
+
+func (x *GetRelatedMemosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedMemosResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedMemosResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetRelatedMemosResponse) GetResults() []*AiSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// ListAiSearchModesRequest is the request to list the AI search modes.
+type ListAiSearchModesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAiSearchModesRequest) Reset() {
+	*x = ListAiSearchModesRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAiSearchModesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAiSearchModesRequest) ProtoMessage() {}
+
+func (x *ListAiSearchModesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAiSearchModesRequest.ProtoReflect.Descriptor instead.
+func (*ListAiSearchModesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{50}
+}
+
+// ListAiSearchModesResponse lists the AI search modes.
+type ListAiSearchModesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The search modes accepted by AiSearch, e.g. "hybrid", "vector" and "bm25".
+	SearchModes []string `protobuf:"bytes,1,rep,name=search_modes,json=searchModes,proto3" json:"search_modes,omitempty"`
+	// The search mode used when the request doesn't specify one, the default of the instance AI setting
+	// if set.
+	DefaultSearchMode string `protobuf:"bytes,2,opt,name=default_search_mode,json=defaultSearchMode,proto3" json:"default_search_mode,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListAiSearchModesResponse) Reset() {
+	*x = ListAiSearchModesResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAiSearchModesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAiSearchModesResponse) ProtoMessage() {}
+
+func (x *ListAiSearchModesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAiSearchModesResponse.ProtoReflect.Descriptor instead.
+func (*ListAiSearchModesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListAiSearchModesResponse) GetSearchModes() []string {
+	if x != nil {
+		return x.SearchModes
+	}
+	return nil
+}
+
+func (x *ListAiSearchModesResponse) GetDefaultSearchMode() string {
+	if x != nil {
+		return x.DefaultSearchMode
+	}
+	return ""
+}
+
+// GetAiServiceConfigRequest is the request to get the AI service config.
+type GetAiServiceConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAiServiceConfigRequest) Reset() {
+	*x = GetAiServiceConfigRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAiServiceConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAiServiceConfigRequest) ProtoMessage() {}
+
+func (x *GetAiServiceConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAiServiceConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetAiServiceConfigRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{52}
+}
+
+// AiServiceConfig describes what the configured AI service supports.
+type AiServiceConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The search modes accepted by both AiSearch and the AI service. All search modes accepted by
+	// AiSearch if the AI service doesn't report its own.
+	SearchModes []string `protobuf:"bytes,1,rep,name=search_modes,json=searchModes,proto3" json:"search_modes,omitempty"`
+	// Whether the AI service indexes the images of memos for image search.
+	ImageIndexingEnabled bool `protobuf:"varint,2,opt,name=image_indexing_enabled,json=imageIndexingEnabled,proto3" json:"image_indexing_enabled,omitempty"`
+	// The number of tags the AI service generates for a memo by default. 0 if not reported.
+	MaxTags int32 `protobuf:"varint,3,opt,name=max_tags,json=maxTags,proto3" json:"max_tags,omitempty"`
+	// The model generating tags. Empty if not reported.
+	TagGenerationModel string `protobuf:"bytes,4,opt,name=tag_generation_model,json=tagGenerationModel,proto3" json:"tag_generation_model,omitempty"`
+	// The model embedding text. Empty if not reported.
+	TextEmbeddingModel string `protobuf:"bytes,5,opt,name=text_embedding_model,json=textEmbeddingModel,proto3" json:"text_embedding_model,omitempty"`
+	// The model embedding images. Empty if not reported.
+	ImageEmbeddingModel string `protobuf:"bytes,6,opt,name=image_embedding_model,json=imageEmbeddingModel,proto3" json:"image_embedding_model,omitempty"`
+	// The model captioning images. Empty if not reported.
+	ImageCaptionModel string `protobuf:"bytes,7,opt,name=image_caption_model,json=imageCaptionModel,proto3" json:"image_caption_model,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AiServiceConfig) Reset() {
+	*x = AiServiceConfig{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiServiceConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiServiceConfig) ProtoMessage() {}
+
+func (x *AiServiceConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiServiceConfig.ProtoReflect.Descriptor instead.
+func (*AiServiceConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *AiServiceConfig) GetSearchModes() []string {
+	if x != nil {
+		return x.SearchModes
+	}
+	return nil
+}
+
+func (x *AiServiceConfig) GetImageIndexingEnabled() bool {
+	if x != nil {
+		return x.ImageIndexingEnabled
+	}
+	return false
+}
+
+func (x *AiServiceConfig) GetMaxTags() int32 {
+	if x != nil {
+		return x.MaxTags
+	}
+	return 0
+}
+
+func (x *AiServiceConfig) GetTagGenerationModel() string {
+	if x != nil {
+		return x.TagGenerationModel
+	}
+	return ""
+}
+
+func (x *AiServiceConfig) GetTextEmbeddingModel() string {
+	if x != nil {
+		return x.TextEmbeddingModel
+	}
+	return ""
+}
+
+func (x *AiServiceConfig) GetImageEmbeddingModel() string {
+	if x != nil {
+		return x.ImageEmbeddingModel
+	}
+	return ""
+}
+
+func (x *AiServiceConfig) GetImageCaptionModel() string {
+	if x != nil {
+		return x.ImageCaptionModel
+	}
+	return ""
+}
+
+// AiAskRequest is the request to answer a question about the current user's memos.
+type AiAskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The question in natural language.
+	Question string `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	// Optional. Maximum number of memos the answer is grounded on.
+	// Defaults to 5; values above 20 are clamped to 20.
+	TopK          int32 `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiAskRequest) Reset() {
+	*x = AiAskRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiAskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiAskRequest) ProtoMessage() {}
+
+func (x *AiAskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiAskRequest.ProtoReflect.Descriptor instead.
+func (*AiAskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *AiAskRequest) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *AiAskRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+// AiAskResponse is the answer to a question about the current user's memos.
+type AiAskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The answer synthesized from the memos.
+	Answer string `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	// The UIDs of the memos cited by the answer, limited to those visible to the caller.
+	CitedMemoUids []string `protobuf:"bytes,2,rep,name=cited_memo_uids,json=citedMemoUids,proto3" json:"cited_memo_uids,omitempty"`
+	// The token usage of the answer.
+	Usage         *AiTokenUsage `protobuf:"bytes,3,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiAskResponse) Reset() {
+	*x = AiAskResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiAskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiAskResponse) ProtoMessage() {}
+
+func (x *AiAskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiAskResponse.ProtoReflect.Descriptor instead.
+func (*AiAskResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AiAskResponse) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *AiAskResponse) GetCitedMemoUids() []string {
+	if x != nil {
+		return x.CitedMemoUids
+	}
+	return nil
+}
+
+func (x *AiAskResponse) GetUsage() *AiTokenUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// AiSearchPageToken is the opaque cursor used to page through AI search results.
+type AiSearchPageToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The offset of the first result of the page, counting the results of the AI service, or of the
+	// snapshot, before the results of the same memo are merged.
+	Offset int32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The hash of the query the token was issued for.
+	QueryHash string `protobuf:"bytes,2,opt,name=query_hash,json=queryHash,proto3" json:"query_hash,omitempty"`
+	// The ID of the ranked result snapshot the token pages through, if any.
+	SnapshotId string `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// The UIDs of the memos returned by earlier pages, whose later results of the AI service are
+	// skipped so that no memo shows up on two pages.
+	ReturnedMemoUids []string `protobuf:"bytes,4,rep,name=returned_memo_uids,json=returnedMemoUids,proto3" json:"returned_memo_uids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AiSearchPageToken) Reset() {
+	*x = AiSearchPageToken{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiSearchPageToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiSearchPageToken) ProtoMessage() {}
+
+func (x *AiSearchPageToken) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiSearchPageToken.ProtoReflect.Descriptor instead.
+func (*AiSearchPageToken) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *AiSearchPageToken) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AiSearchPageToken) GetQueryHash() string {
+	if x != nil {
+		return x.QueryHash
+	}
+	return ""
+}
+
+func (x *AiSearchPageToken) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *AiSearchPageToken) GetReturnedMemoUids() []string {
+	if x != nil {
+		return x.ReturnedMemoUids
+	}
+	return nil
+}
+
+// RebuildIndexRequest is the request to rebuild all indexes.
+type RebuildIndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator whose indexes to rebuild.
+	// Format: users/{user}
+	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Optional. An http or https URL the AI service POSTs the final status to once the rebuild is
+	// completed, failed or cancelled, instead of having to poll GetRebuildStatus.
+	// The body is a JSON object with the creator and the snake_case fields of RebuildTaskStatus, e.g.
+	// {"creator": "users/1", "status": "completed", "started_at": "...", "finished_at": "...",
+	//  "total": 10, "completed": 9, "failed": 1, "error": "", "failed_memos": [...]}.
+	// URLs resolving to loopback, private or link-local addresses are rejected unless allowed by the
+	// AI setting allow_internal_callback_urls.
+	CallbackUrl   string `protobuf:"bytes,2,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildIndexRequest) Reset() {
+	*x = RebuildIndexRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildIndexRequest) ProtoMessage() {}
+
+func (x *RebuildIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildIndexRequest.ProtoReflect.Descriptor instead.
+func (*RebuildIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RebuildIndexRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *RebuildIndexRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+// RebuildIndexResponse is the response after starting rebuild.
+type RebuildIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator.
+	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// The status.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Total number of memos to index.
+	TotalMemos int32 `protobuf:"varint,3,opt,name=total_memos,json=totalMemos,proto3" json:"total_memos,omitempty"`
+	// The timestamp.
+	Timestamp     string `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildIndexResponse) Reset() {
+	*x = RebuildIndexResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildIndexResponse) ProtoMessage() {}
+
+func (x *RebuildIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildIndexResponse.ProtoReflect.Descriptor instead.
+func (*RebuildIndexResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RebuildIndexResponse) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *RebuildIndexResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RebuildIndexResponse) GetTotalMemos() int32 {
+	if x != nil {
+		return x.TotalMemos
+	}
+	return 0
+}
+
+func (x *RebuildIndexResponse) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+// VerifyIndexRequest is the request to verify the AI index of a user.
+type VerifyIndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator whose index to verify.
+	// Format: users/{user}
+	Creator       string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyIndexRequest) Reset() {
+	*x = VerifyIndexRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyIndexRequest) ProtoMessage() {}
+
+func (x *VerifyIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyIndexRequest.ProtoReflect.Descriptor instead.
+func (*VerifyIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *VerifyIndexRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+// VerifyIndexResponse is the result of comparing the AI index with the stored memos.
+type VerifyIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator.
+	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Whether the index checksum matches the checksum of the stored memos.
+	Match bool `protobuf:"varint,2,opt,name=match,proto3" json:"match,omitempty"`
+	// The checksum over the UIDs and content hashes of the creator's indexable memos.
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// The checksum reported by the AI service for the creator's indexed memos.
+	IndexChecksum string `protobuf:"bytes,4,opt,name=index_checksum,json=indexChecksum,proto3" json:"index_checksum,omitempty"`
+	// The UIDs of memos that are missing from the index, indexed with stale content,
+	// or indexed but no longer stored. Empty when the checksums match.
+	MismatchedMemoUids []string `protobuf:"bytes,5,rep,name=mismatched_memo_uids,json=mismatchedMemoUids,proto3" json:"mismatched_memo_uids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *VerifyIndexResponse) Reset() {
+	*x = VerifyIndexResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyIndexResponse) ProtoMessage() {}
+
+func (x *VerifyIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyIndexResponse.ProtoReflect.Descriptor instead.
+func (*VerifyIndexResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *VerifyIndexResponse) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *VerifyIndexResponse) GetMatch() bool {
+	if x != nil {
+		return x.Match
+	}
+	return false
+}
+
+func (x *VerifyIndexResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *VerifyIndexResponse) GetIndexChecksum() string {
+	if x != nil {
+		return x.IndexChecksum
+	}
+	return ""
+}
+
+func (x *VerifyIndexResponse) GetMismatchedMemoUids() []string {
+	if x != nil {
+		return x.MismatchedMemoUids
+	}
+	return nil
+}
+
+// DeleteCreatorIndexRequest is the request to delete the AI indexes of a user.
+type DeleteCreatorIndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator whose indexes to delete.
+	// Format: users/{user}
+	Creator       string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCreatorIndexRequest) Reset() {
+	*x = DeleteCreatorIndexRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCreatorIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCreatorIndexRequest) ProtoMessage() {}
+
+func (x *DeleteCreatorIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCreatorIndexRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCreatorIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DeleteCreatorIndexRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+// DeleteCreatorIndexResponse is the response after deleting the AI indexes of a user.
+type DeleteCreatorIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Success status.
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCreatorIndexResponse) Reset() {
+	*x = DeleteCreatorIndexResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCreatorIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCreatorIndexResponse) ProtoMessage() {}
+
+func (x *DeleteCreatorIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCreatorIndexResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCreatorIndexResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DeleteCreatorIndexResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// GetRebuildStatusRequest is the request to get rebuild status.
+type GetRebuildStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator whose rebuild status to get.
+	// Format: users/{user}
+	Creator       string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRebuildStatusRequest) Reset() {
+	*x = GetRebuildStatusRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRebuildStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRebuildStatusRequest) ProtoMessage() {}
+
+func (x *GetRebuildStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRebuildStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetRebuildStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetRebuildStatusRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+// RebuildTaskStatus contains the rebuild task status.
+type RebuildTaskStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The status: "pending", "running", "completed", "failed", "cancelled".
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The start time.
+	StartedAt string `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// The finish time.
+	FinishedAt string `protobuf:"bytes,3,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// Total memos to process.
+	Total int32 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Number of completed memos.
+	Completed int32 `protobuf:"varint,5,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Number of failed memos.
+	Failed int32 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Error message if failed.
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// The memos that failed to index. Empty if the AI service reports no failures.
+	FailedMemos   []*RebuildTaskStatus_FailedMemo `protobuf:"bytes,8,rep,name=failed_memos,json=failedMemos,proto3" json:"failed_memos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildTaskStatus) Reset() {
+	*x = RebuildTaskStatus{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildTaskStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildTaskStatus) ProtoMessage() {}
+
+func (x *RebuildTaskStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildTaskStatus.ProtoReflect.Descriptor instead.
+func (*RebuildTaskStatus) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RebuildTaskStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RebuildTaskStatus) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *RebuildTaskStatus) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *RebuildTaskStatus) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *RebuildTaskStatus) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *RebuildTaskStatus) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RebuildTaskStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RebuildTaskStatus) GetFailedMemos() []*RebuildTaskStatus_FailedMemo {
+	if x != nil {
+		return x.FailedMemos
+	}
+	return nil
+}
+
+// ListIndexedMemosRequest is the request to list the memos indexed for a user.
+type ListIndexedMemosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator whose indexed memos to list.
+	// Format: users/{user}
+	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Optional. A page token from a previous ListIndexedMemos call.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIndexedMemosRequest) Reset() {
+	*x = ListIndexedMemosRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIndexedMemosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIndexedMemosRequest) ProtoMessage() {}
+
+func (x *ListIndexedMemosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIndexedMemosRequest.ProtoReflect.Descriptor instead.
+func (*ListIndexedMemosRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListIndexedMemosRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *ListIndexedMemosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListIndexedMemosResponse is a page of the memos indexed for a user.
+type ListIndexedMemosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The uids of the indexed memos.
+	MemoUids []string `protobuf:"bytes,1,rep,name=memo_uids,json=memoUids,proto3" json:"memo_uids,omitempty"`
+	// A token to retrieve the next page. Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIndexedMemosResponse) Reset() {
+	*x = ListIndexedMemosResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIndexedMemosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIndexedMemosResponse) ProtoMessage() {}
+
+func (x *ListIndexedMemosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIndexedMemosResponse.ProtoReflect.Descriptor instead.
+func (*ListIndexedMemosResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListIndexedMemosResponse) GetMemoUids() []string {
+	if x != nil {
+		return x.MemoUids
+	}
+	return nil
+}
+
+func (x *ListIndexedMemosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ReconcileIndexRequest is the request to sync the AI index of a user with their memos.
+type ReconcileIndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator whose index to reconcile.
+	// Format: users/{user}
+	Creator       string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileIndexRequest) Reset() {
+	*x = ReconcileIndexRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileIndexRequest) ProtoMessage() {}
+
+func (x *ReconcileIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileIndexRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ReconcileIndexRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+// ReconcileIndexResponse is the result of syncing the AI index of a user with their memos.
+// Memos that failed are listed, and the counts cover the memos that succeeded.
+type ReconcileIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The creator.
+	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Number of memos that were missing from the index and have been indexed.
+	Added int32 `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	// Number of orphaned indexes that have been deleted.
+	Removed int32 `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	// Number of memos that were already indexed.
+	Unchanged int32 `protobuf:"varint,4,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	// The memos that failed to be indexed or deleted.
+	Failures      []*ReconcileIndexResponse_Failure `protobuf:"bytes,5,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileIndexResponse) Reset() {
+	*x = ReconcileIndexResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileIndexResponse) ProtoMessage() {}
+
+func (x *ReconcileIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileIndexResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileIndexResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ReconcileIndexResponse) GetCreator() string {
+	if x != nil {
+		return x.Creator
 	}
 	return ""
 }
 
-func (x *AiSearchResult) GetScore() float32 {
+func (x *ReconcileIndexResponse) GetAdded() int32 {
 	if x != nil {
-		return x.Score
+		return x.Added
 	}
 	return 0
 }
 
-func (x *AiSearchResult) GetMatchType() string {
+func (x *ReconcileIndexResponse) GetRemoved() int32 {
 	if x != nil {
-		return x.MatchType
+		return x.Removed
 	}
-	return ""
+	return 0
 }
 
-// RebuildIndexRequest is the request to rebuild all indexes.
-type RebuildIndexRequest struct {
+func (x *ReconcileIndexResponse) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ReconcileIndexResponse) GetFailures() []*ReconcileIndexResponse_Failure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// CancelRebuildRequest is the request to cancel a rebuild index task.
+type CancelRebuildRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The creator whose indexes to rebuild.
+	// The creator whose rebuild to cancel.
 	// Format: users/{user}
 	Creator       string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RebuildIndexRequest) Reset() {
-	*x = RebuildIndexRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[37]
+func (x *CancelRebuildRequest) Reset() {
+	*x = CancelRebuildRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RebuildIndexRequest) String() string {
+func (x *CancelRebuildRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RebuildIndexRequest) ProtoMessage() {}
+func (*CancelRebuildRequest) ProtoMessage() {}
 
-func (x *RebuildIndexRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[37]
+func (x *CancelRebuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2559,48 +4839,134 @@ func (x *RebuildIndexRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RebuildIndexRequest.ProtoReflect.Descriptor instead.
-func (*RebuildIndexRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use CancelRebuildRequest.ProtoReflect.Descriptor instead.
+func (*CancelRebuildRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *RebuildIndexRequest) GetCreator() string {
+func (x *CancelRebuildRequest) GetCreator() string {
 	if x != nil {
 		return x.Creator
 	}
 	return ""
 }
 
-// RebuildIndexResponse is the response after starting rebuild.
-type RebuildIndexResponse struct {
+// CancelRebuildResponse is the response after cancelling a rebuild index task.
+// Cancelling a task that has finished or doesn't exist succeeds too.
+type CancelRebuildResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The creator.
-	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
-	// The status.
+	// Success status.
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRebuildResponse) Reset() {
+	*x = CancelRebuildResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRebuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRebuildResponse) ProtoMessage() {}
+
+func (x *CancelRebuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRebuildResponse.ProtoReflect.Descriptor instead.
+func (*CancelRebuildResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CancelRebuildResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// AiHealthCheckRequest is the request to check AI service health.
+type AiHealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiHealthCheckRequest) Reset() {
+	*x = AiHealthCheckRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiHealthCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiHealthCheckRequest) ProtoMessage() {}
+
+func (x *AiHealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiHealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*AiHealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{71}
+}
+
+// AiHealthCheckResponse is the response of AI health check.
+type AiHealthCheckResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the AI service is healthy.
+	Healthy bool `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// The status reported by the AI service, e.g. "ok". Empty if not reported.
 	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	// Total number of memos to index.
-	TotalMemos int32 `protobuf:"varint,3,opt,name=total_memos,json=totalMemos,proto3" json:"total_memos,omitempty"`
-	// The timestamp.
-	Timestamp     string `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The version of the AI service. Empty if not reported.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// The embedding model in use. Empty if not reported.
+	EmbeddingModel string `protobuf:"bytes,4,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
+	// Whether the index backend is reachable. Unset if not reported.
+	VectorStoreOk *bool `protobuf:"varint,5,opt,name=vector_store_ok,json=vectorStoreOk,proto3,oneof" json:"vector_store_ok,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RebuildIndexResponse) Reset() {
-	*x = RebuildIndexResponse{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[38]
+func (x *AiHealthCheckResponse) Reset() {
+	*x = AiHealthCheckResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RebuildIndexResponse) String() string {
+func (x *AiHealthCheckResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RebuildIndexResponse) ProtoMessage() {}
+func (*AiHealthCheckResponse) ProtoMessage() {}
 
-func (x *RebuildIndexResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[38]
+func (x *AiHealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2611,64 +4977,201 @@ func (x *RebuildIndexResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RebuildIndexResponse.ProtoReflect.Descriptor instead.
-func (*RebuildIndexResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use AiHealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*AiHealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *RebuildIndexResponse) GetCreator() string {
+func (x *AiHealthCheckResponse) GetHealthy() bool {
 	if x != nil {
-		return x.Creator
+		return x.Healthy
 	}
-	return ""
+	return false
 }
 
-func (x *RebuildIndexResponse) GetStatus() string {
+func (x *AiHealthCheckResponse) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-func (x *RebuildIndexResponse) GetTotalMemos() int32 {
+func (x *AiHealthCheckResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AiHealthCheckResponse) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
+}
+
+func (x *AiHealthCheckResponse) GetVectorStoreOk() bool {
+	if x != nil && x.VectorStoreOk != nil {
+		return *x.VectorStoreOk
+	}
+	return false
+}
+
+// Computed properties of a memo.
+type Memo_Property struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	HasLink            bool                   `protobuf:"varint,1,opt,name=has_link,json=hasLink,proto3" json:"has_link,omitempty"`
+	HasTaskList        bool                   `protobuf:"varint,2,opt,name=has_task_list,json=hasTaskList,proto3" json:"has_task_list,omitempty"`
+	HasCode            bool                   `protobuf:"varint,3,opt,name=has_code,json=hasCode,proto3" json:"has_code,omitempty"`
+	HasIncompleteTasks bool                   `protobuf:"varint,4,opt,name=has_incomplete_tasks,json=hasIncompleteTasks,proto3" json:"has_incomplete_tasks,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Memo_Property) Reset() {
+	*x = Memo_Property{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Memo_Property) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Memo_Property) ProtoMessage() {}
+
+func (x *Memo_Property) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Memo_Property.ProtoReflect.Descriptor instead.
+func (*Memo_Property) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *Memo_Property) GetHasLink() bool {
+	if x != nil {
+		return x.HasLink
+	}
+	return false
+}
+
+func (x *Memo_Property) GetHasTaskList() bool {
+	if x != nil {
+		return x.HasTaskList
+	}
+	return false
+}
+
+func (x *Memo_Property) GetHasCode() bool {
+	if x != nil {
+		return x.HasCode
+	}
+	return false
+}
+
+func (x *Memo_Property) GetHasIncompleteTasks() bool {
+	if x != nil {
+		return x.HasIncompleteTasks
+	}
+	return false
+}
+
+// Memo reference in relations.
+type MemoRelation_Memo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resource name of the memo.
+	// Format: memos/{memo}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Output only. The snippet of the memo content. Plain text only.
+	Snippet       string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoRelation_Memo) Reset() {
+	*x = MemoRelation_Memo{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoRelation_Memo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoRelation_Memo) ProtoMessage() {}
+
+func (x *MemoRelation_Memo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoRelation_Memo.ProtoReflect.Descriptor instead.
+func (*MemoRelation_Memo) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *MemoRelation_Memo) GetName() string {
 	if x != nil {
-		return x.TotalMemos
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *RebuildIndexResponse) GetTimestamp() string {
+func (x *MemoRelation_Memo) GetSnippet() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Snippet
 	}
 	return ""
 }
 
-// GetRebuildStatusRequest is the request to get rebuild status.
-type GetRebuildStatusRequest struct {
+// Result is the outcome of tag generation for a single memo.
+type BatchGenerateAiTagsResponse_Result struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The creator whose rebuild status to get.
-	// Format: users/{user}
-	Creator       string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// The generated AI tags, as suggested by the AI service.
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The suggested tags that were consolidated with the user's existing tags.
+	MergedTags []string `protobuf:"bytes,2,rep,name=merged_tags,json=mergedTags,proto3" json:"merged_tags,omitempty"`
+	// The token usage of the tag generation, if reported by the AI service.
+	Usage *AiTokenUsage `protobuf:"bytes,3,opt,name=usage,proto3" json:"usage,omitempty"`
+	// The error message if tags couldn't be generated for the memo. Empty on success.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRebuildStatusRequest) Reset() {
-	*x = GetRebuildStatusRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[39]
+func (x *BatchGenerateAiTagsResponse_Result) Reset() {
+	*x = BatchGenerateAiTagsResponse_Result{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRebuildStatusRequest) String() string {
+func (x *BatchGenerateAiTagsResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRebuildStatusRequest) ProtoMessage() {}
+func (*BatchGenerateAiTagsResponse_Result) ProtoMessage() {}
 
-func (x *GetRebuildStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[39]
+func (x *BatchGenerateAiTagsResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2679,54 +5182,69 @@ func (x *GetRebuildStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRebuildStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetRebuildStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use BatchGenerateAiTagsResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchGenerateAiTagsResponse_Result) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{26, 1}
 }
 
-func (x *GetRebuildStatusRequest) GetCreator() string {
+func (x *BatchGenerateAiTagsResponse_Result) GetTags() []string {
 	if x != nil {
-		return x.Creator
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *BatchGenerateAiTagsResponse_Result) GetMergedTags() []string {
+	if x != nil {
+		return x.MergedTags
+	}
+	return nil
+}
+
+func (x *BatchGenerateAiTagsResponse_Result) GetUsage() *AiTokenUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *BatchGenerateAiTagsResponse_Result) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-// RebuildTaskStatus contains the rebuild task status.
-type RebuildTaskStatus struct {
+// MemoInfo is the part of a memo needed to render a search result.
+type AiSearchResult_MemoInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The status: "pending", "running", "completed", "failed".
-	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	// The start time.
-	StartedAt string `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	// The finish time.
-	FinishedAt string `protobuf:"bytes,3,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
-	// Total memos to process.
-	Total int32 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
-	// Number of completed memos.
-	Completed int32 `protobuf:"varint,5,opt,name=completed,proto3" json:"completed,omitempty"`
-	// Number of failed memos.
-	Failed int32 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
-	// Error message if failed.
-	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// The display time of the memo.
+	DisplayTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=display_time,json=displayTime,proto3" json:"display_time,omitempty"`
+	// A plain text snippet of the memo content.
+	Snippet string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	// Whether the memo is pinned.
+	Pinned bool `protobuf:"varint,3,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// The visibility of the memo.
+	Visibility    Visibility `protobuf:"varint,4,opt,name=visibility,proto3,enum=memos.api.v1.Visibility" json:"visibility,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RebuildTaskStatus) Reset() {
-	*x = RebuildTaskStatus{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[40]
+func (x *AiSearchResult_MemoInfo) Reset() {
+	*x = AiSearchResult_MemoInfo{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RebuildTaskStatus) String() string {
+func (x *AiSearchResult_MemoInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RebuildTaskStatus) ProtoMessage() {}
+func (*AiSearchResult_MemoInfo) ProtoMessage() {}
 
-func (x *RebuildTaskStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[40]
+func (x *AiSearchResult_MemoInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2737,82 +5255,70 @@ func (x *RebuildTaskStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RebuildTaskStatus.ProtoReflect.Descriptor instead.
-func (*RebuildTaskStatus) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{40}
-}
-
-func (x *RebuildTaskStatus) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+// Deprecated: Use AiSearchResult_MemoInfo.ProtoReflect.Descriptor instead.
+func (*AiSearchResult_MemoInfo) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{47, 0}
 }
 
-func (x *RebuildTaskStatus) GetStartedAt() string {
+func (x *AiSearchResult_MemoInfo) GetDisplayTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.StartedAt
+		return x.DisplayTime
 	}
-	return ""
+	return nil
 }
 
-func (x *RebuildTaskStatus) GetFinishedAt() string {
+func (x *AiSearchResult_MemoInfo) GetSnippet() string {
 	if x != nil {
-		return x.FinishedAt
+		return x.Snippet
 	}
 	return ""
 }
 
-func (x *RebuildTaskStatus) GetTotal() int32 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-func (x *RebuildTaskStatus) GetCompleted() int32 {
-	if x != nil {
-		return x.Completed
-	}
-	return 0
-}
-
-func (x *RebuildTaskStatus) GetFailed() int32 {
+func (x *AiSearchResult_MemoInfo) GetPinned() bool {
 	if x != nil {
-		return x.Failed
+		return x.Pinned
 	}
-	return 0
+	return false
 }
 
-func (x *RebuildTaskStatus) GetError() string {
+func (x *AiSearchResult_MemoInfo) GetVisibility() Visibility {
 	if x != nil {
-		return x.Error
+		return x.Visibility
 	}
-	return ""
+	return Visibility_VISIBILITY_UNSPECIFIED
 }
 
-// AiHealthCheckRequest is the request to check AI service health.
-type AiHealthCheckRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// ScoreBreakdown lists the components the score of a result combines.
+// Components that didn't contribute to the score are 0.
+type AiSearchResult_ScoreBreakdown struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The score of the text match.
+	TextScore float32 `protobuf:"fixed32,1,opt,name=text_score,json=textScore,proto3" json:"text_score,omitempty"`
+	// The score of the image match.
+	ImageScore float32 `protobuf:"fixed32,2,opt,name=image_score,json=imageScore,proto3" json:"image_score,omitempty"`
+	// The score of the keyword match.
+	KeywordScore float32 `protobuf:"fixed32,3,opt,name=keyword_score,json=keywordScore,proto3" json:"keyword_score,omitempty"`
+	// The combined score the results are ranked by.
+	FinalScore    float32 `protobuf:"fixed32,4,opt,name=final_score,json=finalScore,proto3" json:"final_score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AiHealthCheckRequest) Reset() {
-	*x = AiHealthCheckRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[41]
+func (x *AiSearchResult_ScoreBreakdown) Reset() {
+	*x = AiSearchResult_ScoreBreakdown{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AiHealthCheckRequest) String() string {
+func (x *AiSearchResult_ScoreBreakdown) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AiHealthCheckRequest) ProtoMessage() {}
+func (*AiSearchResult_ScoreBreakdown) ProtoMessage() {}
 
-func (x *AiHealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[41]
+func (x *AiSearchResult_ScoreBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2823,35 +5329,65 @@ func (x *AiHealthCheckRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AiHealthCheckRequest.ProtoReflect.Descriptor instead.
-func (*AiHealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use AiSearchResult_ScoreBreakdown.ProtoReflect.Descriptor instead.
+func (*AiSearchResult_ScoreBreakdown) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{47, 1}
 }
 
-// AiHealthCheckResponse is the response of AI health check.
-type AiHealthCheckResponse struct {
+func (x *AiSearchResult_ScoreBreakdown) GetTextScore() float32 {
+	if x != nil {
+		return x.TextScore
+	}
+	return 0
+}
+
+func (x *AiSearchResult_ScoreBreakdown) GetImageScore() float32 {
+	if x != nil {
+		return x.ImageScore
+	}
+	return 0
+}
+
+func (x *AiSearchResult_ScoreBreakdown) GetKeywordScore() float32 {
+	if x != nil {
+		return x.KeywordScore
+	}
+	return 0
+}
+
+func (x *AiSearchResult_ScoreBreakdown) GetFinalScore() float32 {
+	if x != nil {
+		return x.FinalScore
+	}
+	return 0
+}
+
+// HighlightRange is a range of the snippet in Unicode code points.
+type AiSearchResult_HighlightRange struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Whether the AI service is healthy.
-	Healthy       bool `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// The start offset, inclusive.
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// The end offset, exclusive.
+	End           int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AiHealthCheckResponse) Reset() {
-	*x = AiHealthCheckResponse{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[42]
+func (x *AiSearchResult_HighlightRange) Reset() {
+	*x = AiSearchResult_HighlightRange{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AiHealthCheckResponse) String() string {
+func (x *AiSearchResult_HighlightRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AiHealthCheckResponse) ProtoMessage() {}
+func (*AiSearchResult_HighlightRange) ProtoMessage() {}
 
-func (x *AiHealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[42]
+func (x *AiSearchResult_HighlightRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2862,44 +5398,51 @@ func (x *AiHealthCheckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AiHealthCheckResponse.ProtoReflect.Descriptor instead.
-func (*AiHealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use AiSearchResult_HighlightRange.ProtoReflect.Descriptor instead.
+func (*AiSearchResult_HighlightRange) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{47, 2}
 }
 
-func (x *AiHealthCheckResponse) GetHealthy() bool {
+func (x *AiSearchResult_HighlightRange) GetStart() int32 {
 	if x != nil {
-		return x.Healthy
+		return x.Start
 	}
-	return false
+	return 0
 }
 
-// Computed properties of a memo.
-type Memo_Property struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	HasLink            bool                   `protobuf:"varint,1,opt,name=has_link,json=hasLink,proto3" json:"has_link,omitempty"`
-	HasTaskList        bool                   `protobuf:"varint,2,opt,name=has_task_list,json=hasTaskList,proto3" json:"has_task_list,omitempty"`
-	HasCode            bool                   `protobuf:"varint,3,opt,name=has_code,json=hasCode,proto3" json:"has_code,omitempty"`
-	HasIncompleteTasks bool                   `protobuf:"varint,4,opt,name=has_incomplete_tasks,json=hasIncompleteTasks,proto3" json:"has_incomplete_tasks,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *AiSearchResult_HighlightRange) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
 }
 
-func (x *Memo_Property) Reset() {
-	*x = Memo_Property{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[43]
+// FailedMemo is a memo that failed to index.
+type RebuildTaskStatus_FailedMemo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The memo uid.
+	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
+	// The error the memo failed with.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildTaskStatus_FailedMemo) Reset() {
+	*x = RebuildTaskStatus_FailedMemo{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Memo_Property) String() string {
+func (x *RebuildTaskStatus_FailedMemo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Memo_Property) ProtoMessage() {}
+func (*RebuildTaskStatus_FailedMemo) ProtoMessage() {}
 
-func (x *Memo_Property) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[43]
+func (x *RebuildTaskStatus_FailedMemo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2910,66 +5453,51 @@ func (x *Memo_Property) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Memo_Property.ProtoReflect.Descriptor instead.
-func (*Memo_Property) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{1, 0}
-}
-
-func (x *Memo_Property) GetHasLink() bool {
-	if x != nil {
-		return x.HasLink
-	}
-	return false
-}
-
-func (x *Memo_Property) GetHasTaskList() bool {
-	if x != nil {
-		return x.HasTaskList
-	}
-	return false
+// Deprecated: Use RebuildTaskStatus_FailedMemo.ProtoReflect.Descriptor instead.
+func (*RebuildTaskStatus_FailedMemo) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{64, 0}
 }
 
-func (x *Memo_Property) GetHasCode() bool {
+func (x *RebuildTaskStatus_FailedMemo) GetMemoUid() string {
 	if x != nil {
-		return x.HasCode
+		return x.MemoUid
 	}
-	return false
+	return ""
 }
 
-func (x *Memo_Property) GetHasIncompleteTasks() bool {
+func (x *RebuildTaskStatus_FailedMemo) GetError() string {
 	if x != nil {
-		return x.HasIncompleteTasks
+		return x.Error
 	}
-	return false
+	return ""
 }
 
-// Memo reference in relations.
-type MemoRelation_Memo struct {
+// Failure is a memo that failed to be reconciled.
+type ReconcileIndexResponse_Failure struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The resource name of the memo.
-	// Format: memos/{memo}
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Output only. The snippet of the memo content. Plain text only.
-	Snippet       string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	// The memo uid.
+	MemoUid string `protobuf:"bytes,1,opt,name=memo_uid,json=memoUid,proto3" json:"memo_uid,omitempty"`
+	// The error the memo failed with.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MemoRelation_Memo) Reset() {
-	*x = MemoRelation_Memo{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[44]
+func (x *ReconcileIndexResponse_Failure) Reset() {
+	*x = ReconcileIndexResponse_Failure{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MemoRelation_Memo) String() string {
+func (x *ReconcileIndexResponse_Failure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MemoRelation_Memo) ProtoMessage() {}
+func (*ReconcileIndexResponse_Failure) ProtoMessage() {}
 
-func (x *MemoRelation_Memo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[44]
+func (x *ReconcileIndexResponse_Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2980,21 +5508,21 @@ func (x *MemoRelation_Memo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MemoRelation_Memo.ProtoReflect.Descriptor instead.
-func (*MemoRelation_Memo) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{12, 0}
+// Deprecated: Use ReconcileIndexResponse_Failure.ProtoReflect.Descriptor instead.
+func (*ReconcileIndexResponse_Failure) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{68, 0}
 }
 
-func (x *MemoRelation_Memo) GetName() string {
+func (x *ReconcileIndexResponse_Failure) GetMemoUid() string {
 	if x != nil {
-		return x.Name
+		return x.MemoUid
 	}
 	return ""
 }
 
-func (x *MemoRelation_Memo) GetSnippet() string {
+func (x *ReconcileIndexResponse_Failure) GetError() string {
 	if x != nil {
-		return x.Snippet
+		return x.Error
 	}
 	return ""
 }
@@ -3151,34 +5679,98 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\breaction\x18\x02 \x01(\v2\x16.memos.api.v1.ReactionB\x03\xe0A\x02R\breaction\"N\n" +
 	"\x19DeleteMemoReactionRequest\x121\n" +
 	"\x04name\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\n" +
-	"\x15memos.api.v1/ReactionR\x04name\"F\n" +
+	"\x15memos.api.v1/ReactionR\x04name\"\xca\x01\n" +
 	"\x15GenerateAiTagsRequest\x12-\n" +
 	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
-	"\x11memos.api.v1/MemoR\x04name\",\n" +
+	"\x11memos.api.v1/MemoR\x04name\x12\x1e\n" +
+	"\bmax_tags\x18\x02 \x01(\x05B\x03\xe0A\x01R\amaxTags\x12\x19\n" +
+	"\x05model\x18\x03 \x01(\tB\x03\xe0A\x01R\x05model\x12&\n" +
+	"\fexclude_tags\x18\x04 \x03(\tB\x03\xe0A\x01R\vexcludeTags\x12\x1f\n" +
+	"\blanguage\x18\x05 \x01(\tB\x03\xe0A\x01R\blanguage\"\x7f\n" +
 	"\x16GenerateAiTagsResponse\x12\x12\n" +
-	"\x04tags\x18\x01 \x03(\tR\x04tags\"A\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vmerged_tags\x18\x02 \x03(\tR\n" +
+	"mergedTags\x120\n" +
+	"\x05usage\x18\x03 \x01(\v2\x1a.memos.api.v1.AiTokenUsageR\x05usage\"\xb6\x01\n" +
+	"\x1aBatchGenerateAiTagsRequest\x12/\n" +
+	"\x05names\x18\x01 \x03(\tB\x19\xe0A\x02\xfaA\x13\n" +
+	"\x11memos.api.v1/MemoR\x05names\x12\x1e\n" +
+	"\bmax_tags\x18\x02 \x01(\x05B\x03\xe0A\x01R\amaxTags\x12&\n" +
+	"\fexclude_tags\x18\x03 \x03(\tB\x03\xe0A\x01R\vexcludeTags\x12\x1f\n" +
+	"\blanguage\x18\x04 \x01(\tB\x03\xe0A\x01R\blanguage\"\xe5\x02\n" +
+	"\x1bBatchGenerateAiTagsResponse\x12P\n" +
+	"\aresults\x18\x01 \x03(\v26.memos.api.v1.BatchGenerateAiTagsResponse.ResultsEntryR\aresults\x1al\n" +
+	"\fResultsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12F\n" +
+	"\x05value\x18\x02 \x01(\v20.memos.api.v1.BatchGenerateAiTagsResponse.ResultR\x05value:\x028\x01\x1a\x85\x01\n" +
+	"\x06Result\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vmerged_tags\x18\x02 \x03(\tR\n" +
+	"mergedTags\x120\n" +
+	"\x05usage\x18\x03 \x01(\v2\x1a.memos.api.v1.AiTokenUsageR\x05usage\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\\\n" +
+	"\x12ApplyAiTagsRequest\x12-\n" +
+	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
+	"\x11memos.api.v1/MemoR\x04name\x12\x17\n" +
+	"\x04tags\x18\x02 \x03(\tB\x03\xe0A\x01R\x04tags\".\n" +
+	"\x13ApplyAiTagsResponse\x12\x17\n" +
+	"\aai_tags\x18\x01 \x03(\tR\x06aiTags\"\x86\x01\n" +
+	"\x12AiSummarizeRequest\x12-\n" +
+	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
+	"\x11memos.api.v1/MemoR\x04name\x12 \n" +
+	"\tmax_words\x18\x02 \x01(\x05B\x03\xe0A\x01R\bmaxWords\x12\x1f\n" +
+	"\blanguage\x18\x03 \x01(\tB\x03\xe0A\x01R\blanguage\"a\n" +
+	"\x13AiSummarizeResponse\x12\x18\n" +
+	"\asummary\x18\x01 \x01(\tR\asummary\x120\n" +
+	"\x05usage\x18\x02 \x01(\v2\x1a.memos.api.v1.AiTokenUsageR\x05usage\"\x83\x01\n" +
+	"\fAiTokenUsage\x12#\n" +
+	"\rprompt_tokens\x18\x01 \x01(\x05R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\x02 \x01(\x05R\x10completionTokens\x12!\n" +
+	"\ftotal_tokens\x18\x03 \x01(\x05R\vtotalTokens\"\x7f\n" +
 	"\x10IndexMemoRequest\x12-\n" +
 	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
-	"\x11memos.api.v1/MemoR\x04name\"d\n" +
+	"\x11memos.api.v1/MemoR\x04name\x12!\n" +
+	"\toperation\x18\x02 \x01(\tB\x03\xe0A\x01R\toperation\x12\x19\n" +
+	"\x05force\x18\x03 \x01(\bB\x03\xe0A\x01R\x05force\"~\n" +
 	"\x11IndexMemoResponse\x12\x19\n" +
 	"\bmemo_uid\x18\x01 \x01(\tR\amemoUid\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\tR\ttimestamp\"G\n" +
+	"\ttimestamp\x18\x03 \x01(\tR\ttimestamp\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\bR\askipped\"H\n" +
+	"\x17RefreshMemoIndexRequest\x12-\n" +
+	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
+	"\x11memos.api.v1/MemoR\x04name\"\xb3\x01\n" +
+	"\x18RefreshMemoIndexResponse\x12\x19\n" +
+	"\bmemo_uid\x18\x01 \x01(\tR\amemoUid\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\tR\ttimestamp\x12!\n" +
+	"\ftext_vectors\x18\x04 \x01(\x05R\vtextVectors\x12#\n" +
+	"\rimage_vectors\x18\x05 \x01(\x05R\fimageVectors\"G\n" +
 	"\x16DeleteMemoIndexRequest\x12-\n" +
 	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
-	"\x11memos.api.v1/MemoR\x04name\"3\n" +
+	"\x11memos.api.v1/MemoR\x04name\"M\n" +
 	"\x17DeleteMemoIndexResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\apending\x18\x02 \x01(\bR\apending\"h\n" +
+	"\x1bDeleteMemoIndexChunkRequest\x12-\n" +
+	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
+	"\x11memos.api.v1/MemoR\x04name\x12\x1a\n" +
+	"\x06doc_id\x18\x02 \x01(\tB\x03\xe0A\x02R\x05docId\"8\n" +
+	"\x1cDeleteMemoIndexChunkResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"t\n" +
 	"\x17GetMemoIndexInfoRequest\x12-\n" +
 	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
 	"\x11memos.api.v1/MemoR\x04name\x12*\n" +
-	"\x0einclude_detail\x18\x02 \x01(\bB\x03\xe0A\x01R\rincludeDetail\"\xc3\x01\n" +
+	"\x0einclude_detail\x18\x02 \x01(\bB\x03\xe0A\x01R\rincludeDetail\"\x94\x02\n" +
 	"\rMemoIndexInfo\x12\x19\n" +
 	"\bmemo_uid\x18\x01 \x01(\tR\amemoUid\x12\x18\n" +
 	"\aindexed\x18\x02 \x01(\bR\aindexed\x12!\n" +
 	"\ftext_vectors\x18\x03 \x01(\x05R\vtextVectors\x12#\n" +
 	"\rimage_vectors\x18\x04 \x01(\x05R\fimageVectors\x125\n" +
-	"\x06detail\x18\x05 \x01(\v2\x1d.memos.api.v1.MemoIndexDetailR\x06detail\"|\n" +
+	"\x06detail\x18\x05 \x01(\v2\x1d.memos.api.v1.MemoIndexDetailR\x06detail\x129\n" +
+	"\n" +
+	"indexed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\x12\x14\n" +
+	"\x05stale\x18\a \x01(\bR\x05stale\"|\n" +
 	"\x0fMemoIndexDetail\x128\n" +
 	"\vtext_chunks\x18\x01 \x03(\v2\x17.memos.api.v1.TextChunkR\n" +
 	"textChunks\x12/\n" +
@@ -3190,36 +5782,130 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\tImageInfo\x12\x15\n" +
 	"\x06doc_id\x18\x01 \x01(\tR\x05docId\x12\x1a\n" +
 	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x18\n" +
-	"\acaption\x18\x03 \x01(\tR\acaption\"\x99\x01\n" +
+	"\acaption\x18\x03 \x01(\tR\acaption\"\xa4\x05\n" +
 	"\x0fAiSearchRequest\x12\x19\n" +
 	"\x05query\x18\x01 \x01(\tB\x03\xe0A\x02R\x05query\x12\x13\n" +
 	"\x05top_k\x18\x02 \x01(\x05R\x04topK\x12\x1f\n" +
 	"\vsearch_mode\x18\x03 \x01(\tR\n" +
 	"searchMode\x12\x1b\n" +
 	"\tmin_score\x18\x04 \x01(\x02R\bminScore\x12\x18\n" +
-	"\acreator\x18\x05 \x01(\tR\acreator\"\xa6\x01\n" +
+	"\acreator\x18\x05 \x01(\tR\acreator\x12 \n" +
+	"\tpage_size\x18\x06 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tB\x03\xe0A\x01R\tpageToken\x12\x19\n" +
+	"\x05model\x18\b \x01(\tB\x03\xe0A\x01R\x05model\x12\x17\n" +
+	"\x04tags\x18\t \x03(\tB\x03\xe0A\x01R\x04tags\x12D\n" +
+	"\rcreated_after\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\fcreatedAfter\x12F\n" +
+	"\x0ecreated_before\x18\v \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\rcreatedBefore\x12\x1f\n" +
+	"\bsnapshot\x18\f \x01(\bB\x03\xe0A\x01R\bsnapshot\x12>\n" +
+	"\x05scope\x18\r \x01(\x0e2#.memos.api.v1.AiSearchRequest.ScopeB\x03\xe0A\x01R\x05scope\x12&\n" +
+	"\finclude_memo\x18\x0e \x01(\bB\x03\xe0A\x01R\vincludeMemo\x12\x1d\n" +
+	"\aexplain\x18\x0f \x01(\bB\x03\xe0A\x01R\aexplain\"Y\n" +
+	"\x05Scope\x12\x15\n" +
+	"\x11SCOPE_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tSCOPE_OWN\x10\x01\x12\x10\n" +
+	"\fSCOPE_PUBLIC\x10\x02\x12\x18\n" +
+	"\x14SCOPE_ALL_ACCESSIBLE\x10\x03\"\xf6\x01\n" +
 	"\x10AiSearchResponse\x126\n" +
 	"\aresults\x18\x01 \x03(\v2\x1c.memos.api.v1.AiSearchResultR\aresults\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1f\n" +
 	"\vsearch_mode\x18\x03 \x01(\tR\n" +
 	"searchMode\x12#\n" +
-	"\rtotal_results\x18\x04 \x01(\x05R\ftotalResults\"}\n" +
+	"\rtotal_results\x18\x04 \x01(\x05R\ftotalResults\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\x12&\n" +
+	"\x0ftag_filter_mode\x18\x06 \x01(\tR\rtagFilterMode\"\xec\x06\n" +
 	"\x0eAiSearchResult\x12\x19\n" +
 	"\bmemo_uid\x18\x01 \x01(\tR\amemoUid\x12\x1b\n" +
 	"\tmemo_name\x18\x02 \x01(\tR\bmemoName\x12\x14\n" +
 	"\x05score\x18\x03 \x01(\x02R\x05score\x12\x1d\n" +
 	"\n" +
-	"match_type\x18\x04 \x01(\tR\tmatchType\"4\n" +
+	"match_type\x18\x04 \x01(\tR\tmatchType\x12!\n" +
+	"\fmatched_text\x18\x05 \x01(\tR\vmatchedText\x12\x18\n" +
+	"\asnippet\x18\x06 \x01(\tR\asnippet\x12K\n" +
+	"\n" +
+	"highlights\x18\a \x03(\v2+.memos.api.v1.AiSearchResult.HighlightRangeR\n" +
+	"highlights\x12\x1d\n" +
+	"\n" +
+	"index_type\x18\b \x01(\tR\tindexType\x12(\n" +
+	"\x10matched_chunk_id\x18\t \x01(\tR\x0ematchedChunkId\x129\n" +
+	"\x04memo\x18\n" +
+	" \x01(\v2%.memos.api.v1.AiSearchResult.MemoInfoR\x04memo\x12T\n" +
+	"\x0fscore_breakdown\x18\v \x01(\v2+.memos.api.v1.AiSearchResult.ScoreBreakdownR\x0escoreBreakdown\x1a\xb5\x01\n" +
+	"\bMemoInfo\x12=\n" +
+	"\fdisplay_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vdisplayTime\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\x12\x16\n" +
+	"\x06pinned\x18\x03 \x01(\bR\x06pinned\x128\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\x18.memos.api.v1.VisibilityR\n" +
+	"visibility\x1a\x96\x01\n" +
+	"\x0eScoreBreakdown\x12\x1d\n" +
+	"\n" +
+	"text_score\x18\x01 \x01(\x02R\ttextScore\x12\x1f\n" +
+	"\vimage_score\x18\x02 \x01(\x02R\n" +
+	"imageScore\x12#\n" +
+	"\rkeyword_score\x18\x03 \x01(\x02R\fkeywordScore\x12\x1f\n" +
+	"\vfinal_score\x18\x04 \x01(\x02R\n" +
+	"finalScore\x1a8\n" +
+	"\x0eHighlightRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"a\n" +
+	"\x16GetRelatedMemosRequest\x12-\n" +
+	"\x04name\x18\x01 \x01(\tB\x19\xe0A\x02\xfaA\x13\n" +
+	"\x11memos.api.v1/MemoR\x04name\x12\x18\n" +
+	"\x05top_k\x18\x02 \x01(\x05B\x03\xe0A\x01R\x04topK\"Q\n" +
+	"\x17GetRelatedMemosResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.memos.api.v1.AiSearchResultR\aresults\"\x1a\n" +
+	"\x18ListAiSearchModesRequest\"n\n" +
+	"\x19ListAiSearchModesResponse\x12!\n" +
+	"\fsearch_modes\x18\x01 \x03(\tR\vsearchModes\x12.\n" +
+	"\x13default_search_mode\x18\x02 \x01(\tR\x11defaultSearchMode\"\x1b\n" +
+	"\x19GetAiServiceConfigRequest\"\xcd\x02\n" +
+	"\x0fAiServiceConfig\x12!\n" +
+	"\fsearch_modes\x18\x01 \x03(\tR\vsearchModes\x124\n" +
+	"\x16image_indexing_enabled\x18\x02 \x01(\bR\x14imageIndexingEnabled\x12\x19\n" +
+	"\bmax_tags\x18\x03 \x01(\x05R\amaxTags\x120\n" +
+	"\x14tag_generation_model\x18\x04 \x01(\tR\x12tagGenerationModel\x120\n" +
+	"\x14text_embedding_model\x18\x05 \x01(\tR\x12textEmbeddingModel\x122\n" +
+	"\x15image_embedding_model\x18\x06 \x01(\tR\x13imageEmbeddingModel\x12.\n" +
+	"\x13image_caption_model\x18\a \x01(\tR\x11imageCaptionModel\"I\n" +
+	"\fAiAskRequest\x12\x1f\n" +
+	"\bquestion\x18\x01 \x01(\tB\x03\xe0A\x02R\bquestion\x12\x18\n" +
+	"\x05top_k\x18\x02 \x01(\x05B\x03\xe0A\x01R\x04topK\"\x81\x01\n" +
+	"\rAiAskResponse\x12\x16\n" +
+	"\x06answer\x18\x01 \x01(\tR\x06answer\x12&\n" +
+	"\x0fcited_memo_uids\x18\x02 \x03(\tR\rcitedMemoUids\x120\n" +
+	"\x05usage\x18\x03 \x01(\v2\x1a.memos.api.v1.AiTokenUsageR\x05usage\"\x99\x01\n" +
+	"\x11AiSearchPageToken\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x05R\x06offset\x12\x1d\n" +
+	"\n" +
+	"query_hash\x18\x02 \x01(\tR\tqueryHash\x12\x1f\n" +
+	"\vsnapshot_id\x18\x03 \x01(\tR\n" +
+	"snapshotId\x12,\n" +
+	"\x12returned_memo_uids\x18\x04 \x03(\tR\x10returnedMemoUids\"\\\n" +
 	"\x13RebuildIndexRequest\x12\x1d\n" +
-	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\"\x87\x01\n" +
+	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\x12&\n" +
+	"\fcallback_url\x18\x02 \x01(\tB\x03\xe0A\x01R\vcallbackUrl\"\x87\x01\n" +
 	"\x14RebuildIndexResponse\x12\x18\n" +
 	"\acreator\x18\x01 \x01(\tR\acreator\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
 	"\vtotal_memos\x18\x03 \x01(\x05R\n" +
 	"totalMemos\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\"8\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\"3\n" +
+	"\x12VerifyIndexRequest\x12\x1d\n" +
+	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\"\xba\x01\n" +
+	"\x13VerifyIndexResponse\x12\x18\n" +
+	"\acreator\x18\x01 \x01(\tR\acreator\x12\x14\n" +
+	"\x05match\x18\x02 \x01(\bR\x05match\x12\x1a\n" +
+	"\bchecksum\x18\x03 \x01(\tR\bchecksum\x12%\n" +
+	"\x0eindex_checksum\x18\x04 \x01(\tR\rindexChecksum\x120\n" +
+	"\x14mismatched_memo_uids\x18\x05 \x03(\tR\x12mismatchedMemoUids\":\n" +
+	"\x19DeleteCreatorIndexRequest\x12\x1d\n" +
+	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\"6\n" +
+	"\x1aDeleteCreatorIndexResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
 	"\x17GetRebuildStatusRequest\x12\x1d\n" +
-	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\"\xcd\x01\n" +
+	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\"\xdb\x02\n" +
 	"\x11RebuildTaskStatus\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
@@ -3229,17 +5915,49 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1c\n" +
 	"\tcompleted\x18\x05 \x01(\x05R\tcompleted\x12\x16\n" +
 	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x14\n" +
-	"\x05error\x18\a \x01(\tR\x05error\"\x16\n" +
-	"\x14AiHealthCheckRequest\"1\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12M\n" +
+	"\ffailed_memos\x18\b \x03(\v2*.memos.api.v1.RebuildTaskStatus.FailedMemoR\vfailedMemos\x1a=\n" +
+	"\n" +
+	"FailedMemo\x12\x19\n" +
+	"\bmemo_uid\x18\x01 \x01(\tR\amemoUid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\\\n" +
+	"\x17ListIndexedMemosRequest\x12\x1d\n" +
+	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\x12\"\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tB\x03\xe0A\x01R\tpageToken\"_\n" +
+	"\x18ListIndexedMemosResponse\x12\x1b\n" +
+	"\tmemo_uids\x18\x01 \x03(\tR\bmemoUids\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"6\n" +
+	"\x15ReconcileIndexRequest\x12\x1d\n" +
+	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\"\x86\x02\n" +
+	"\x16ReconcileIndexResponse\x12\x18\n" +
+	"\acreator\x18\x01 \x01(\tR\acreator\x12\x14\n" +
+	"\x05added\x18\x02 \x01(\x05R\x05added\x12\x18\n" +
+	"\aremoved\x18\x03 \x01(\x05R\aremoved\x12\x1c\n" +
+	"\tunchanged\x18\x04 \x01(\x05R\tunchanged\x12H\n" +
+	"\bfailures\x18\x05 \x03(\v2,.memos.api.v1.ReconcileIndexResponse.FailureR\bfailures\x1a:\n" +
+	"\aFailure\x12\x19\n" +
+	"\bmemo_uid\x18\x01 \x01(\tR\amemoUid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"5\n" +
+	"\x14CancelRebuildRequest\x12\x1d\n" +
+	"\acreator\x18\x01 \x01(\tB\x03\xe0A\x02R\acreator\"1\n" +
+	"\x15CancelRebuildResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x16\n" +
+	"\x14AiHealthCheckRequest\"\xcd\x01\n" +
 	"\x15AiHealthCheckResponse\x12\x18\n" +
-	"\ahealthy\x18\x01 \x01(\bR\ahealthy*P\n" +
+	"\ahealthy\x18\x01 \x01(\bR\ahealthy\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12'\n" +
+	"\x0fembedding_model\x18\x04 \x01(\tR\x0eembeddingModel\x12+\n" +
+	"\x0fvector_store_ok\x18\x05 \x01(\bH\x00R\rvectorStoreOk\x88\x01\x01B\x12\n" +
+	"\x10_vector_store_ok*P\n" +
 	"\n" +
 	"Visibility\x12\x1a\n" +
 	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aPRIVATE\x10\x01\x12\r\n" +
 	"\tPROTECTED\x10\x02\x12\n" +
 	"\n" +
-	"\x06PUBLIC\x10\x032\xd6\x16\n" +
+	"\x06PUBLIC\x10\x032\xd7&\n" +
 	"\vMemoService\x12e\n" +
 	"\n" +
 	"CreateMemo\x12\x1f.memos.api.v1.CreateMemoRequest\x1a\x12.memos.api.v1.Memo\"\"\xdaA\x04memo\x82\xd3\xe4\x93\x02\x15:\x04memo\"\r/api/v1/memos\x12f\n" +
@@ -3258,13 +5976,28 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\x11ListMemoReactions\x12&.memos.api.v1.ListMemoReactionsRequest\x1a'.memos.api.v1.ListMemoReactionsResponse\"/\xdaA\x04name\x82\xd3\xe4\x93\x02\"\x12 /api/v1/{name=memos/*}/reactions\x12\x89\x01\n" +
 	"\x12UpsertMemoReaction\x12'.memos.api.v1.UpsertMemoReactionRequest\x1a\x16.memos.api.v1.Reaction\"2\xdaA\x04name\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/{name=memos/*}/reactions\x12\x80\x01\n" +
 	"\x12DeleteMemoReaction\x12'.memos.api.v1.DeleteMemoReactionRequest\x1a\x16.google.protobuf.Empty\")\xdaA\x04name\x82\xd3\xe4\x93\x02\x1c*\x1a/api/v1/{name=reactions/*}\x12\x96\x01\n" +
-	"\x0eGenerateAiTags\x12#.memos.api.v1.GenerateAiTagsRequest\x1a$.memos.api.v1.GenerateAiTagsResponse\"9\xdaA\x04name\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/{name=memos/*}/ai-tags:generate\x12|\n" +
-	"\tIndexMemo\x12\x1e.memos.api.v1.IndexMemoRequest\x1a\x1f.memos.api.v1.IndexMemoResponse\".\xdaA\x04name\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/{name=memos/*}/index\x12\x8b\x01\n" +
-	"\x0fDeleteMemoIndex\x12$.memos.api.v1.DeleteMemoIndexRequest\x1a%.memos.api.v1.DeleteMemoIndexResponse\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/{name=memos/*}/index\x12\x83\x01\n" +
+	"\x0eGenerateAiTags\x12#.memos.api.v1.GenerateAiTagsRequest\x1a$.memos.api.v1.GenerateAiTagsResponse\"9\xdaA\x04name\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/{name=memos/*}/ai-tags:generate\x12\x98\x01\n" +
+	"\x13BatchGenerateAiTags\x12(.memos.api.v1.BatchGenerateAiTagsRequest\x1a).memos.api.v1.BatchGenerateAiTagsResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/memos:batchGenerateAiTags\x12\x8a\x01\n" +
+	"\vApplyAiTags\x12 .memos.api.v1.ApplyAiTagsRequest\x1a!.memos.api.v1.ApplyAiTagsResponse\"6\xdaA\x04name\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/{name=memos/*}/ai-tags:apply\x12\x86\x01\n" +
+	"\vAiSummarize\x12 .memos.api.v1.AiSummarizeRequest\x1a!.memos.api.v1.AiSummarizeResponse\"2\xdaA\x04name\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/{name=memos/*}:summarize\x12|\n" +
+	"\tIndexMemo\x12\x1e.memos.api.v1.IndexMemoRequest\x1a\x1f.memos.api.v1.IndexMemoResponse\".\xdaA\x04name\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/{name=memos/*}/index\x12\x99\x01\n" +
+	"\x10RefreshMemoIndex\x12%.memos.api.v1.RefreshMemoIndexRequest\x1a&.memos.api.v1.RefreshMemoIndexResponse\"6\xdaA\x04name\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/{name=memos/*}/index:refresh\x12\x8b\x01\n" +
+	"\x0fDeleteMemoIndex\x12$.memos.api.v1.DeleteMemoIndexRequest\x1a%.memos.api.v1.DeleteMemoIndexResponse\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/{name=memos/*}/index\x12\xb1\x01\n" +
+	"\x14DeleteMemoIndexChunk\x12).memos.api.v1.DeleteMemoIndexChunkRequest\x1a*.memos.api.v1.DeleteMemoIndexChunkResponse\"B\xdaA\vname,doc_id\x82\xd3\xe4\x93\x02.*,/api/v1/{name=memos/*}/index/chunks/{doc_id}\x12\x83\x01\n" +
 	"\x10GetMemoIndexInfo\x12%.memos.api.v1.GetMemoIndexInfoRequest\x1a\x1b.memos.api.v1.MemoIndexInfo\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/{name=memos/*}/index\x12g\n" +
-	"\bAiSearch\x12\x1d.memos.api.v1.AiSearchRequest\x1a\x1e.memos.api.v1.AiSearchResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/ai/search\x12z\n" +
-	"\fRebuildIndex\x12!.memos.api.v1.RebuildIndexRequest\x1a\".memos.api.v1.RebuildIndexResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/ai/index:rebuild\x12\x83\x01\n" +
-	"\x10GetRebuildStatus\x12%.memos.api.v1.GetRebuildStatusRequest\x1a\x1f.memos.api.v1.RebuildTaskStatus\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/ai/index/rebuild-status\x12s\n" +
+	"\bAiSearch\x12\x1d.memos.api.v1.AiSearchRequest\x1a\x1e.memos.api.v1.AiSearchResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/ai/search\x12t\n" +
+	"\x0eAiSearchStream\x12\x1d.memos.api.v1.AiSearchRequest\x1a\x1c.memos.api.v1.AiSearchResult\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/ai/search:stream0\x01\x12[\n" +
+	"\x05AiAsk\x12\x1a.memos.api.v1.AiAskRequest\x1a\x1b.memos.api.v1.AiAskResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/api/v1/ai/ask\x12\x85\x01\n" +
+	"\x11ListAiSearchModes\x12&.memos.api.v1.ListAiSearchModesRequest\x1a'.memos.api.v1.ListAiSearchModesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/ai/search-modes\x12w\n" +
+	"\x12GetAiServiceConfig\x12'.memos.api.v1.GetAiServiceConfigRequest\x1a\x1d.memos.api.v1.AiServiceConfig\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/ai/config\x12\x8d\x01\n" +
+	"\x0fGetRelatedMemos\x12$.memos.api.v1.GetRelatedMemosRequest\x1a%.memos.api.v1.GetRelatedMemosResponse\"-\xdaA\x04name\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/{name=memos/*}/related\x12z\n" +
+	"\fRebuildIndex\x12!.memos.api.v1.RebuildIndexRequest\x1a\".memos.api.v1.RebuildIndexResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/ai/index:rebuild\x12v\n" +
+	"\vVerifyIndex\x12 .memos.api.v1.VerifyIndexRequest\x1a!.memos.api.v1.VerifyIndexResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/ai/index:verify\x12\x92\x01\n" +
+	"\x12DeleteCreatorIndex\x12'.memos.api.v1.DeleteCreatorIndexRequest\x1a(.memos.api.v1.DeleteCreatorIndexResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/ai/index:deleteCreator\x12\x83\x01\n" +
+	"\x10GetRebuildStatus\x12%.memos.api.v1.GetRebuildStatusRequest\x1a\x1f.memos.api.v1.RebuildTaskStatus\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/ai/index/rebuild-status\x12\x83\x01\n" +
+	"\rCancelRebuild\x12\".memos.api.v1.CancelRebuildRequest\x1a#.memos.api.v1.CancelRebuildResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/ai/index:cancelRebuild\x12\x81\x01\n" +
+	"\x10ListIndexedMemos\x12%.memos.api.v1.ListIndexedMemosRequest\x1a&.memos.api.v1.ListIndexedMemosResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/ai/index/memos\x12\x82\x01\n" +
+	"\x0eReconcileIndex\x12#.memos.api.v1.ReconcileIndexRequest\x1a$.memos.api.v1.ReconcileIndexResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/ai/index:reconcile\x12s\n" +
 	"\rAiHealthCheck\x12\".memos.api.v1.AiHealthCheckRequest\x1a#.memos.api.v1.AiHealthCheckResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/ai/healthB\xa8\x01\n" +
 	"\x10com.memos.api.v1B\x10MemoServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
@@ -3280,143 +6013,229 @@ func file_api_v1_memo_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_memo_service_proto_rawDescData
 }
 
-var file_api_v1_memo_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_v1_memo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_api_v1_memo_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_v1_memo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 82)
 var file_api_v1_memo_service_proto_goTypes = []any{
-	(Visibility)(0),                     // 0: memos.api.v1.Visibility
-	(MemoRelation_Type)(0),              // 1: memos.api.v1.MemoRelation.Type
-	(*Reaction)(nil),                    // 2: memos.api.v1.Reaction
-	(*Memo)(nil),                        // 3: memos.api.v1.Memo
-	(*Location)(nil),                    // 4: memos.api.v1.Location
-	(*CreateMemoRequest)(nil),           // 5: memos.api.v1.CreateMemoRequest
-	(*ListMemosRequest)(nil),            // 6: memos.api.v1.ListMemosRequest
-	(*ListMemosResponse)(nil),           // 7: memos.api.v1.ListMemosResponse
-	(*GetMemoRequest)(nil),              // 8: memos.api.v1.GetMemoRequest
-	(*UpdateMemoRequest)(nil),           // 9: memos.api.v1.UpdateMemoRequest
-	(*DeleteMemoRequest)(nil),           // 10: memos.api.v1.DeleteMemoRequest
-	(*SetMemoAttachmentsRequest)(nil),   // 11: memos.api.v1.SetMemoAttachmentsRequest
-	(*ListMemoAttachmentsRequest)(nil),  // 12: memos.api.v1.ListMemoAttachmentsRequest
-	(*ListMemoAttachmentsResponse)(nil), // 13: memos.api.v1.ListMemoAttachmentsResponse
-	(*MemoRelation)(nil),                // 14: memos.api.v1.MemoRelation
-	(*SetMemoRelationsRequest)(nil),     // 15: memos.api.v1.SetMemoRelationsRequest
-	(*ListMemoRelationsRequest)(nil),    // 16: memos.api.v1.ListMemoRelationsRequest
-	(*ListMemoRelationsResponse)(nil),   // 17: memos.api.v1.ListMemoRelationsResponse
-	(*CreateMemoCommentRequest)(nil),    // 18: memos.api.v1.CreateMemoCommentRequest
-	(*ListMemoCommentsRequest)(nil),     // 19: memos.api.v1.ListMemoCommentsRequest
-	(*ListMemoCommentsResponse)(nil),    // 20: memos.api.v1.ListMemoCommentsResponse
-	(*ListMemoReactionsRequest)(nil),    // 21: memos.api.v1.ListMemoReactionsRequest
-	(*ListMemoReactionsResponse)(nil),   // 22: memos.api.v1.ListMemoReactionsResponse
-	(*UpsertMemoReactionRequest)(nil),   // 23: memos.api.v1.UpsertMemoReactionRequest
-	(*DeleteMemoReactionRequest)(nil),   // 24: memos.api.v1.DeleteMemoReactionRequest
-	(*GenerateAiTagsRequest)(nil),       // 25: memos.api.v1.GenerateAiTagsRequest
-	(*GenerateAiTagsResponse)(nil),      // 26: memos.api.v1.GenerateAiTagsResponse
-	(*IndexMemoRequest)(nil),            // 27: memos.api.v1.IndexMemoRequest
-	(*IndexMemoResponse)(nil),           // 28: memos.api.v1.IndexMemoResponse
-	(*DeleteMemoIndexRequest)(nil),      // 29: memos.api.v1.DeleteMemoIndexRequest
-	(*DeleteMemoIndexResponse)(nil),     // 30: memos.api.v1.DeleteMemoIndexResponse
-	(*GetMemoIndexInfoRequest)(nil),     // 31: memos.api.v1.GetMemoIndexInfoRequest
-	(*MemoIndexInfo)(nil),               // 32: memos.api.v1.MemoIndexInfo
-	(*MemoIndexDetail)(nil),             // 33: memos.api.v1.MemoIndexDetail
-	(*TextChunk)(nil),                   // 34: memos.api.v1.TextChunk
-	(*ImageInfo)(nil),                   // 35: memos.api.v1.ImageInfo
-	(*AiSearchRequest)(nil),             // 36: memos.api.v1.AiSearchRequest
-	(*AiSearchResponse)(nil),            // 37: memos.api.v1.AiSearchResponse
-	(*AiSearchResult)(nil),              // 38: memos.api.v1.AiSearchResult
-	(*RebuildIndexRequest)(nil),         // 39: memos.api.v1.RebuildIndexRequest
-	(*RebuildIndexResponse)(nil),        // 40: memos.api.v1.RebuildIndexResponse
-	(*GetRebuildStatusRequest)(nil),     // 41: memos.api.v1.GetRebuildStatusRequest
-	(*RebuildTaskStatus)(nil),           // 42: memos.api.v1.RebuildTaskStatus
-	(*AiHealthCheckRequest)(nil),        // 43: memos.api.v1.AiHealthCheckRequest
-	(*AiHealthCheckResponse)(nil),       // 44: memos.api.v1.AiHealthCheckResponse
-	(*Memo_Property)(nil),               // 45: memos.api.v1.Memo.Property
-	(*MemoRelation_Memo)(nil),           // 46: memos.api.v1.MemoRelation.Memo
-	(*timestamppb.Timestamp)(nil),       // 47: google.protobuf.Timestamp
-	(State)(0),                          // 48: memos.api.v1.State
-	(*Attachment)(nil),                  // 49: memos.api.v1.Attachment
-	(*fieldmaskpb.FieldMask)(nil),       // 50: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),               // 51: google.protobuf.Empty
+	(Visibility)(0),                            // 0: memos.api.v1.Visibility
+	(MemoRelation_Type)(0),                     // 1: memos.api.v1.MemoRelation.Type
+	(AiSearchRequest_Scope)(0),                 // 2: memos.api.v1.AiSearchRequest.Scope
+	(*Reaction)(nil),                           // 3: memos.api.v1.Reaction
+	(*Memo)(nil),                               // 4: memos.api.v1.Memo
+	(*Location)(nil),                           // 5: memos.api.v1.Location
+	(*CreateMemoRequest)(nil),                  // 6: memos.api.v1.CreateMemoRequest
+	(*ListMemosRequest)(nil),                   // 7: memos.api.v1.ListMemosRequest
+	(*ListMemosResponse)(nil),                  // 8: memos.api.v1.ListMemosResponse
+	(*GetMemoRequest)(nil),                     // 9: memos.api.v1.GetMemoRequest
+	(*UpdateMemoRequest)(nil),                  // 10: memos.api.v1.UpdateMemoRequest
+	(*DeleteMemoRequest)(nil),                  // 11: memos.api.v1.DeleteMemoRequest
+	(*SetMemoAttachmentsRequest)(nil),          // 12: memos.api.v1.SetMemoAttachmentsRequest
+	(*ListMemoAttachmentsRequest)(nil),         // 13: memos.api.v1.ListMemoAttachmentsRequest
+	(*ListMemoAttachmentsResponse)(nil),        // 14: memos.api.v1.ListMemoAttachmentsResponse
+	(*MemoRelation)(nil),                       // 15: memos.api.v1.MemoRelation
+	(*SetMemoRelationsRequest)(nil),            // 16: memos.api.v1.SetMemoRelationsRequest
+	(*ListMemoRelationsRequest)(nil),           // 17: memos.api.v1.ListMemoRelationsRequest
+	(*ListMemoRelationsResponse)(nil),          // 18: memos.api.v1.ListMemoRelationsResponse
+	(*CreateMemoCommentRequest)(nil),           // 19: memos.api.v1.CreateMemoCommentRequest
+	(*ListMemoCommentsRequest)(nil),            // 20: memos.api.v1.ListMemoCommentsRequest
+	(*ListMemoCommentsResponse)(nil),           // 21: memos.api.v1.ListMemoCommentsResponse
+	(*ListMemoReactionsRequest)(nil),           // 22: memos.api.v1.ListMemoReactionsRequest
+	(*ListMemoReactionsResponse)(nil),          // 23: memos.api.v1.ListMemoReactionsResponse
+	(*UpsertMemoReactionRequest)(nil),          // 24: memos.api.v1.UpsertMemoReactionRequest
+	(*DeleteMemoReactionRequest)(nil),          // 25: memos.api.v1.DeleteMemoReactionRequest
+	(*GenerateAiTagsRequest)(nil),              // 26: memos.api.v1.GenerateAiTagsRequest
+	(*GenerateAiTagsResponse)(nil),             // 27: memos.api.v1.GenerateAiTagsResponse
+	(*BatchGenerateAiTagsRequest)(nil),         // 28: memos.api.v1.BatchGenerateAiTagsRequest
+	(*BatchGenerateAiTagsResponse)(nil),        // 29: memos.api.v1.BatchGenerateAiTagsResponse
+	(*ApplyAiTagsRequest)(nil),                 // 30: memos.api.v1.ApplyAiTagsRequest
+	(*ApplyAiTagsResponse)(nil),                // 31: memos.api.v1.ApplyAiTagsResponse
+	(*AiSummarizeRequest)(nil),                 // 32: memos.api.v1.AiSummarizeRequest
+	(*AiSummarizeResponse)(nil),                // 33: memos.api.v1.AiSummarizeResponse
+	(*AiTokenUsage)(nil),                       // 34: memos.api.v1.AiTokenUsage
+	(*IndexMemoRequest)(nil),                   // 35: memos.api.v1.IndexMemoRequest
+	(*IndexMemoResponse)(nil),                  // 36: memos.api.v1.IndexMemoResponse
+	(*RefreshMemoIndexRequest)(nil),            // 37: memos.api.v1.RefreshMemoIndexRequest
+	(*RefreshMemoIndexResponse)(nil),           // 38: memos.api.v1.RefreshMemoIndexResponse
+	(*DeleteMemoIndexRequest)(nil),             // 39: memos.api.v1.DeleteMemoIndexRequest
+	(*DeleteMemoIndexResponse)(nil),            // 40: memos.api.v1.DeleteMemoIndexResponse
+	(*DeleteMemoIndexChunkRequest)(nil),        // 41: memos.api.v1.DeleteMemoIndexChunkRequest
+	(*DeleteMemoIndexChunkResponse)(nil),       // 42: memos.api.v1.DeleteMemoIndexChunkResponse
+	(*GetMemoIndexInfoRequest)(nil),            // 43: memos.api.v1.GetMemoIndexInfoRequest
+	(*MemoIndexInfo)(nil),                      // 44: memos.api.v1.MemoIndexInfo
+	(*MemoIndexDetail)(nil),                    // 45: memos.api.v1.MemoIndexDetail
+	(*TextChunk)(nil),                          // 46: memos.api.v1.TextChunk
+	(*ImageInfo)(nil),                          // 47: memos.api.v1.ImageInfo
+	(*AiSearchRequest)(nil),                    // 48: memos.api.v1.AiSearchRequest
+	(*AiSearchResponse)(nil),                   // 49: memos.api.v1.AiSearchResponse
+	(*AiSearchResult)(nil),                     // 50: memos.api.v1.AiSearchResult
+	(*GetRelatedMemosRequest)(nil),             // 51: memos.api.v1.GetRelatedMemosRequest
+	(*GetRelatedMemosResponse)(nil),            // 52: memos.api.v1.GetRelatedMemosResponse
+	(*ListAiSearchModesRequest)(nil),           // 53: memos.api.v1.ListAiSearchModesRequest
+	(*ListAiSearchModesResponse)(nil),          // 54: memos.api.v1.ListAiSearchModesResponse
+	(*GetAiServiceConfigRequest)(nil),          // 55: memos.api.v1.GetAiServiceConfigRequest
+	(*AiServiceConfig)(nil),                    // 56: memos.api.v1.AiServiceConfig
+	(*AiAskRequest)(nil),                       // 57: memos.api.v1.AiAskRequest
+	(*AiAskResponse)(nil),                      // 58: memos.api.v1.AiAskResponse
+	(*AiSearchPageToken)(nil),                  // 59: memos.api.v1.AiSearchPageToken
+	(*RebuildIndexRequest)(nil),                // 60: memos.api.v1.RebuildIndexRequest
+	(*RebuildIndexResponse)(nil),               // 61: memos.api.v1.RebuildIndexResponse
+	(*VerifyIndexRequest)(nil),                 // 62: memos.api.v1.VerifyIndexRequest
+	(*VerifyIndexResponse)(nil),                // 63: memos.api.v1.VerifyIndexResponse
+	(*DeleteCreatorIndexRequest)(nil),          // 64: memos.api.v1.DeleteCreatorIndexRequest
+	(*DeleteCreatorIndexResponse)(nil),         // 65: memos.api.v1.DeleteCreatorIndexResponse
+	(*GetRebuildStatusRequest)(nil),            // 66: memos.api.v1.GetRebuildStatusRequest
+	(*RebuildTaskStatus)(nil),                  // 67: memos.api.v1.RebuildTaskStatus
+	(*ListIndexedMemosRequest)(nil),            // 68: memos.api.v1.ListIndexedMemosRequest
+	(*ListIndexedMemosResponse)(nil),           // 69: memos.api.v1.ListIndexedMemosResponse
+	(*ReconcileIndexRequest)(nil),              // 70: memos.api.v1.ReconcileIndexRequest
+	(*ReconcileIndexResponse)(nil),             // 71: memos.api.v1.ReconcileIndexResponse
+	(*CancelRebuildRequest)(nil),               // 72: memos.api.v1.CancelRebuildRequest
+	(*CancelRebuildResponse)(nil),              // 73: memos.api.v1.CancelRebuildResponse
+	(*AiHealthCheckRequest)(nil),               // 74: memos.api.v1.AiHealthCheckRequest
+	(*AiHealthCheckResponse)(nil),              // 75: memos.api.v1.AiHealthCheckResponse
+	(*Memo_Property)(nil),                      // 76: memos.api.v1.Memo.Property
+	(*MemoRelation_Memo)(nil),                  // 77: memos.api.v1.MemoRelation.Memo
+	nil,                                        // 78: memos.api.v1.BatchGenerateAiTagsResponse.ResultsEntry
+	(*BatchGenerateAiTagsResponse_Result)(nil), // 79: memos.api.v1.BatchGenerateAiTagsResponse.Result
+	(*AiSearchResult_MemoInfo)(nil),            // 80: memos.api.v1.AiSearchResult.MemoInfo
+	(*AiSearchResult_ScoreBreakdown)(nil),      // 81: memos.api.v1.AiSearchResult.ScoreBreakdown
+	(*AiSearchResult_HighlightRange)(nil),      // 82: memos.api.v1.AiSearchResult.HighlightRange
+	(*RebuildTaskStatus_FailedMemo)(nil),       // 83: memos.api.v1.RebuildTaskStatus.FailedMemo
+	(*ReconcileIndexResponse_Failure)(nil),     // 84: memos.api.v1.ReconcileIndexResponse.Failure
+	(*timestamppb.Timestamp)(nil),              // 85: google.protobuf.Timestamp
+	(State)(0),                                 // 86: memos.api.v1.State
+	(*Attachment)(nil),                         // 87: memos.api.v1.Attachment
+	(*fieldmaskpb.FieldMask)(nil),              // 88: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                      // 89: google.protobuf.Empty
 }
 var file_api_v1_memo_service_proto_depIdxs = []int32{
-	47, // 0: memos.api.v1.Reaction.create_time:type_name -> google.protobuf.Timestamp
-	48, // 1: memos.api.v1.Memo.state:type_name -> memos.api.v1.State
-	47, // 2: memos.api.v1.Memo.create_time:type_name -> google.protobuf.Timestamp
-	47, // 3: memos.api.v1.Memo.update_time:type_name -> google.protobuf.Timestamp
-	47, // 4: memos.api.v1.Memo.display_time:type_name -> google.protobuf.Timestamp
+	85, // 0: memos.api.v1.Reaction.create_time:type_name -> google.protobuf.Timestamp
+	86, // 1: memos.api.v1.Memo.state:type_name -> memos.api.v1.State
+	85, // 2: memos.api.v1.Memo.create_time:type_name -> google.protobuf.Timestamp
+	85, // 3: memos.api.v1.Memo.update_time:type_name -> google.protobuf.Timestamp
+	85, // 4: memos.api.v1.Memo.display_time:type_name -> google.protobuf.Timestamp
 	0,  // 5: memos.api.v1.Memo.visibility:type_name -> memos.api.v1.Visibility
-	49, // 6: memos.api.v1.Memo.attachments:type_name -> memos.api.v1.Attachment
-	14, // 7: memos.api.v1.Memo.relations:type_name -> memos.api.v1.MemoRelation
-	2,  // 8: memos.api.v1.Memo.reactions:type_name -> memos.api.v1.Reaction
-	45, // 9: memos.api.v1.Memo.property:type_name -> memos.api.v1.Memo.Property
-	4,  // 10: memos.api.v1.Memo.location:type_name -> memos.api.v1.Location
-	3,  // 11: memos.api.v1.CreateMemoRequest.memo:type_name -> memos.api.v1.Memo
-	48, // 12: memos.api.v1.ListMemosRequest.state:type_name -> memos.api.v1.State
-	3,  // 13: memos.api.v1.ListMemosResponse.memos:type_name -> memos.api.v1.Memo
-	3,  // 14: memos.api.v1.UpdateMemoRequest.memo:type_name -> memos.api.v1.Memo
-	50, // 15: memos.api.v1.UpdateMemoRequest.update_mask:type_name -> google.protobuf.FieldMask
-	49, // 16: memos.api.v1.SetMemoAttachmentsRequest.attachments:type_name -> memos.api.v1.Attachment
-	49, // 17: memos.api.v1.ListMemoAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
-	46, // 18: memos.api.v1.MemoRelation.memo:type_name -> memos.api.v1.MemoRelation.Memo
-	46, // 19: memos.api.v1.MemoRelation.related_memo:type_name -> memos.api.v1.MemoRelation.Memo
+	87, // 6: memos.api.v1.Memo.attachments:type_name -> memos.api.v1.Attachment
+	15, // 7: memos.api.v1.Memo.relations:type_name -> memos.api.v1.MemoRelation
+	3,  // 8: memos.api.v1.Memo.reactions:type_name -> memos.api.v1.Reaction
+	76, // 9: memos.api.v1.Memo.property:type_name -> memos.api.v1.Memo.Property
+	5,  // 10: memos.api.v1.Memo.location:type_name -> memos.api.v1.Location
+	4,  // 11: memos.api.v1.CreateMemoRequest.memo:type_name -> memos.api.v1.Memo
+	86, // 12: memos.api.v1.ListMemosRequest.state:type_name -> memos.api.v1.State
+	4,  // 13: memos.api.v1.ListMemosResponse.memos:type_name -> memos.api.v1.Memo
+	4,  // 14: memos.api.v1.UpdateMemoRequest.memo:type_name -> memos.api.v1.Memo
+	88, // 15: memos.api.v1.UpdateMemoRequest.update_mask:type_name -> google.protobuf.FieldMask
+	87, // 16: memos.api.v1.SetMemoAttachmentsRequest.attachments:type_name -> memos.api.v1.Attachment
+	87, // 17: memos.api.v1.ListMemoAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
+	77, // 18: memos.api.v1.MemoRelation.memo:type_name -> memos.api.v1.MemoRelation.Memo
+	77, // 19: memos.api.v1.MemoRelation.related_memo:type_name -> memos.api.v1.MemoRelation.Memo
 	1,  // 20: memos.api.v1.MemoRelation.type:type_name -> memos.api.v1.MemoRelation.Type
-	14, // 21: memos.api.v1.SetMemoRelationsRequest.relations:type_name -> memos.api.v1.MemoRelation
-	14, // 22: memos.api.v1.ListMemoRelationsResponse.relations:type_name -> memos.api.v1.MemoRelation
-	3,  // 23: memos.api.v1.CreateMemoCommentRequest.comment:type_name -> memos.api.v1.Memo
-	3,  // 24: memos.api.v1.ListMemoCommentsResponse.memos:type_name -> memos.api.v1.Memo
-	2,  // 25: memos.api.v1.ListMemoReactionsResponse.reactions:type_name -> memos.api.v1.Reaction
-	2,  // 26: memos.api.v1.UpsertMemoReactionRequest.reaction:type_name -> memos.api.v1.Reaction
-	33, // 27: memos.api.v1.MemoIndexInfo.detail:type_name -> memos.api.v1.MemoIndexDetail
-	34, // 28: memos.api.v1.MemoIndexDetail.text_chunks:type_name -> memos.api.v1.TextChunk
-	35, // 29: memos.api.v1.MemoIndexDetail.images:type_name -> memos.api.v1.ImageInfo
-	38, // 30: memos.api.v1.AiSearchResponse.results:type_name -> memos.api.v1.AiSearchResult
-	5,  // 31: memos.api.v1.MemoService.CreateMemo:input_type -> memos.api.v1.CreateMemoRequest
-	6,  // 32: memos.api.v1.MemoService.ListMemos:input_type -> memos.api.v1.ListMemosRequest
-	8,  // 33: memos.api.v1.MemoService.GetMemo:input_type -> memos.api.v1.GetMemoRequest
-	9,  // 34: memos.api.v1.MemoService.UpdateMemo:input_type -> memos.api.v1.UpdateMemoRequest
-	10, // 35: memos.api.v1.MemoService.DeleteMemo:input_type -> memos.api.v1.DeleteMemoRequest
-	11, // 36: memos.api.v1.MemoService.SetMemoAttachments:input_type -> memos.api.v1.SetMemoAttachmentsRequest
-	12, // 37: memos.api.v1.MemoService.ListMemoAttachments:input_type -> memos.api.v1.ListMemoAttachmentsRequest
-	15, // 38: memos.api.v1.MemoService.SetMemoRelations:input_type -> memos.api.v1.SetMemoRelationsRequest
-	16, // 39: memos.api.v1.MemoService.ListMemoRelations:input_type -> memos.api.v1.ListMemoRelationsRequest
-	18, // 40: memos.api.v1.MemoService.CreateMemoComment:input_type -> memos.api.v1.CreateMemoCommentRequest
-	19, // 41: memos.api.v1.MemoService.ListMemoComments:input_type -> memos.api.v1.ListMemoCommentsRequest
-	21, // 42: memos.api.v1.MemoService.ListMemoReactions:input_type -> memos.api.v1.ListMemoReactionsRequest
-	23, // 43: memos.api.v1.MemoService.UpsertMemoReaction:input_type -> memos.api.v1.UpsertMemoReactionRequest
-	24, // 44: memos.api.v1.MemoService.DeleteMemoReaction:input_type -> memos.api.v1.DeleteMemoReactionRequest
-	25, // 45: memos.api.v1.MemoService.GenerateAiTags:input_type -> memos.api.v1.GenerateAiTagsRequest
-	27, // 46: memos.api.v1.MemoService.IndexMemo:input_type -> memos.api.v1.IndexMemoRequest
-	29, // 47: memos.api.v1.MemoService.DeleteMemoIndex:input_type -> memos.api.v1.DeleteMemoIndexRequest
-	31, // 48: memos.api.v1.MemoService.GetMemoIndexInfo:input_type -> memos.api.v1.GetMemoIndexInfoRequest
-	36, // 49: memos.api.v1.MemoService.AiSearch:input_type -> memos.api.v1.AiSearchRequest
-	39, // 50: memos.api.v1.MemoService.RebuildIndex:input_type -> memos.api.v1.RebuildIndexRequest
-	41, // 51: memos.api.v1.MemoService.GetRebuildStatus:input_type -> memos.api.v1.GetRebuildStatusRequest
-	43, // 52: memos.api.v1.MemoService.AiHealthCheck:input_type -> memos.api.v1.AiHealthCheckRequest
-	3,  // 53: memos.api.v1.MemoService.CreateMemo:output_type -> memos.api.v1.Memo
-	7,  // 54: memos.api.v1.MemoService.ListMemos:output_type -> memos.api.v1.ListMemosResponse
-	3,  // 55: memos.api.v1.MemoService.GetMemo:output_type -> memos.api.v1.Memo
-	3,  // 56: memos.api.v1.MemoService.UpdateMemo:output_type -> memos.api.v1.Memo
-	51, // 57: memos.api.v1.MemoService.DeleteMemo:output_type -> google.protobuf.Empty
-	51, // 58: memos.api.v1.MemoService.SetMemoAttachments:output_type -> google.protobuf.Empty
-	13, // 59: memos.api.v1.MemoService.ListMemoAttachments:output_type -> memos.api.v1.ListMemoAttachmentsResponse
-	51, // 60: memos.api.v1.MemoService.SetMemoRelations:output_type -> google.protobuf.Empty
-	17, // 61: memos.api.v1.MemoService.ListMemoRelations:output_type -> memos.api.v1.ListMemoRelationsResponse
-	3,  // 62: memos.api.v1.MemoService.CreateMemoComment:output_type -> memos.api.v1.Memo
-	20, // 63: memos.api.v1.MemoService.ListMemoComments:output_type -> memos.api.v1.ListMemoCommentsResponse
-	22, // 64: memos.api.v1.MemoService.ListMemoReactions:output_type -> memos.api.v1.ListMemoReactionsResponse
-	2,  // 65: memos.api.v1.MemoService.UpsertMemoReaction:output_type -> memos.api.v1.Reaction
-	51, // 66: memos.api.v1.MemoService.DeleteMemoReaction:output_type -> google.protobuf.Empty
-	26, // 67: memos.api.v1.MemoService.GenerateAiTags:output_type -> memos.api.v1.GenerateAiTagsResponse
-	28, // 68: memos.api.v1.MemoService.IndexMemo:output_type -> memos.api.v1.IndexMemoResponse
-	30, // 69: memos.api.v1.MemoService.DeleteMemoIndex:output_type -> memos.api.v1.DeleteMemoIndexResponse
-	32, // 70: memos.api.v1.MemoService.GetMemoIndexInfo:output_type -> memos.api.v1.MemoIndexInfo
-	37, // 71: memos.api.v1.MemoService.AiSearch:output_type -> memos.api.v1.AiSearchResponse
-	40, // 72: memos.api.v1.MemoService.RebuildIndex:output_type -> memos.api.v1.RebuildIndexResponse
-	42, // 73: memos.api.v1.MemoService.GetRebuildStatus:output_type -> memos.api.v1.RebuildTaskStatus
-	44, // 74: memos.api.v1.MemoService.AiHealthCheck:output_type -> memos.api.v1.AiHealthCheckResponse
-	53, // [53:75] is the sub-list for method output_type
-	31, // [31:53] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
+	15, // 21: memos.api.v1.SetMemoRelationsRequest.relations:type_name -> memos.api.v1.MemoRelation
+	15, // 22: memos.api.v1.ListMemoRelationsResponse.relations:type_name -> memos.api.v1.MemoRelation
+	4,  // 23: memos.api.v1.CreateMemoCommentRequest.comment:type_name -> memos.api.v1.Memo
+	4,  // 24: memos.api.v1.ListMemoCommentsResponse.memos:type_name -> memos.api.v1.Memo
+	3,  // 25: memos.api.v1.ListMemoReactionsResponse.reactions:type_name -> memos.api.v1.Reaction
+	3,  // 26: memos.api.v1.UpsertMemoReactionRequest.reaction:type_name -> memos.api.v1.Reaction
+	34, // 27: memos.api.v1.GenerateAiTagsResponse.usage:type_name -> memos.api.v1.AiTokenUsage
+	78, // 28: memos.api.v1.BatchGenerateAiTagsResponse.results:type_name -> memos.api.v1.BatchGenerateAiTagsResponse.ResultsEntry
+	34, // 29: memos.api.v1.AiSummarizeResponse.usage:type_name -> memos.api.v1.AiTokenUsage
+	45, // 30: memos.api.v1.MemoIndexInfo.detail:type_name -> memos.api.v1.MemoIndexDetail
+	85, // 31: memos.api.v1.MemoIndexInfo.indexed_at:type_name -> google.protobuf.Timestamp
+	46, // 32: memos.api.v1.MemoIndexDetail.text_chunks:type_name -> memos.api.v1.TextChunk
+	47, // 33: memos.api.v1.MemoIndexDetail.images:type_name -> memos.api.v1.ImageInfo
+	85, // 34: memos.api.v1.AiSearchRequest.created_after:type_name -> google.protobuf.Timestamp
+	85, // 35: memos.api.v1.AiSearchRequest.created_before:type_name -> google.protobuf.Timestamp
+	2,  // 36: memos.api.v1.AiSearchRequest.scope:type_name -> memos.api.v1.AiSearchRequest.Scope
+	50, // 37: memos.api.v1.AiSearchResponse.results:type_name -> memos.api.v1.AiSearchResult
+	82, // 38: memos.api.v1.AiSearchResult.highlights:type_name -> memos.api.v1.AiSearchResult.HighlightRange
+	80, // 39: memos.api.v1.AiSearchResult.memo:type_name -> memos.api.v1.AiSearchResult.MemoInfo
+	81, // 40: memos.api.v1.AiSearchResult.score_breakdown:type_name -> memos.api.v1.AiSearchResult.ScoreBreakdown
+	50, // 41: memos.api.v1.GetRelatedMemosResponse.results:type_name -> memos.api.v1.AiSearchResult
+	34, // 42: memos.api.v1.AiAskResponse.usage:type_name -> memos.api.v1.AiTokenUsage
+	83, // 43: memos.api.v1.RebuildTaskStatus.failed_memos:type_name -> memos.api.v1.RebuildTaskStatus.FailedMemo
+	84, // 44: memos.api.v1.ReconcileIndexResponse.failures:type_name -> memos.api.v1.ReconcileIndexResponse.Failure
+	79, // 45: memos.api.v1.BatchGenerateAiTagsResponse.ResultsEntry.value:type_name -> memos.api.v1.BatchGenerateAiTagsResponse.Result
+	34, // 46: memos.api.v1.BatchGenerateAiTagsResponse.Result.usage:type_name -> memos.api.v1.AiTokenUsage
+	85, // 47: memos.api.v1.AiSearchResult.MemoInfo.display_time:type_name -> google.protobuf.Timestamp
+	0,  // 48: memos.api.v1.AiSearchResult.MemoInfo.visibility:type_name -> memos.api.v1.Visibility
+	6,  // 49: memos.api.v1.MemoService.CreateMemo:input_type -> memos.api.v1.CreateMemoRequest
+	7,  // 50: memos.api.v1.MemoService.ListMemos:input_type -> memos.api.v1.ListMemosRequest
+	9,  // 51: memos.api.v1.MemoService.GetMemo:input_type -> memos.api.v1.GetMemoRequest
+	10, // 52: memos.api.v1.MemoService.UpdateMemo:input_type -> memos.api.v1.UpdateMemoRequest
+	11, // 53: memos.api.v1.MemoService.DeleteMemo:input_type -> memos.api.v1.DeleteMemoRequest
+	12, // 54: memos.api.v1.MemoService.SetMemoAttachments:input_type -> memos.api.v1.SetMemoAttachmentsRequest
+	13, // 55: memos.api.v1.MemoService.ListMemoAttachments:input_type -> memos.api.v1.ListMemoAttachmentsRequest
+	16, // 56: memos.api.v1.MemoService.SetMemoRelations:input_type -> memos.api.v1.SetMemoRelationsRequest
+	17, // 57: memos.api.v1.MemoService.ListMemoRelations:input_type -> memos.api.v1.ListMemoRelationsRequest
+	19, // 58: memos.api.v1.MemoService.CreateMemoComment:input_type -> memos.api.v1.CreateMemoCommentRequest
+	20, // 59: memos.api.v1.MemoService.ListMemoComments:input_type -> memos.api.v1.ListMemoCommentsRequest
+	22, // 60: memos.api.v1.MemoService.ListMemoReactions:input_type -> memos.api.v1.ListMemoReactionsRequest
+	24, // 61: memos.api.v1.MemoService.UpsertMemoReaction:input_type -> memos.api.v1.UpsertMemoReactionRequest
+	25, // 62: memos.api.v1.MemoService.DeleteMemoReaction:input_type -> memos.api.v1.DeleteMemoReactionRequest
+	26, // 63: memos.api.v1.MemoService.GenerateAiTags:input_type -> memos.api.v1.GenerateAiTagsRequest
+	28, // 64: memos.api.v1.MemoService.BatchGenerateAiTags:input_type -> memos.api.v1.BatchGenerateAiTagsRequest
+	30, // 65: memos.api.v1.MemoService.ApplyAiTags:input_type -> memos.api.v1.ApplyAiTagsRequest
+	32, // 66: memos.api.v1.MemoService.AiSummarize:input_type -> memos.api.v1.AiSummarizeRequest
+	35, // 67: memos.api.v1.MemoService.IndexMemo:input_type -> memos.api.v1.IndexMemoRequest
+	37, // 68: memos.api.v1.MemoService.RefreshMemoIndex:input_type -> memos.api.v1.RefreshMemoIndexRequest
+	39, // 69: memos.api.v1.MemoService.DeleteMemoIndex:input_type -> memos.api.v1.DeleteMemoIndexRequest
+	41, // 70: memos.api.v1.MemoService.DeleteMemoIndexChunk:input_type -> memos.api.v1.DeleteMemoIndexChunkRequest
+	43, // 71: memos.api.v1.MemoService.GetMemoIndexInfo:input_type -> memos.api.v1.GetMemoIndexInfoRequest
+	48, // 72: memos.api.v1.MemoService.AiSearch:input_type -> memos.api.v1.AiSearchRequest
+	48, // 73: memos.api.v1.MemoService.AiSearchStream:input_type -> memos.api.v1.AiSearchRequest
+	57, // 74: memos.api.v1.MemoService.AiAsk:input_type -> memos.api.v1.AiAskRequest
+	53, // 75: memos.api.v1.MemoService.ListAiSearchModes:input_type -> memos.api.v1.ListAiSearchModesRequest
+	55, // 76: memos.api.v1.MemoService.GetAiServiceConfig:input_type -> memos.api.v1.GetAiServiceConfigRequest
+	51, // 77: memos.api.v1.MemoService.GetRelatedMemos:input_type -> memos.api.v1.GetRelatedMemosRequest
+	60, // 78: memos.api.v1.MemoService.RebuildIndex:input_type -> memos.api.v1.RebuildIndexRequest
+	62, // 79: memos.api.v1.MemoService.VerifyIndex:input_type -> memos.api.v1.VerifyIndexRequest
+	64, // 80: memos.api.v1.MemoService.DeleteCreatorIndex:input_type -> memos.api.v1.DeleteCreatorIndexRequest
+	66, // 81: memos.api.v1.MemoService.GetRebuildStatus:input_type -> memos.api.v1.GetRebuildStatusRequest
+	72, // 82: memos.api.v1.MemoService.CancelRebuild:input_type -> memos.api.v1.CancelRebuildRequest
+	68, // 83: memos.api.v1.MemoService.ListIndexedMemos:input_type -> memos.api.v1.ListIndexedMemosRequest
+	70, // 84: memos.api.v1.MemoService.ReconcileIndex:input_type -> memos.api.v1.ReconcileIndexRequest
+	74, // 85: memos.api.v1.MemoService.AiHealthCheck:input_type -> memos.api.v1.AiHealthCheckRequest
+	4,  // 86: memos.api.v1.MemoService.CreateMemo:output_type -> memos.api.v1.Memo
+	8,  // 87: memos.api.v1.MemoService.ListMemos:output_type -> memos.api.v1.ListMemosResponse
+	4,  // 88: memos.api.v1.MemoService.GetMemo:output_type -> memos.api.v1.Memo
+	4,  // 89: memos.api.v1.MemoService.UpdateMemo:output_type -> memos.api.v1.Memo
+	89, // 90: memos.api.v1.MemoService.DeleteMemo:output_type -> google.protobuf.Empty
+	89, // 91: memos.api.v1.MemoService.SetMemoAttachments:output_type -> google.protobuf.Empty
+	14, // 92: memos.api.v1.MemoService.ListMemoAttachments:output_type -> memos.api.v1.ListMemoAttachmentsResponse
+	89, // 93: memos.api.v1.MemoService.SetMemoRelations:output_type -> google.protobuf.Empty
+	18, // 94: memos.api.v1.MemoService.ListMemoRelations:output_type -> memos.api.v1.ListMemoRelationsResponse
+	4,  // 95: memos.api.v1.MemoService.CreateMemoComment:output_type -> memos.api.v1.Memo
+	21, // 96: memos.api.v1.MemoService.ListMemoComments:output_type -> memos.api.v1.ListMemoCommentsResponse
+	23, // 97: memos.api.v1.MemoService.ListMemoReactions:output_type -> memos.api.v1.ListMemoReactionsResponse
+	3,  // 98: memos.api.v1.MemoService.UpsertMemoReaction:output_type -> memos.api.v1.Reaction
+	89, // 99: memos.api.v1.MemoService.DeleteMemoReaction:output_type -> google.protobuf.Empty
+	27, // 100: memos.api.v1.MemoService.GenerateAiTags:output_type -> memos.api.v1.GenerateAiTagsResponse
+	29, // 101: memos.api.v1.MemoService.BatchGenerateAiTags:output_type -> memos.api.v1.BatchGenerateAiTagsResponse
+	31, // 102: memos.api.v1.MemoService.ApplyAiTags:output_type -> memos.api.v1.ApplyAiTagsResponse
+	33, // 103: memos.api.v1.MemoService.AiSummarize:output_type -> memos.api.v1.AiSummarizeResponse
+	36, // 104: memos.api.v1.MemoService.IndexMemo:output_type -> memos.api.v1.IndexMemoResponse
+	38, // 105: memos.api.v1.MemoService.RefreshMemoIndex:output_type -> memos.api.v1.RefreshMemoIndexResponse
+	40, // 106: memos.api.v1.MemoService.DeleteMemoIndex:output_type -> memos.api.v1.DeleteMemoIndexResponse
+	42, // 107: memos.api.v1.MemoService.DeleteMemoIndexChunk:output_type -> memos.api.v1.DeleteMemoIndexChunkResponse
+	44, // 108: memos.api.v1.MemoService.GetMemoIndexInfo:output_type -> memos.api.v1.MemoIndexInfo
+	49, // 109: memos.api.v1.MemoService.AiSearch:output_type -> memos.api.v1.AiSearchResponse
+	50, // 110: memos.api.v1.MemoService.AiSearchStream:output_type -> memos.api.v1.AiSearchResult
+	58, // 111: memos.api.v1.MemoService.AiAsk:output_type -> memos.api.v1.AiAskResponse
+	54, // 112: memos.api.v1.MemoService.ListAiSearchModes:output_type -> memos.api.v1.ListAiSearchModesResponse
+	56, // 113: memos.api.v1.MemoService.GetAiServiceConfig:output_type -> memos.api.v1.AiServiceConfig
+	52, // 114: memos.api.v1.MemoService.GetRelatedMemos:output_type -> memos.api.v1.GetRelatedMemosResponse
+	61, // 115: memos.api.v1.MemoService.RebuildIndex:output_type -> memos.api.v1.RebuildIndexResponse
+	63, // 116: memos.api.v1.MemoService.VerifyIndex:output_type -> memos.api.v1.VerifyIndexResponse
+	65, // 117: memos.api.v1.MemoService.DeleteCreatorIndex:output_type -> memos.api.v1.DeleteCreatorIndexResponse
+	67, // 118: memos.api.v1.MemoService.GetRebuildStatus:output_type -> memos.api.v1.RebuildTaskStatus
+	73, // 119: memos.api.v1.MemoService.CancelRebuild:output_type -> memos.api.v1.CancelRebuildResponse
+	69, // 120: memos.api.v1.MemoService.ListIndexedMemos:output_type -> memos.api.v1.ListIndexedMemosResponse
+	71, // 121: memos.api.v1.MemoService.ReconcileIndex:output_type -> memos.api.v1.ReconcileIndexResponse
+	75, // 122: memos.api.v1.MemoService.AiHealthCheck:output_type -> memos.api.v1.AiHealthCheckResponse
+	86, // [86:123] is the sub-list for method output_type
+	49, // [49:86] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_memo_service_proto_init() }
@@ -3427,13 +6246,14 @@ func file_api_v1_memo_service_proto_init() {
 	file_api_v1_attachment_service_proto_init()
 	file_api_v1_common_proto_init()
 	file_api_v1_memo_service_proto_msgTypes[1].OneofWrappers = []any{}
+	file_api_v1_memo_service_proto_msgTypes[72].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_memo_service_proto_rawDesc), len(file_api_v1_memo_service_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   45,
+			NumEnums:      3,
+			NumMessages:   82,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
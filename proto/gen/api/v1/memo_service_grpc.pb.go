@@ -20,28 +20,43 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MemoService_CreateMemo_FullMethodName          = "/memos.api.v1.MemoService/CreateMemo"
-	MemoService_ListMemos_FullMethodName           = "/memos.api.v1.MemoService/ListMemos"
-	MemoService_GetMemo_FullMethodName             = "/memos.api.v1.MemoService/GetMemo"
-	MemoService_UpdateMemo_FullMethodName          = "/memos.api.v1.MemoService/UpdateMemo"
-	MemoService_DeleteMemo_FullMethodName          = "/memos.api.v1.MemoService/DeleteMemo"
-	MemoService_SetMemoAttachments_FullMethodName  = "/memos.api.v1.MemoService/SetMemoAttachments"
-	MemoService_ListMemoAttachments_FullMethodName = "/memos.api.v1.MemoService/ListMemoAttachments"
-	MemoService_SetMemoRelations_FullMethodName    = "/memos.api.v1.MemoService/SetMemoRelations"
-	MemoService_ListMemoRelations_FullMethodName   = "/memos.api.v1.MemoService/ListMemoRelations"
-	MemoService_CreateMemoComment_FullMethodName   = "/memos.api.v1.MemoService/CreateMemoComment"
-	MemoService_ListMemoComments_FullMethodName    = "/memos.api.v1.MemoService/ListMemoComments"
-	MemoService_ListMemoReactions_FullMethodName   = "/memos.api.v1.MemoService/ListMemoReactions"
-	MemoService_UpsertMemoReaction_FullMethodName  = "/memos.api.v1.MemoService/UpsertMemoReaction"
-	MemoService_DeleteMemoReaction_FullMethodName  = "/memos.api.v1.MemoService/DeleteMemoReaction"
-	MemoService_GenerateAiTags_FullMethodName      = "/memos.api.v1.MemoService/GenerateAiTags"
-	MemoService_IndexMemo_FullMethodName           = "/memos.api.v1.MemoService/IndexMemo"
-	MemoService_DeleteMemoIndex_FullMethodName     = "/memos.api.v1.MemoService/DeleteMemoIndex"
-	MemoService_GetMemoIndexInfo_FullMethodName    = "/memos.api.v1.MemoService/GetMemoIndexInfo"
-	MemoService_AiSearch_FullMethodName            = "/memos.api.v1.MemoService/AiSearch"
-	MemoService_RebuildIndex_FullMethodName        = "/memos.api.v1.MemoService/RebuildIndex"
-	MemoService_GetRebuildStatus_FullMethodName    = "/memos.api.v1.MemoService/GetRebuildStatus"
-	MemoService_AiHealthCheck_FullMethodName       = "/memos.api.v1.MemoService/AiHealthCheck"
+	MemoService_CreateMemo_FullMethodName           = "/memos.api.v1.MemoService/CreateMemo"
+	MemoService_ListMemos_FullMethodName            = "/memos.api.v1.MemoService/ListMemos"
+	MemoService_GetMemo_FullMethodName              = "/memos.api.v1.MemoService/GetMemo"
+	MemoService_UpdateMemo_FullMethodName           = "/memos.api.v1.MemoService/UpdateMemo"
+	MemoService_DeleteMemo_FullMethodName           = "/memos.api.v1.MemoService/DeleteMemo"
+	MemoService_SetMemoAttachments_FullMethodName   = "/memos.api.v1.MemoService/SetMemoAttachments"
+	MemoService_ListMemoAttachments_FullMethodName  = "/memos.api.v1.MemoService/ListMemoAttachments"
+	MemoService_SetMemoRelations_FullMethodName     = "/memos.api.v1.MemoService/SetMemoRelations"
+	MemoService_ListMemoRelations_FullMethodName    = "/memos.api.v1.MemoService/ListMemoRelations"
+	MemoService_CreateMemoComment_FullMethodName    = "/memos.api.v1.MemoService/CreateMemoComment"
+	MemoService_ListMemoComments_FullMethodName     = "/memos.api.v1.MemoService/ListMemoComments"
+	MemoService_ListMemoReactions_FullMethodName    = "/memos.api.v1.MemoService/ListMemoReactions"
+	MemoService_UpsertMemoReaction_FullMethodName   = "/memos.api.v1.MemoService/UpsertMemoReaction"
+	MemoService_DeleteMemoReaction_FullMethodName   = "/memos.api.v1.MemoService/DeleteMemoReaction"
+	MemoService_GenerateAiTags_FullMethodName       = "/memos.api.v1.MemoService/GenerateAiTags"
+	MemoService_BatchGenerateAiTags_FullMethodName  = "/memos.api.v1.MemoService/BatchGenerateAiTags"
+	MemoService_ApplyAiTags_FullMethodName          = "/memos.api.v1.MemoService/ApplyAiTags"
+	MemoService_AiSummarize_FullMethodName          = "/memos.api.v1.MemoService/AiSummarize"
+	MemoService_IndexMemo_FullMethodName            = "/memos.api.v1.MemoService/IndexMemo"
+	MemoService_RefreshMemoIndex_FullMethodName     = "/memos.api.v1.MemoService/RefreshMemoIndex"
+	MemoService_DeleteMemoIndex_FullMethodName      = "/memos.api.v1.MemoService/DeleteMemoIndex"
+	MemoService_DeleteMemoIndexChunk_FullMethodName = "/memos.api.v1.MemoService/DeleteMemoIndexChunk"
+	MemoService_GetMemoIndexInfo_FullMethodName     = "/memos.api.v1.MemoService/GetMemoIndexInfo"
+	MemoService_AiSearch_FullMethodName             = "/memos.api.v1.MemoService/AiSearch"
+	MemoService_AiSearchStream_FullMethodName       = "/memos.api.v1.MemoService/AiSearchStream"
+	MemoService_AiAsk_FullMethodName                = "/memos.api.v1.MemoService/AiAsk"
+	MemoService_ListAiSearchModes_FullMethodName    = "/memos.api.v1.MemoService/ListAiSearchModes"
+	MemoService_GetAiServiceConfig_FullMethodName   = "/memos.api.v1.MemoService/GetAiServiceConfig"
+	MemoService_GetRelatedMemos_FullMethodName      = "/memos.api.v1.MemoService/GetRelatedMemos"
+	MemoService_RebuildIndex_FullMethodName         = "/memos.api.v1.MemoService/RebuildIndex"
+	MemoService_VerifyIndex_FullMethodName          = "/memos.api.v1.MemoService/VerifyIndex"
+	MemoService_DeleteCreatorIndex_FullMethodName   = "/memos.api.v1.MemoService/DeleteCreatorIndex"
+	MemoService_GetRebuildStatus_FullMethodName     = "/memos.api.v1.MemoService/GetRebuildStatus"
+	MemoService_CancelRebuild_FullMethodName        = "/memos.api.v1.MemoService/CancelRebuild"
+	MemoService_ListIndexedMemos_FullMethodName     = "/memos.api.v1.MemoService/ListIndexedMemos"
+	MemoService_ReconcileIndex_FullMethodName       = "/memos.api.v1.MemoService/ReconcileIndex"
+	MemoService_AiHealthCheck_FullMethodName        = "/memos.api.v1.MemoService/AiHealthCheck"
 )
 
 // MemoServiceClient is the client API for MemoService service.
@@ -78,18 +93,50 @@ type MemoServiceClient interface {
 	DeleteMemoReaction(ctx context.Context, in *DeleteMemoReactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// GenerateAiTags generates AI tags for a memo.
 	GenerateAiTags(ctx context.Context, in *GenerateAiTagsRequest, opts ...grpc.CallOption) (*GenerateAiTagsResponse, error)
+	// BatchGenerateAiTags generates AI tags for multiple memos.
+	BatchGenerateAiTags(ctx context.Context, in *BatchGenerateAiTagsRequest, opts ...grpc.CallOption) (*BatchGenerateAiTagsResponse, error)
+	// ApplyAiTags applies AI tags to a memo.
+	ApplyAiTags(ctx context.Context, in *ApplyAiTagsRequest, opts ...grpc.CallOption) (*ApplyAiTagsResponse, error)
+	// AiSummarize generates a summary of a memo.
+	AiSummarize(ctx context.Context, in *AiSummarizeRequest, opts ...grpc.CallOption) (*AiSummarizeResponse, error)
 	// IndexMemo indexes a memo for AI search.
 	IndexMemo(ctx context.Context, in *IndexMemoRequest, opts ...grpc.CallOption) (*IndexMemoResponse, error)
+	// RefreshMemoIndex forces the AI service to re-read and re-embed a memo from scratch.
+	RefreshMemoIndex(ctx context.Context, in *RefreshMemoIndexRequest, opts ...grpc.CallOption) (*RefreshMemoIndexResponse, error)
 	// DeleteMemoIndex deletes the index of a memo.
 	DeleteMemoIndex(ctx context.Context, in *DeleteMemoIndexRequest, opts ...grpc.CallOption) (*DeleteMemoIndexResponse, error)
+	// DeleteMemoIndexChunk deletes a single indexed chunk of a memo.
+	DeleteMemoIndexChunk(ctx context.Context, in *DeleteMemoIndexChunkRequest, opts ...grpc.CallOption) (*DeleteMemoIndexChunkResponse, error)
 	// GetMemoIndexInfo gets the index info of a memo.
 	GetMemoIndexInfo(ctx context.Context, in *GetMemoIndexInfoRequest, opts ...grpc.CallOption) (*MemoIndexInfo, error)
 	// AiSearch performs AI semantic search on memos.
 	AiSearch(ctx context.Context, in *AiSearchRequest, opts ...grpc.CallOption) (*AiSearchResponse, error)
+	// AiSearchStream performs AI semantic search on memos and streams the results one at a time.
+	// Paging fields in the request are ignored; top_k bounds the whole stream.
+	AiSearchStream(ctx context.Context, in *AiSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AiSearchResult], error)
+	// AiAsk answers a question about the current user's memos, citing the memos the answer is based on.
+	AiAsk(ctx context.Context, in *AiAskRequest, opts ...grpc.CallOption) (*AiAskResponse, error)
+	// ListAiSearchModes lists the search modes accepted by AiSearch.
+	ListAiSearchModes(ctx context.Context, in *ListAiSearchModesRequest, opts ...grpc.CallOption) (*ListAiSearchModesResponse, error)
+	// GetAiServiceConfig gets what the configured AI service supports, so clients can adapt to it.
+	GetAiServiceConfig(ctx context.Context, in *GetAiServiceConfigRequest, opts ...grpc.CallOption) (*AiServiceConfig, error)
+	// GetRelatedMemos finds memos similar to a memo.
+	GetRelatedMemos(ctx context.Context, in *GetRelatedMemosRequest, opts ...grpc.CallOption) (*GetRelatedMemosResponse, error)
 	// RebuildIndex rebuilds all memo indexes for a user.
 	RebuildIndex(ctx context.Context, in *RebuildIndexRequest, opts ...grpc.CallOption) (*RebuildIndexResponse, error)
+	// VerifyIndex checks whether the AI index of a user matches their memos without rebuilding it.
+	VerifyIndex(ctx context.Context, in *VerifyIndexRequest, opts ...grpc.CallOption) (*VerifyIndexResponse, error)
+	// DeleteCreatorIndex deletes the AI indexes of all memos of a user.
+	DeleteCreatorIndex(ctx context.Context, in *DeleteCreatorIndexRequest, opts ...grpc.CallOption) (*DeleteCreatorIndexResponse, error)
 	// GetRebuildStatus gets the rebuild index task status.
 	GetRebuildStatus(ctx context.Context, in *GetRebuildStatusRequest, opts ...grpc.CallOption) (*RebuildTaskStatus, error)
+	// CancelRebuild cancels the running rebuild index task of a user.
+	CancelRebuild(ctx context.Context, in *CancelRebuildRequest, opts ...grpc.CallOption) (*CancelRebuildResponse, error)
+	// ListIndexedMemos lists the memos the AI service has indexed for a user. Admin only.
+	ListIndexedMemos(ctx context.Context, in *ListIndexedMemosRequest, opts ...grpc.CallOption) (*ListIndexedMemosResponse, error)
+	// ReconcileIndex syncs the AI index of a user with their memos, indexing the missing memos and
+	// deleting the indexes of memos that are no longer stored or indexable.
+	ReconcileIndex(ctx context.Context, in *ReconcileIndexRequest, opts ...grpc.CallOption) (*ReconcileIndexResponse, error)
 	// AiHealthCheck checks the AI service health.
 	AiHealthCheck(ctx context.Context, in *AiHealthCheckRequest, opts ...grpc.CallOption) (*AiHealthCheckResponse, error)
 }
@@ -252,6 +299,36 @@ func (c *memoServiceClient) GenerateAiTags(ctx context.Context, in *GenerateAiTa
 	return out, nil
 }
 
+func (c *memoServiceClient) BatchGenerateAiTags(ctx context.Context, in *BatchGenerateAiTagsRequest, opts ...grpc.CallOption) (*BatchGenerateAiTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGenerateAiTagsResponse)
+	err := c.cc.Invoke(ctx, MemoService_BatchGenerateAiTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ApplyAiTags(ctx context.Context, in *ApplyAiTagsRequest, opts ...grpc.CallOption) (*ApplyAiTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyAiTagsResponse)
+	err := c.cc.Invoke(ctx, MemoService_ApplyAiTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) AiSummarize(ctx context.Context, in *AiSummarizeRequest, opts ...grpc.CallOption) (*AiSummarizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AiSummarizeResponse)
+	err := c.cc.Invoke(ctx, MemoService_AiSummarize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) IndexMemo(ctx context.Context, in *IndexMemoRequest, opts ...grpc.CallOption) (*IndexMemoResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IndexMemoResponse)
@@ -262,6 +339,16 @@ func (c *memoServiceClient) IndexMemo(ctx context.Context, in *IndexMemoRequest,
 	return out, nil
 }
 
+func (c *memoServiceClient) RefreshMemoIndex(ctx context.Context, in *RefreshMemoIndexRequest, opts ...grpc.CallOption) (*RefreshMemoIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshMemoIndexResponse)
+	err := c.cc.Invoke(ctx, MemoService_RefreshMemoIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) DeleteMemoIndex(ctx context.Context, in *DeleteMemoIndexRequest, opts ...grpc.CallOption) (*DeleteMemoIndexResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteMemoIndexResponse)
@@ -272,6 +359,16 @@ func (c *memoServiceClient) DeleteMemoIndex(ctx context.Context, in *DeleteMemoI
 	return out, nil
 }
 
+func (c *memoServiceClient) DeleteMemoIndexChunk(ctx context.Context, in *DeleteMemoIndexChunkRequest, opts ...grpc.CallOption) (*DeleteMemoIndexChunkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMemoIndexChunkResponse)
+	err := c.cc.Invoke(ctx, MemoService_DeleteMemoIndexChunk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) GetMemoIndexInfo(ctx context.Context, in *GetMemoIndexInfoRequest, opts ...grpc.CallOption) (*MemoIndexInfo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MemoIndexInfo)
@@ -292,6 +389,65 @@ func (c *memoServiceClient) AiSearch(ctx context.Context, in *AiSearchRequest, o
 	return out, nil
 }
 
+func (c *memoServiceClient) AiSearchStream(ctx context.Context, in *AiSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AiSearchResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MemoService_ServiceDesc.Streams[0], MemoService_AiSearchStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AiSearchRequest, AiSearchResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MemoService_AiSearchStreamClient = grpc.ServerStreamingClient[AiSearchResult]
+
+func (c *memoServiceClient) AiAsk(ctx context.Context, in *AiAskRequest, opts ...grpc.CallOption) (*AiAskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AiAskResponse)
+	err := c.cc.Invoke(ctx, MemoService_AiAsk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ListAiSearchModes(ctx context.Context, in *ListAiSearchModesRequest, opts ...grpc.CallOption) (*ListAiSearchModesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAiSearchModesResponse)
+	err := c.cc.Invoke(ctx, MemoService_ListAiSearchModes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) GetAiServiceConfig(ctx context.Context, in *GetAiServiceConfigRequest, opts ...grpc.CallOption) (*AiServiceConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AiServiceConfig)
+	err := c.cc.Invoke(ctx, MemoService_GetAiServiceConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) GetRelatedMemos(ctx context.Context, in *GetRelatedMemosRequest, opts ...grpc.CallOption) (*GetRelatedMemosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedMemosResponse)
+	err := c.cc.Invoke(ctx, MemoService_GetRelatedMemos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) RebuildIndex(ctx context.Context, in *RebuildIndexRequest, opts ...grpc.CallOption) (*RebuildIndexResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RebuildIndexResponse)
@@ -302,6 +458,26 @@ func (c *memoServiceClient) RebuildIndex(ctx context.Context, in *RebuildIndexRe
 	return out, nil
 }
 
+func (c *memoServiceClient) VerifyIndex(ctx context.Context, in *VerifyIndexRequest, opts ...grpc.CallOption) (*VerifyIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyIndexResponse)
+	err := c.cc.Invoke(ctx, MemoService_VerifyIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) DeleteCreatorIndex(ctx context.Context, in *DeleteCreatorIndexRequest, opts ...grpc.CallOption) (*DeleteCreatorIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCreatorIndexResponse)
+	err := c.cc.Invoke(ctx, MemoService_DeleteCreatorIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) GetRebuildStatus(ctx context.Context, in *GetRebuildStatusRequest, opts ...grpc.CallOption) (*RebuildTaskStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RebuildTaskStatus)
@@ -312,6 +488,36 @@ func (c *memoServiceClient) GetRebuildStatus(ctx context.Context, in *GetRebuild
 	return out, nil
 }
 
+func (c *memoServiceClient) CancelRebuild(ctx context.Context, in *CancelRebuildRequest, opts ...grpc.CallOption) (*CancelRebuildResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelRebuildResponse)
+	err := c.cc.Invoke(ctx, MemoService_CancelRebuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ListIndexedMemos(ctx context.Context, in *ListIndexedMemosRequest, opts ...grpc.CallOption) (*ListIndexedMemosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIndexedMemosResponse)
+	err := c.cc.Invoke(ctx, MemoService_ListIndexedMemos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ReconcileIndex(ctx context.Context, in *ReconcileIndexRequest, opts ...grpc.CallOption) (*ReconcileIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileIndexResponse)
+	err := c.cc.Invoke(ctx, MemoService_ReconcileIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) AiHealthCheck(ctx context.Context, in *AiHealthCheckRequest, opts ...grpc.CallOption) (*AiHealthCheckResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AiHealthCheckResponse)
@@ -356,18 +562,50 @@ type MemoServiceServer interface {
 	DeleteMemoReaction(context.Context, *DeleteMemoReactionRequest) (*emptypb.Empty, error)
 	// GenerateAiTags generates AI tags for a memo.
 	GenerateAiTags(context.Context, *GenerateAiTagsRequest) (*GenerateAiTagsResponse, error)
+	// BatchGenerateAiTags generates AI tags for multiple memos.
+	BatchGenerateAiTags(context.Context, *BatchGenerateAiTagsRequest) (*BatchGenerateAiTagsResponse, error)
+	// ApplyAiTags applies AI tags to a memo.
+	ApplyAiTags(context.Context, *ApplyAiTagsRequest) (*ApplyAiTagsResponse, error)
+	// AiSummarize generates a summary of a memo.
+	AiSummarize(context.Context, *AiSummarizeRequest) (*AiSummarizeResponse, error)
 	// IndexMemo indexes a memo for AI search.
 	IndexMemo(context.Context, *IndexMemoRequest) (*IndexMemoResponse, error)
+	// RefreshMemoIndex forces the AI service to re-read and re-embed a memo from scratch.
+	RefreshMemoIndex(context.Context, *RefreshMemoIndexRequest) (*RefreshMemoIndexResponse, error)
 	// DeleteMemoIndex deletes the index of a memo.
 	DeleteMemoIndex(context.Context, *DeleteMemoIndexRequest) (*DeleteMemoIndexResponse, error)
+	// DeleteMemoIndexChunk deletes a single indexed chunk of a memo.
+	DeleteMemoIndexChunk(context.Context, *DeleteMemoIndexChunkRequest) (*DeleteMemoIndexChunkResponse, error)
 	// GetMemoIndexInfo gets the index info of a memo.
 	GetMemoIndexInfo(context.Context, *GetMemoIndexInfoRequest) (*MemoIndexInfo, error)
 	// AiSearch performs AI semantic search on memos.
 	AiSearch(context.Context, *AiSearchRequest) (*AiSearchResponse, error)
+	// AiSearchStream performs AI semantic search on memos and streams the results one at a time.
+	// Paging fields in the request are ignored; top_k bounds the whole stream.
+	AiSearchStream(*AiSearchRequest, grpc.ServerStreamingServer[AiSearchResult]) error
+	// AiAsk answers a question about the current user's memos, citing the memos the answer is based on.
+	AiAsk(context.Context, *AiAskRequest) (*AiAskResponse, error)
+	// ListAiSearchModes lists the search modes accepted by AiSearch.
+	ListAiSearchModes(context.Context, *ListAiSearchModesRequest) (*ListAiSearchModesResponse, error)
+	// GetAiServiceConfig gets what the configured AI service supports, so clients can adapt to it.
+	GetAiServiceConfig(context.Context, *GetAiServiceConfigRequest) (*AiServiceConfig, error)
+	// GetRelatedMemos finds memos similar to a memo.
+	GetRelatedMemos(context.Context, *GetRelatedMemosRequest) (*GetRelatedMemosResponse, error)
 	// RebuildIndex rebuilds all memo indexes for a user.
 	RebuildIndex(context.Context, *RebuildIndexRequest) (*RebuildIndexResponse, error)
+	// VerifyIndex checks whether the AI index of a user matches their memos without rebuilding it.
+	VerifyIndex(context.Context, *VerifyIndexRequest) (*VerifyIndexResponse, error)
+	// DeleteCreatorIndex deletes the AI indexes of all memos of a user.
+	DeleteCreatorIndex(context.Context, *DeleteCreatorIndexRequest) (*DeleteCreatorIndexResponse, error)
 	// GetRebuildStatus gets the rebuild index task status.
 	GetRebuildStatus(context.Context, *GetRebuildStatusRequest) (*RebuildTaskStatus, error)
+	// CancelRebuild cancels the running rebuild index task of a user.
+	CancelRebuild(context.Context, *CancelRebuildRequest) (*CancelRebuildResponse, error)
+	// ListIndexedMemos lists the memos the AI service has indexed for a user. Admin only.
+	ListIndexedMemos(context.Context, *ListIndexedMemosRequest) (*ListIndexedMemosResponse, error)
+	// ReconcileIndex syncs the AI index of a user with their memos, indexing the missing memos and
+	// deleting the indexes of memos that are no longer stored or indexable.
+	ReconcileIndex(context.Context, *ReconcileIndexRequest) (*ReconcileIndexResponse, error)
 	// AiHealthCheck checks the AI service health.
 	AiHealthCheck(context.Context, *AiHealthCheckRequest) (*AiHealthCheckResponse, error)
 	mustEmbedUnimplementedMemoServiceServer()
@@ -425,24 +663,69 @@ func (UnimplementedMemoServiceServer) DeleteMemoReaction(context.Context, *Delet
 func (UnimplementedMemoServiceServer) GenerateAiTags(context.Context, *GenerateAiTagsRequest) (*GenerateAiTagsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateAiTags not implemented")
 }
+func (UnimplementedMemoServiceServer) BatchGenerateAiTags(context.Context, *BatchGenerateAiTagsRequest) (*BatchGenerateAiTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGenerateAiTags not implemented")
+}
+func (UnimplementedMemoServiceServer) ApplyAiTags(context.Context, *ApplyAiTagsRequest) (*ApplyAiTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyAiTags not implemented")
+}
+func (UnimplementedMemoServiceServer) AiSummarize(context.Context, *AiSummarizeRequest) (*AiSummarizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AiSummarize not implemented")
+}
 func (UnimplementedMemoServiceServer) IndexMemo(context.Context, *IndexMemoRequest) (*IndexMemoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IndexMemo not implemented")
 }
+func (UnimplementedMemoServiceServer) RefreshMemoIndex(context.Context, *RefreshMemoIndexRequest) (*RefreshMemoIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshMemoIndex not implemented")
+}
 func (UnimplementedMemoServiceServer) DeleteMemoIndex(context.Context, *DeleteMemoIndexRequest) (*DeleteMemoIndexResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMemoIndex not implemented")
 }
+func (UnimplementedMemoServiceServer) DeleteMemoIndexChunk(context.Context, *DeleteMemoIndexChunkRequest) (*DeleteMemoIndexChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMemoIndexChunk not implemented")
+}
 func (UnimplementedMemoServiceServer) GetMemoIndexInfo(context.Context, *GetMemoIndexInfoRequest) (*MemoIndexInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMemoIndexInfo not implemented")
 }
 func (UnimplementedMemoServiceServer) AiSearch(context.Context, *AiSearchRequest) (*AiSearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AiSearch not implemented")
 }
+func (UnimplementedMemoServiceServer) AiSearchStream(*AiSearchRequest, grpc.ServerStreamingServer[AiSearchResult]) error {
+	return status.Errorf(codes.Unimplemented, "method AiSearchStream not implemented")
+}
+func (UnimplementedMemoServiceServer) AiAsk(context.Context, *AiAskRequest) (*AiAskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AiAsk not implemented")
+}
+func (UnimplementedMemoServiceServer) ListAiSearchModes(context.Context, *ListAiSearchModesRequest) (*ListAiSearchModesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAiSearchModes not implemented")
+}
+func (UnimplementedMemoServiceServer) GetAiServiceConfig(context.Context, *GetAiServiceConfigRequest) (*AiServiceConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAiServiceConfig not implemented")
+}
+func (UnimplementedMemoServiceServer) GetRelatedMemos(context.Context, *GetRelatedMemosRequest) (*GetRelatedMemosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedMemos not implemented")
+}
 func (UnimplementedMemoServiceServer) RebuildIndex(context.Context, *RebuildIndexRequest) (*RebuildIndexResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RebuildIndex not implemented")
 }
+func (UnimplementedMemoServiceServer) VerifyIndex(context.Context, *VerifyIndexRequest) (*VerifyIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyIndex not implemented")
+}
+func (UnimplementedMemoServiceServer) DeleteCreatorIndex(context.Context, *DeleteCreatorIndexRequest) (*DeleteCreatorIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCreatorIndex not implemented")
+}
 func (UnimplementedMemoServiceServer) GetRebuildStatus(context.Context, *GetRebuildStatusRequest) (*RebuildTaskStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRebuildStatus not implemented")
 }
+func (UnimplementedMemoServiceServer) CancelRebuild(context.Context, *CancelRebuildRequest) (*CancelRebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRebuild not implemented")
+}
+func (UnimplementedMemoServiceServer) ListIndexedMemos(context.Context, *ListIndexedMemosRequest) (*ListIndexedMemosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIndexedMemos not implemented")
+}
+func (UnimplementedMemoServiceServer) ReconcileIndex(context.Context, *ReconcileIndexRequest) (*ReconcileIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileIndex not implemented")
+}
 func (UnimplementedMemoServiceServer) AiHealthCheck(context.Context, *AiHealthCheckRequest) (*AiHealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AiHealthCheck not implemented")
 }
@@ -737,6 +1020,60 @@ func _MemoService_GenerateAiTags_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_BatchGenerateAiTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGenerateAiTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).BatchGenerateAiTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_BatchGenerateAiTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).BatchGenerateAiTags(ctx, req.(*BatchGenerateAiTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ApplyAiTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyAiTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ApplyAiTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ApplyAiTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ApplyAiTags(ctx, req.(*ApplyAiTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_AiSummarize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AiSummarizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).AiSummarize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_AiSummarize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).AiSummarize(ctx, req.(*AiSummarizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_IndexMemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IndexMemoRequest)
 	if err := dec(in); err != nil {
@@ -755,6 +1092,24 @@ func _MemoService_IndexMemo_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_RefreshMemoIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshMemoIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).RefreshMemoIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_RefreshMemoIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).RefreshMemoIndex(ctx, req.(*RefreshMemoIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_DeleteMemoIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteMemoIndexRequest)
 	if err := dec(in); err != nil {
@@ -773,6 +1128,24 @@ func _MemoService_DeleteMemoIndex_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_DeleteMemoIndexChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMemoIndexChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).DeleteMemoIndexChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_DeleteMemoIndexChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).DeleteMemoIndexChunk(ctx, req.(*DeleteMemoIndexChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_GetMemoIndexInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMemoIndexInfoRequest)
 	if err := dec(in); err != nil {
@@ -809,6 +1182,89 @@ func _MemoService_AiSearch_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_AiSearchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AiSearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MemoServiceServer).AiSearchStream(m, &grpc.GenericServerStream[AiSearchRequest, AiSearchResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MemoService_AiSearchStreamServer = grpc.ServerStreamingServer[AiSearchResult]
+
+func _MemoService_AiAsk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AiAskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).AiAsk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_AiAsk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).AiAsk(ctx, req.(*AiAskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ListAiSearchModes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAiSearchModesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ListAiSearchModes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ListAiSearchModes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ListAiSearchModes(ctx, req.(*ListAiSearchModesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_GetAiServiceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAiServiceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).GetAiServiceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_GetAiServiceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).GetAiServiceConfig(ctx, req.(*GetAiServiceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_GetRelatedMemos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedMemosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).GetRelatedMemos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_GetRelatedMemos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).GetRelatedMemos(ctx, req.(*GetRelatedMemosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_RebuildIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RebuildIndexRequest)
 	if err := dec(in); err != nil {
@@ -827,6 +1283,42 @@ func _MemoService_RebuildIndex_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_VerifyIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).VerifyIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_VerifyIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).VerifyIndex(ctx, req.(*VerifyIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_DeleteCreatorIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCreatorIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).DeleteCreatorIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_DeleteCreatorIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).DeleteCreatorIndex(ctx, req.(*DeleteCreatorIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_GetRebuildStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRebuildStatusRequest)
 	if err := dec(in); err != nil {
@@ -845,6 +1337,60 @@ func _MemoService_GetRebuildStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_CancelRebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).CancelRebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_CancelRebuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).CancelRebuild(ctx, req.(*CancelRebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ListIndexedMemos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIndexedMemosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ListIndexedMemos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ListIndexedMemos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ListIndexedMemos(ctx, req.(*ListIndexedMemosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ReconcileIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ReconcileIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ReconcileIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ReconcileIndex(ctx, req.(*ReconcileIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_AiHealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AiHealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -930,14 +1476,34 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateAiTags",
 			Handler:    _MemoService_GenerateAiTags_Handler,
 		},
+		{
+			MethodName: "BatchGenerateAiTags",
+			Handler:    _MemoService_BatchGenerateAiTags_Handler,
+		},
+		{
+			MethodName: "ApplyAiTags",
+			Handler:    _MemoService_ApplyAiTags_Handler,
+		},
+		{
+			MethodName: "AiSummarize",
+			Handler:    _MemoService_AiSummarize_Handler,
+		},
 		{
 			MethodName: "IndexMemo",
 			Handler:    _MemoService_IndexMemo_Handler,
 		},
+		{
+			MethodName: "RefreshMemoIndex",
+			Handler:    _MemoService_RefreshMemoIndex_Handler,
+		},
 		{
 			MethodName: "DeleteMemoIndex",
 			Handler:    _MemoService_DeleteMemoIndex_Handler,
 		},
+		{
+			MethodName: "DeleteMemoIndexChunk",
+			Handler:    _MemoService_DeleteMemoIndexChunk_Handler,
+		},
 		{
 			MethodName: "GetMemoIndexInfo",
 			Handler:    _MemoService_GetMemoIndexInfo_Handler,
@@ -946,19 +1512,61 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AiSearch",
 			Handler:    _MemoService_AiSearch_Handler,
 		},
+		{
+			MethodName: "AiAsk",
+			Handler:    _MemoService_AiAsk_Handler,
+		},
+		{
+			MethodName: "ListAiSearchModes",
+			Handler:    _MemoService_ListAiSearchModes_Handler,
+		},
+		{
+			MethodName: "GetAiServiceConfig",
+			Handler:    _MemoService_GetAiServiceConfig_Handler,
+		},
+		{
+			MethodName: "GetRelatedMemos",
+			Handler:    _MemoService_GetRelatedMemos_Handler,
+		},
 		{
 			MethodName: "RebuildIndex",
 			Handler:    _MemoService_RebuildIndex_Handler,
 		},
+		{
+			MethodName: "VerifyIndex",
+			Handler:    _MemoService_VerifyIndex_Handler,
+		},
+		{
+			MethodName: "DeleteCreatorIndex",
+			Handler:    _MemoService_DeleteCreatorIndex_Handler,
+		},
 		{
 			MethodName: "GetRebuildStatus",
 			Handler:    _MemoService_GetRebuildStatus_Handler,
 		},
+		{
+			MethodName: "CancelRebuild",
+			Handler:    _MemoService_CancelRebuild_Handler,
+		},
+		{
+			MethodName: "ListIndexedMemos",
+			Handler:    _MemoService_ListIndexedMemos_Handler,
+		},
+		{
+			MethodName: "ReconcileIndex",
+			Handler:    _MemoService_ReconcileIndex_Handler,
+		},
 		{
 			MethodName: "AiHealthCheck",
 			Handler:    _MemoService_AiHealthCheck_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AiSearchStream",
+			Handler:       _MemoService_AiSearchStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/v1/memo_service.proto",
 }
@@ -82,7 +82,10 @@ type AttachmentPayload struct {
 	// Types that are valid to be assigned to Payload:
 	//
 	//	*AttachmentPayload_S3Object_
-	Payload       isAttachmentPayload_Payload `protobuf_oneof:"payload"`
+	Payload isAttachmentPayload_Payload `protobuf_oneof:"payload"`
+	// extracted_text is the text extracted from the attachment, e.g. by OCR or image captioning.
+	// It is sent along with the attachment when indexing memos for AI search.
+	ExtractedText string `protobuf:"bytes,2,opt,name=extracted_text,json=extractedText,proto3" json:"extracted_text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +136,13 @@ func (x *AttachmentPayload) GetS3Object() *AttachmentPayload_S3Object {
 	return nil
 }
 
+func (x *AttachmentPayload) GetExtractedText() string {
+	if x != nil {
+		return x.ExtractedText
+	}
+	return ""
+}
+
 type isAttachmentPayload_Payload interface {
 	isAttachmentPayload_Payload()
 }
@@ -210,9 +220,10 @@ var File_store_attachment_proto protoreflect.FileDescriptor
 
 const file_store_attachment_proto_rawDesc = "" +
 	"\n" +
-	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cstore/instance_setting.proto\"\x8c\x02\n" +
+	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cstore/instance_setting.proto\"\xb3\x02\n" +
 	"\x11AttachmentPayload\x12F\n" +
-	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x1a\xa3\x01\n" +
+	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x12%\n" +
+	"\x0eextracted_text\x18\x02 \x01(\tR\rextractedText\x1a\xa3\x01\n" +
 	"\bS3Object\x129\n" +
 	"\ts3_config\x18\x01 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12J\n" +
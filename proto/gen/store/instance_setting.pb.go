@@ -696,9 +696,14 @@ type InstanceMemoRelatedSetting struct {
 	// enable_blur_nsfw_content enables blurring of content marked as not safe for work (NSFW).
 	EnableBlurNsfwContent bool `protobuf:"varint,9,opt,name=enable_blur_nsfw_content,json=enableBlurNsfwContent,proto3" json:"enable_blur_nsfw_content,omitempty"`
 	// nsfw_tags is the list of tags that mark content as NSFW for blurring.
-	NsfwTags      []string `protobuf:"bytes,10,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	NsfwTags []string `protobuf:"bytes,10,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
+	// tag_locale is a BCP 47 language tag for language-specific tag case mapping, e.g. "tr".
+	// Default: "" (language-neutral)
+	TagLocale string `protobuf:"bytes,11,opt,name=tag_locale,json=tagLocale,proto3" json:"tag_locale,omitempty"`
+	// preserve_tag_case keeps the original case of tags instead of lowercasing them.
+	PreserveTagCase bool `protobuf:"varint,12,opt,name=preserve_tag_case,json=preserveTagCase,proto3" json:"preserve_tag_case,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *InstanceMemoRelatedSetting) Reset() {
@@ -794,11 +799,66 @@ func (x *InstanceMemoRelatedSetting) GetNsfwTags() []string {
 	return nil
 }
 
+func (x *InstanceMemoRelatedSetting) GetTagLocale() string {
+	if x != nil {
+		return x.TagLocale
+	}
+	return ""
+}
+
+func (x *InstanceMemoRelatedSetting) GetPreserveTagCase() bool {
+	if x != nil {
+		return x.PreserveTagCase
+	}
+	return false
+}
+
 type InstanceAiSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// ai_service_url is the URL of the AI service.
 	// Default: http://127.0.0.1:8000
-	AiServiceUrl  string `protobuf:"bytes,1,opt,name=ai_service_url,json=aiServiceUrl,proto3" json:"ai_service_url,omitempty"`
+	AiServiceUrl string `protobuf:"bytes,1,opt,name=ai_service_url,json=aiServiceUrl,proto3" json:"ai_service_url,omitempty"`
+	// exclude_public_memos excludes memos with public visibility from AI indexing.
+	ExcludePublicMemos bool `protobuf:"varint,2,opt,name=exclude_public_memos,json=excludePublicMemos,proto3" json:"exclude_public_memos,omitempty"`
+	// tag_cache_ttl_seconds is how long a user's tag set is cached for AI tag generation.
+	// Default: 60
+	TagCacheTtlSeconds int32 `protobuf:"varint,3,opt,name=tag_cache_ttl_seconds,json=tagCacheTtlSeconds,proto3" json:"tag_cache_ttl_seconds,omitempty"`
+	// max_attachment_size_mb is the max size of a local attachment embedded in AI requests.
+	// Larger attachments are sent with metadata only. Default: 5
+	MaxAttachmentSizeMb int64 `protobuf:"varint,4,opt,name=max_attachment_size_mb,json=maxAttachmentSizeMb,proto3" json:"max_attachment_size_mb,omitempty"`
+	// index_delete_grace_period_seconds defers deleting the index of a deleted memo, so it is kept
+	// if the memo is restored within the period. Default: 0 (delete immediately)
+	IndexDeleteGracePeriodSeconds int32 `protobuf:"varint,5,opt,name=index_delete_grace_period_seconds,json=indexDeleteGracePeriodSeconds,proto3" json:"index_delete_grace_period_seconds,omitempty"`
+	// ai_requests_per_minute limits the GenerateAiTags and AiSearch requests of each user other than
+	// admins and the host, per method. Default: 0 (unlimited)
+	AiRequestsPerMinute int32 `protobuf:"varint,6,opt,name=ai_requests_per_minute,json=aiRequestsPerMinute,proto3" json:"ai_requests_per_minute,omitempty"`
+	// max_content_chars is the max number of characters of memo content sent to the AI service for
+	// tag generation and indexing. Longer content is truncated from the end. Default: 20000
+	MaxContentChars int32 `protobuf:"varint,7,opt,name=max_content_chars,json=maxContentChars,proto3" json:"max_content_chars,omitempty"`
+	// fold_tag_case treats tags differing only in case, e.g. "Work" and "work", as one tag when collecting
+	// a user's tags for AI tag generation, keeping the most used casing. Suggested tags are matched the
+	// same way. Default: false (tags are case-sensitive)
+	FoldTagCase bool `protobuf:"varint,8,opt,name=fold_tag_case,json=foldTagCase,proto3" json:"fold_tag_case,omitempty"`
+	// max_attachments is the max number of attachments of a memo sent to the AI service. Images are
+	// kept first when a memo has more. Default: 10
+	MaxAttachments int32 `protobuf:"varint,9,opt,name=max_attachments,json=maxAttachments,proto3" json:"max_attachments,omitempty"`
+	// allow_internal_callback_urls allows rebuild callback URLs pointing to loopback, private or
+	// link-local addresses, e.g. when the AI service and the callback receiver share a private network.
+	// Default: false
+	AllowInternalCallbackUrls bool `protobuf:"varint,10,opt,name=allow_internal_callback_urls,json=allowInternalCallbackUrls,proto3" json:"allow_internal_callback_urls,omitempty"`
+	// default_search_top_k is the max number of results of AI searches not specifying one.
+	// Default: 0 (10 results)
+	DefaultSearchTopK int32 `protobuf:"varint,11,opt,name=default_search_top_k,json=defaultSearchTopK,proto3" json:"default_search_top_k,omitempty"`
+	// default_search_min_score is the min score of AI searches not specifying one.
+	// Default: 0 (0.5)
+	DefaultSearchMinScore float32 `protobuf:"fixed32,12,opt,name=default_search_min_score,json=defaultSearchMinScore,proto3" json:"default_search_min_score,omitempty"`
+	// default_search_mode is the search mode of AI searches not specifying one, one of those listed by
+	// ListAiSearchModes. Default: empty ("hybrid")
+	DefaultSearchMode string `protobuf:"bytes,13,opt,name=default_search_mode,json=defaultSearchMode,proto3" json:"default_search_mode,omitempty"`
+	// index_mode is what of a memo is sent to the AI service for indexing: "both" the text and the
+	// attachments, "text_only" without attachment data, e.g. for AI services without an image model,
+	// or "images_only" only image attachments. Default: empty ("both")
+	IndexMode     string `protobuf:"bytes,14,opt,name=index_mode,json=indexMode,proto3" json:"index_mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -840,6 +900,97 @@ func (x *InstanceAiSetting) GetAiServiceUrl() string {
 	return ""
 }
 
+func (x *InstanceAiSetting) GetExcludePublicMemos() bool {
+	if x != nil {
+		return x.ExcludePublicMemos
+	}
+	return false
+}
+
+func (x *InstanceAiSetting) GetTagCacheTtlSeconds() int32 {
+	if x != nil {
+		return x.TagCacheTtlSeconds
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetMaxAttachmentSizeMb() int64 {
+	if x != nil {
+		return x.MaxAttachmentSizeMb
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetIndexDeleteGracePeriodSeconds() int32 {
+	if x != nil {
+		return x.IndexDeleteGracePeriodSeconds
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetAiRequestsPerMinute() int32 {
+	if x != nil {
+		return x.AiRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetMaxContentChars() int32 {
+	if x != nil {
+		return x.MaxContentChars
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetFoldTagCase() bool {
+	if x != nil {
+		return x.FoldTagCase
+	}
+	return false
+}
+
+func (x *InstanceAiSetting) GetMaxAttachments() int32 {
+	if x != nil {
+		return x.MaxAttachments
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetAllowInternalCallbackUrls() bool {
+	if x != nil {
+		return x.AllowInternalCallbackUrls
+	}
+	return false
+}
+
+func (x *InstanceAiSetting) GetDefaultSearchTopK() int32 {
+	if x != nil {
+		return x.DefaultSearchTopK
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetDefaultSearchMinScore() float32 {
+	if x != nil {
+		return x.DefaultSearchMinScore
+	}
+	return 0
+}
+
+func (x *InstanceAiSetting) GetDefaultSearchMode() string {
+	if x != nil {
+		return x.DefaultSearchMode
+	}
+	return ""
+}
+
+func (x *InstanceAiSetting) GetIndexMode() string {
+	if x != nil {
+		return x.IndexMode
+	}
+	return ""
+}
+
 var File_store_instance_setting_proto protoreflect.FileDescriptor
 
 const file_store_instance_setting_proto_rawDesc = "" +
@@ -889,7 +1040,7 @@ const file_store_instance_setting_proto_rawDesc = "" +
 	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
-	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"\xe0\x03\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"\xab\x04\n" +
 	"\x1aInstanceMemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -900,9 +1051,27 @@ const file_store_instance_setting_proto_rawDesc = "" +
 	"\x1adisable_markdown_shortcuts\x18\b \x01(\bR\x18disableMarkdownShortcuts\x127\n" +
 	"\x18enable_blur_nsfw_content\x18\t \x01(\bR\x15enableBlurNsfwContent\x12\x1b\n" +
 	"\tnsfw_tags\x18\n" +
-	" \x03(\tR\bnsfwTags\"9\n" +
+	" \x03(\tR\bnsfwTags\x12\x1d\n" +
+	"\n" +
+	"tag_locale\x18\v \x01(\tR\ttagLocale\x12*\n" +
+	"\x11preserve_tag_case\x18\f \x01(\bR\x0fpreserveTagCase\"\xc5\x05\n" +
 	"\x11InstanceAiSetting\x12$\n" +
-	"\x0eai_service_url\x18\x01 \x01(\tR\faiServiceUrl*y\n" +
+	"\x0eai_service_url\x18\x01 \x01(\tR\faiServiceUrl\x120\n" +
+	"\x14exclude_public_memos\x18\x02 \x01(\bR\x12excludePublicMemos\x121\n" +
+	"\x15tag_cache_ttl_seconds\x18\x03 \x01(\x05R\x12tagCacheTtlSeconds\x123\n" +
+	"\x16max_attachment_size_mb\x18\x04 \x01(\x03R\x13maxAttachmentSizeMb\x12H\n" +
+	"!index_delete_grace_period_seconds\x18\x05 \x01(\x05R\x1dindexDeleteGracePeriodSeconds\x123\n" +
+	"\x16ai_requests_per_minute\x18\x06 \x01(\x05R\x13aiRequestsPerMinute\x12*\n" +
+	"\x11max_content_chars\x18\a \x01(\x05R\x0fmaxContentChars\x12\"\n" +
+	"\rfold_tag_case\x18\b \x01(\bR\vfoldTagCase\x12'\n" +
+	"\x0fmax_attachments\x18\t \x01(\x05R\x0emaxAttachments\x12?\n" +
+	"\x1callow_internal_callback_urls\x18\n" +
+	" \x01(\bR\x19allowInternalCallbackUrls\x12/\n" +
+	"\x14default_search_top_k\x18\v \x01(\x05R\x11defaultSearchTopK\x127\n" +
+	"\x18default_search_min_score\x18\f \x01(\x02R\x15defaultSearchMinScore\x12.\n" +
+	"\x13default_search_mode\x18\r \x01(\tR\x11defaultSearchMode\x12\x1d\n" +
+	"\n" +
+	"index_mode\x18\x0e \x01(\tR\tindexMode*y\n" +
 	"\x12InstanceSettingKey\x12$\n" +
 	" INSTANCE_SETTING_KEY_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05BASIC\x10\x01\x12\v\n" +
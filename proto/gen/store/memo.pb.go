@@ -27,7 +27,10 @@ type MemoPayload struct {
 	Location *MemoPayload_Location  `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
 	Tags     []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
 	// The AI generated tags extracted from memo content.
-	AiTags        []string `protobuf:"bytes,4,rep,name=ai_tags,json=aiTags,proto3" json:"ai_tags,omitempty"`
+	AiTags []string `protobuf:"bytes,4,rep,name=ai_tags,json=aiTags,proto3" json:"ai_tags,omitempty"`
+	// The hash of the memo as last sent to the AI index, used to skip re-indexing unchanged memos.
+	// Empty if the memo isn't known to be indexed.
+	AiIndexHash   string `protobuf:"bytes,5,opt,name=ai_index_hash,json=aiIndexHash,proto3" json:"ai_index_hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,6 +93,13 @@ func (x *MemoPayload) GetAiTags() []string {
 	return nil
 }
 
+func (x *MemoPayload) GetAiIndexHash() string {
+	if x != nil {
+		return x.AiIndexHash
+	}
+	return ""
+}
+
 // The calculated properties from the memo content.
 type MemoPayload_Property struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -223,12 +233,13 @@ var File_store_memo_proto protoreflect.FileDescriptor
 
 const file_store_memo_proto_rawDesc = "" +
 	"\n" +
-	"\x10store/memo.proto\x12\vmemos.store\"\xb9\x03\n" +
+	"\x10store/memo.proto\x12\vmemos.store\"\xdd\x03\n" +
 	"\vMemoPayload\x12=\n" +
 	"\bproperty\x18\x01 \x01(\v2!.memos.store.MemoPayload.PropertyR\bproperty\x12=\n" +
 	"\blocation\x18\x02 \x01(\v2!.memos.store.MemoPayload.LocationR\blocation\x12\x12\n" +
 	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x17\n" +
-	"\aai_tags\x18\x04 \x03(\tR\x06aiTags\x1a\x96\x01\n" +
+	"\aai_tags\x18\x04 \x03(\tR\x06aiTags\x12\"\n" +
+	"\rai_index_hash\x18\x05 \x01(\tR\vaiIndexHash\x1a\x96\x01\n" +
 	"\bProperty\x12\x19\n" +
 	"\bhas_link\x18\x01 \x01(\bR\ahasLink\x12\"\n" +
 	"\rhas_task_list\x18\x02 \x01(\bR\vhasTaskList\x12\x19\n" +
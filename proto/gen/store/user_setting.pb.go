@@ -36,6 +36,8 @@ const (
 	UserSetting_SHORTCUTS UserSetting_Key = 4
 	// The webhooks of the user.
 	UserSetting_WEBHOOKS UserSetting_Key = 5
+	// The AI index deletions requested by the user that are waiting for their grace period.
+	UserSetting_AI_INDEX_DELETIONS UserSetting_Key = 6
 )
 
 // Enum value maps for UserSetting_Key.
@@ -47,14 +49,16 @@ var (
 		3: "ACCESS_TOKENS",
 		4: "SHORTCUTS",
 		5: "WEBHOOKS",
+		6: "AI_INDEX_DELETIONS",
 	}
 	UserSetting_Key_value = map[string]int32{
-		"KEY_UNSPECIFIED": 0,
-		"GENERAL":         1,
-		"SESSIONS":        2,
-		"ACCESS_TOKENS":   3,
-		"SHORTCUTS":       4,
-		"WEBHOOKS":        5,
+		"KEY_UNSPECIFIED":    0,
+		"GENERAL":            1,
+		"SESSIONS":           2,
+		"ACCESS_TOKENS":      3,
+		"SHORTCUTS":          4,
+		"WEBHOOKS":           5,
+		"AI_INDEX_DELETIONS": 6,
 	}
 )
 
@@ -96,6 +100,7 @@ type UserSetting struct {
 	//	*UserSetting_AccessTokens
 	//	*UserSetting_Shortcuts
 	//	*UserSetting_Webhooks
+	//	*UserSetting_AiIndexDeletions
 	Value         isUserSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -197,6 +202,15 @@ func (x *UserSetting) GetWebhooks() *WebhooksUserSetting {
 	return nil
 }
 
+func (x *UserSetting) GetAiIndexDeletions() *AiIndexDeletionsUserSetting {
+	if x != nil {
+		if x, ok := x.Value.(*UserSetting_AiIndexDeletions); ok {
+			return x.AiIndexDeletions
+		}
+	}
+	return nil
+}
+
 type isUserSetting_Value interface {
 	isUserSetting_Value()
 }
@@ -221,6 +235,10 @@ type UserSetting_Webhooks struct {
 	Webhooks *WebhooksUserSetting `protobuf:"bytes,7,opt,name=webhooks,proto3,oneof"`
 }
 
+type UserSetting_AiIndexDeletions struct {
+	AiIndexDeletions *AiIndexDeletionsUserSetting `protobuf:"bytes,8,opt,name=ai_index_deletions,json=aiIndexDeletions,proto3,oneof"`
+}
+
 func (*UserSetting_General) isUserSetting_Value() {}
 
 func (*UserSetting_Sessions) isUserSetting_Value() {}
@@ -231,6 +249,8 @@ func (*UserSetting_Shortcuts) isUserSetting_Value() {}
 
 func (*UserSetting_Webhooks) isUserSetting_Value() {}
 
+func (*UserSetting_AiIndexDeletions) isUserSetting_Value() {}
+
 type GeneralUserSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The user's locale.
@@ -507,6 +527,50 @@ func (x *WebhooksUserSetting) GetWebhooks() []*WebhooksUserSetting_Webhook {
 	return nil
 }
 
+type AiIndexDeletionsUserSetting struct {
+	state         protoimpl.MessageState                  `protogen:"open.v1"`
+	Deletions     []*AiIndexDeletionsUserSetting_Deletion `protobuf:"bytes,1,rep,name=deletions,proto3" json:"deletions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiIndexDeletionsUserSetting) Reset() {
+	*x = AiIndexDeletionsUserSetting{}
+	mi := &file_store_user_setting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiIndexDeletionsUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiIndexDeletionsUserSetting) ProtoMessage() {}
+
+func (x *AiIndexDeletionsUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiIndexDeletionsUserSetting.ProtoReflect.Descriptor instead.
+func (*AiIndexDeletionsUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AiIndexDeletionsUserSetting) GetDeletions() []*AiIndexDeletionsUserSetting_Deletion {
+	if x != nil {
+		return x.Deletions
+	}
+	return nil
+}
+
 type SessionsUserSetting_Session struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Unique session identifier.
@@ -524,7 +588,7 @@ type SessionsUserSetting_Session struct {
 
 func (x *SessionsUserSetting_Session) Reset() {
 	*x = SessionsUserSetting_Session{}
-	mi := &file_store_user_setting_proto_msgTypes[6]
+	mi := &file_store_user_setting_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -536,7 +600,7 @@ func (x *SessionsUserSetting_Session) String() string {
 func (*SessionsUserSetting_Session) ProtoMessage() {}
 
 func (x *SessionsUserSetting_Session) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[6]
+	mi := &file_store_user_setting_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -598,7 +662,7 @@ type SessionsUserSetting_ClientInfo struct {
 
 func (x *SessionsUserSetting_ClientInfo) Reset() {
 	*x = SessionsUserSetting_ClientInfo{}
-	mi := &file_store_user_setting_proto_msgTypes[7]
+	mi := &file_store_user_setting_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -610,7 +674,7 @@ func (x *SessionsUserSetting_ClientInfo) String() string {
 func (*SessionsUserSetting_ClientInfo) ProtoMessage() {}
 
 func (x *SessionsUserSetting_ClientInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[7]
+	mi := &file_store_user_setting_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -674,7 +738,7 @@ type AccessTokensUserSetting_AccessToken struct {
 
 func (x *AccessTokensUserSetting_AccessToken) Reset() {
 	*x = AccessTokensUserSetting_AccessToken{}
-	mi := &file_store_user_setting_proto_msgTypes[8]
+	mi := &file_store_user_setting_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -686,7 +750,7 @@ func (x *AccessTokensUserSetting_AccessToken) String() string {
 func (*AccessTokensUserSetting_AccessToken) ProtoMessage() {}
 
 func (x *AccessTokensUserSetting_AccessToken) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[8]
+	mi := &file_store_user_setting_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,7 +791,7 @@ type ShortcutsUserSetting_Shortcut struct {
 
 func (x *ShortcutsUserSetting_Shortcut) Reset() {
 	*x = ShortcutsUserSetting_Shortcut{}
-	mi := &file_store_user_setting_proto_msgTypes[9]
+	mi := &file_store_user_setting_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -739,7 +803,7 @@ func (x *ShortcutsUserSetting_Shortcut) String() string {
 func (*ShortcutsUserSetting_Shortcut) ProtoMessage() {}
 
 func (x *ShortcutsUserSetting_Shortcut) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[9]
+	mi := &file_store_user_setting_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -790,7 +854,7 @@ type WebhooksUserSetting_Webhook struct {
 
 func (x *WebhooksUserSetting_Webhook) Reset() {
 	*x = WebhooksUserSetting_Webhook{}
-	mi := &file_store_user_setting_proto_msgTypes[10]
+	mi := &file_store_user_setting_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -802,7 +866,7 @@ func (x *WebhooksUserSetting_Webhook) String() string {
 func (*WebhooksUserSetting_Webhook) ProtoMessage() {}
 
 func (x *WebhooksUserSetting_Webhook) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[10]
+	mi := &file_store_user_setting_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,11 +903,65 @@ func (x *WebhooksUserSetting_Webhook) GetUrl() string {
 	return ""
 }
 
+type AiIndexDeletionsUserSetting_Deletion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the deleted memo whose index is pending deletion.
+	MemoId string `protobuf:"bytes,1,opt,name=memo_id,json=memoId,proto3" json:"memo_id,omitempty"`
+	// When the grace period ends and the index is deleted.
+	DeleteTime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiIndexDeletionsUserSetting_Deletion) Reset() {
+	*x = AiIndexDeletionsUserSetting_Deletion{}
+	mi := &file_store_user_setting_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiIndexDeletionsUserSetting_Deletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiIndexDeletionsUserSetting_Deletion) ProtoMessage() {}
+
+func (x *AiIndexDeletionsUserSetting_Deletion) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiIndexDeletionsUserSetting_Deletion.ProtoReflect.Descriptor instead.
+func (*AiIndexDeletionsUserSetting_Deletion) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *AiIndexDeletionsUserSetting_Deletion) GetMemoId() string {
+	if x != nil {
+		return x.MemoId
+	}
+	return ""
+}
+
+func (x *AiIndexDeletionsUserSetting_Deletion) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
 var File_store_user_setting_proto protoreflect.FileDescriptor
 
 const file_store_user_setting_proto_rawDesc = "" +
 	"\n" +
-	"\x18store/user_setting.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x04\n" +
+	"\x18store/user_setting.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\"\x85\x05\n" +
 	"\vUserSetting\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12.\n" +
 	"\x03key\x18\x02 \x01(\x0e2\x1c.memos.store.UserSetting.KeyR\x03key\x12;\n" +
@@ -851,14 +969,16 @@ const file_store_user_setting_proto_rawDesc = "" +
 	"\bsessions\x18\x04 \x01(\v2 .memos.store.SessionsUserSettingH\x00R\bsessions\x12K\n" +
 	"\raccess_tokens\x18\x05 \x01(\v2$.memos.store.AccessTokensUserSettingH\x00R\faccessTokens\x12A\n" +
 	"\tshortcuts\x18\x06 \x01(\v2!.memos.store.ShortcutsUserSettingH\x00R\tshortcuts\x12>\n" +
-	"\bwebhooks\x18\a \x01(\v2 .memos.store.WebhooksUserSettingH\x00R\bwebhooks\"e\n" +
+	"\bwebhooks\x18\a \x01(\v2 .memos.store.WebhooksUserSettingH\x00R\bwebhooks\x12X\n" +
+	"\x12ai_index_deletions\x18\b \x01(\v2(.memos.store.AiIndexDeletionsUserSettingH\x00R\x10aiIndexDeletions\"}\n" +
 	"\x03Key\x12\x13\n" +
 	"\x0fKEY_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aGENERAL\x10\x01\x12\f\n" +
 	"\bSESSIONS\x10\x02\x12\x11\n" +
 	"\rACCESS_TOKENS\x10\x03\x12\r\n" +
 	"\tSHORTCUTS\x10\x04\x12\f\n" +
-	"\bWEBHOOKS\x10\x05B\a\n" +
+	"\bWEBHOOKS\x10\x05\x12\x16\n" +
+	"\x12AI_INDEX_DELETIONS\x10\x06B\a\n" +
 	"\x05value\"\x9a\x02\n" +
 	"\x12GeneralUserSetting\x12\x16\n" +
 	"\x06locale\x18\x01 \x01(\tR\x06locale\x12'\n" +
@@ -904,7 +1024,13 @@ const file_store_user_setting_proto_rawDesc = "" +
 	"\aWebhook\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x10\n" +
-	"\x03url\x18\x03 \x01(\tR\x03urlB\x9b\x01\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"\xd0\x01\n" +
+	"\x1bAiIndexDeletionsUserSetting\x12O\n" +
+	"\tdeletions\x18\x01 \x03(\v21.memos.store.AiIndexDeletionsUserSetting.DeletionR\tdeletions\x1a`\n" +
+	"\bDeletion\x12\x17\n" +
+	"\amemo_id\x18\x01 \x01(\tR\x06memoId\x12;\n" +
+	"\vdelete_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTimeB\x9b\x01\n" +
 	"\x0fcom.memos.storeB\x10UserSettingProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -920,21 +1046,23 @@ func file_store_user_setting_proto_rawDescGZIP() []byte {
 }
 
 var file_store_user_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_store_user_setting_proto_goTypes = []any{
-	(UserSetting_Key)(0),                        // 0: memos.store.UserSetting.Key
-	(*UserSetting)(nil),                         // 1: memos.store.UserSetting
-	(*GeneralUserSetting)(nil),                  // 2: memos.store.GeneralUserSetting
-	(*SessionsUserSetting)(nil),                 // 3: memos.store.SessionsUserSetting
-	(*AccessTokensUserSetting)(nil),             // 4: memos.store.AccessTokensUserSetting
-	(*ShortcutsUserSetting)(nil),                // 5: memos.store.ShortcutsUserSetting
-	(*WebhooksUserSetting)(nil),                 // 6: memos.store.WebhooksUserSetting
-	(*SessionsUserSetting_Session)(nil),         // 7: memos.store.SessionsUserSetting.Session
-	(*SessionsUserSetting_ClientInfo)(nil),      // 8: memos.store.SessionsUserSetting.ClientInfo
-	(*AccessTokensUserSetting_AccessToken)(nil), // 9: memos.store.AccessTokensUserSetting.AccessToken
-	(*ShortcutsUserSetting_Shortcut)(nil),       // 10: memos.store.ShortcutsUserSetting.Shortcut
-	(*WebhooksUserSetting_Webhook)(nil),         // 11: memos.store.WebhooksUserSetting.Webhook
-	(*timestamppb.Timestamp)(nil),               // 12: google.protobuf.Timestamp
+	(UserSetting_Key)(0),                         // 0: memos.store.UserSetting.Key
+	(*UserSetting)(nil),                          // 1: memos.store.UserSetting
+	(*GeneralUserSetting)(nil),                   // 2: memos.store.GeneralUserSetting
+	(*SessionsUserSetting)(nil),                  // 3: memos.store.SessionsUserSetting
+	(*AccessTokensUserSetting)(nil),              // 4: memos.store.AccessTokensUserSetting
+	(*ShortcutsUserSetting)(nil),                 // 5: memos.store.ShortcutsUserSetting
+	(*WebhooksUserSetting)(nil),                  // 6: memos.store.WebhooksUserSetting
+	(*AiIndexDeletionsUserSetting)(nil),          // 7: memos.store.AiIndexDeletionsUserSetting
+	(*SessionsUserSetting_Session)(nil),          // 8: memos.store.SessionsUserSetting.Session
+	(*SessionsUserSetting_ClientInfo)(nil),       // 9: memos.store.SessionsUserSetting.ClientInfo
+	(*AccessTokensUserSetting_AccessToken)(nil),  // 10: memos.store.AccessTokensUserSetting.AccessToken
+	(*ShortcutsUserSetting_Shortcut)(nil),        // 11: memos.store.ShortcutsUserSetting.Shortcut
+	(*WebhooksUserSetting_Webhook)(nil),          // 12: memos.store.WebhooksUserSetting.Webhook
+	(*AiIndexDeletionsUserSetting_Deletion)(nil), // 13: memos.store.AiIndexDeletionsUserSetting.Deletion
+	(*timestamppb.Timestamp)(nil),                // 14: google.protobuf.Timestamp
 }
 var file_store_user_setting_proto_depIdxs = []int32{
 	0,  // 0: memos.store.UserSetting.key:type_name -> memos.store.UserSetting.Key
@@ -943,18 +1071,21 @@ var file_store_user_setting_proto_depIdxs = []int32{
 	4,  // 3: memos.store.UserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting
 	5,  // 4: memos.store.UserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting
 	6,  // 5: memos.store.UserSetting.webhooks:type_name -> memos.store.WebhooksUserSetting
-	7,  // 6: memos.store.SessionsUserSetting.sessions:type_name -> memos.store.SessionsUserSetting.Session
-	9,  // 7: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
-	10, // 8: memos.store.ShortcutsUserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting.Shortcut
-	11, // 9: memos.store.WebhooksUserSetting.webhooks:type_name -> memos.store.WebhooksUserSetting.Webhook
-	12, // 10: memos.store.SessionsUserSetting.Session.create_time:type_name -> google.protobuf.Timestamp
-	12, // 11: memos.store.SessionsUserSetting.Session.last_accessed_time:type_name -> google.protobuf.Timestamp
-	8,  // 12: memos.store.SessionsUserSetting.Session.client_info:type_name -> memos.store.SessionsUserSetting.ClientInfo
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	7,  // 6: memos.store.UserSetting.ai_index_deletions:type_name -> memos.store.AiIndexDeletionsUserSetting
+	8,  // 7: memos.store.SessionsUserSetting.sessions:type_name -> memos.store.SessionsUserSetting.Session
+	10, // 8: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
+	11, // 9: memos.store.ShortcutsUserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting.Shortcut
+	12, // 10: memos.store.WebhooksUserSetting.webhooks:type_name -> memos.store.WebhooksUserSetting.Webhook
+	13, // 11: memos.store.AiIndexDeletionsUserSetting.deletions:type_name -> memos.store.AiIndexDeletionsUserSetting.Deletion
+	14, // 12: memos.store.SessionsUserSetting.Session.create_time:type_name -> google.protobuf.Timestamp
+	14, // 13: memos.store.SessionsUserSetting.Session.last_accessed_time:type_name -> google.protobuf.Timestamp
+	9,  // 14: memos.store.SessionsUserSetting.Session.client_info:type_name -> memos.store.SessionsUserSetting.ClientInfo
+	14, // 15: memos.store.AiIndexDeletionsUserSetting.Deletion.delete_time:type_name -> google.protobuf.Timestamp
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_store_user_setting_proto_init() }
@@ -968,6 +1099,7 @@ func file_store_user_setting_proto_init() {
 		(*UserSetting_AccessTokens)(nil),
 		(*UserSetting_Shortcuts)(nil),
 		(*UserSetting_Webhooks)(nil),
+		(*UserSetting_AiIndexDeletions)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -975,7 +1107,7 @@ func file_store_user_setting_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_user_setting_proto_rawDesc), len(file_store_user_setting_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   11,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
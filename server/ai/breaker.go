@@ -0,0 +1,139 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrServiceUnavailable is returned while the circuit breaker is open, without contacting the AI service.
+// Like transport failures, it is wrapped with ErrUnavailable.
+var ErrServiceUnavailable = errors.New("AI service circuit breaker is open")
+
+const (
+	// DefaultFailureThreshold is the default number of consecutive failures that open the circuit breaker.
+	DefaultFailureThreshold = 5
+	// DefaultCooldown is the default time the circuit breaker stays open before allowing a trial request.
+	DefaultCooldown = 30 * time.Second
+)
+
+// CircuitBreaker stops requests to the AI service after consecutive failures. Once open, requests fail
+// with ErrServiceUnavailable for a cooldown, after which a single trial request is let through:
+// its success closes the breaker and its failure reopens it.
+// A breaker is shared by the clients of a service so its state outlives any one client.
+// A nil *CircuitBreaker never opens.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	// openedAt is when the breaker last opened, or zero while it is closed.
+	openedAt time.Time
+	// trial is set while the trial request after the cooldown is in flight.
+	trial bool
+}
+
+// CircuitBreakerOption configures a CircuitBreaker.
+type CircuitBreakerOption func(*CircuitBreaker)
+
+// WithFailureThreshold sets the number of consecutive failures that open the breaker.
+// Non-positive values are ignored.
+func WithFailureThreshold(n int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		if n > 0 {
+			b.threshold = n
+		}
+	}
+}
+
+// WithCooldown sets how long the breaker stays open before allowing a trial request.
+// Non-positive values are ignored.
+func WithCooldown(d time.Duration) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		if d > 0 {
+			b.cooldown = d
+		}
+	}
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(opts ...CircuitBreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		threshold: DefaultFailureThreshold,
+		cooldown:  DefaultCooldown,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithCircuitBreaker guards the client's requests with breaker. A nil breaker never opens.
+// HealthCheck and HealthCheckDetailed bypass the breaker so they can probe for recovery.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *Client) {
+		c.breaker = breaker
+	}
+}
+
+// allow reports whether a request may be sent. If it returns true, the caller must report
+// the outcome with record or release.
+func (b *CircuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.trial || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// record reports the outcome of an allowed request.
+func (b *CircuitBreaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// release reports an allowed request whose outcome says nothing about the AI service.
+func (b *CircuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// recordResponse reports the outcome of a request sent with ctx that returned resp and err.
+// Transport errors and 5xx responses are failures, unless the caller canceled the request.
+func (b *CircuitBreaker) recordResponse(ctx context.Context, resp *http.Response, err error) {
+	if err != nil && ctx.Err() != nil {
+		b.release()
+		return
+	}
+	b.record(err != nil || resp.StatusCode >= http.StatusInternalServerError)
+}
@@ -3,54 +3,260 @@ package ai
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/usememos/memos/internal/util"
 )
 
 // Client is the AI service client.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	batchSize   int
+	metrics     *Metrics
+	compression bool
+	breaker     *CircuitBreaker
+	// fallbackURLs are tried after baseURL, and lastGoodURL is the index in
+	// [baseURL, fallbackURLs...] of the URL that last responded.
+	fallbackURLs []string
+	lastGoodURL  atomic.Int32
+	// basePath is prepended to the path of every endpoint, e.g. "/ai".
+	basePath        string
+	transportTuning TransportTuning
+	logger          *slog.Logger
+	// rebuildNotFoundGracePeriod is how long WaitForRebuild waits for a rebuild task to be registered.
+	rebuildNotFoundGracePeriod time.Duration
+	// operationTimeouts override the timeout of the HTTP client for requests of an operation.
+	operationTimeouts map[string]time.Duration
+	// urlErr is set if a service URL failed ValidateServiceURL, and is returned for every request.
+	urlErr error
 }
 
 // DefaultAIServiceURL is the default URL for the AI service.
 const DefaultAIServiceURL = "http://127.0.0.1:8000"
 
-// NewClient creates a new AI service client.
-// If aiServiceURL is empty, it falls back to AI_SERVICE_URL env var, then to default.
-func NewClient(aiServiceURL string) *Client {
-	baseURL := aiServiceURL
-	if baseURL == "" {
-		baseURL = os.Getenv("AI_SERVICE_URL")
+// DefaultBatchSize is the default maximum number of memos sent in a single batch index request.
+const DefaultBatchSize = 50
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBatchSize sets the maximum number of memos sent in a single batch index request.
+// Larger batches are split automatically. Non-positive values are ignored.
+func WithBatchSize(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.batchSize = n
+		}
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call the AI service, e.g. to configure a proxy or TLS.
+// It replaces the internal client entirely, including its default 60s timeout. Nil is ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithBasePath sets a path prefix prepended to the path of every endpoint, for an AI service
+// mounted under a prefix, e.g. "/ai" turns "/internal/search" into "/ai/internal/search".
+// Surrounding slashes are normalized; an empty prefix keeps the default paths.
+func WithBasePath(prefix string) Option {
+	return func(c *Client) {
+		c.basePath = ""
+		if prefix = strings.Trim(prefix, "/"); prefix != "" {
+			c.basePath = "/" + prefix
+		}
 	}
-	if baseURL == "" {
-		baseURL = DefaultAIServiceURL
+}
+
+// WithRebuildNotFoundGracePeriod sets how long WaitForRebuild polls a rebuild task that isn't
+// registered before giving up with ErrRebuildNotFound. Non-positive values are ignored.
+func WithRebuildNotFoundGracePeriod(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.rebuildNotFoundGracePeriod = d
+		}
 	}
+}
 
-	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+// WithOperationTimeout sets the timeout of requests of operation, the operation label of the request
+// metrics, e.g. "search" or "generate_tags". It replaces the timeout of the HTTP client for those
+// requests, so it may be shorter or longer than it, and also applies to "search_stream", which
+// otherwise has no timeout. A deadline of the request context still applies on top: a request is
+// bounded by the shorter of the context deadline and the operation timeout, or the HTTP client
+// timeout if the operation has none. Non-positive values are ignored.
+func WithOperationTimeout(operation string, d time.Duration) Option {
+	return func(c *Client) {
+		if d <= 0 {
+			return
+		}
+		if c.operationTimeouts == nil {
+			c.operationTimeouts = map[string]time.Duration{}
+		}
+		c.operationTimeouts[operation] = d
 	}
 }
 
+// ResolveServiceURL returns the URL of the AI service used by NewClient for aiServiceURL:
+// aiServiceURL itself if not empty, else the AI_SERVICE_URL env var if not empty, else DefaultAIServiceURL.
+func ResolveServiceURL(aiServiceURL string) string {
+	if aiServiceURL != "" {
+		return aiServiceURL
+	}
+	if envURL := os.Getenv("AI_SERVICE_URL"); envURL != "" {
+		return envURL
+	}
+	return DefaultAIServiceURL
+}
+
+// NewClient creates a new AI service client for the URL resolved by ResolveServiceURL.
+// If the URL or a fallback URL fails ValidateServiceURL, and AllowUnsafeServiceURLEnv isn't set,
+// every request of the client fails with ErrUnsafeServiceURL without being sent.
+func NewClient(aiServiceURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:         ResolveServiceURL(aiServiceURL),
+		batchSize:       DefaultBatchSize,
+		compression:     true,
+		transportTuning: DefaultTransportTuning,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{
+			Transport: sharedTransport(c.transportTuning),
+			Timeout:   60 * time.Second,
+		}
+	}
+	c.urlErr = c.validateServiceURLs()
+	return c
+}
+
+// endpoint returns the URL of the endpoint at path, which must start with a slash.
+func (c *Client) endpoint(path string) string {
+	return c.baseURL + c.basePath + path
+}
+
+// RequestIDHeader is the header carrying the ID that correlates a request with the AI service logs.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDMetadataKey is the incoming gRPC metadata key whose value is propagated as RequestIDHeader.
+const requestIDMetadataKey = "x-request-id"
+
+// send sends a request of operation to the AI service with c.httpClient. See sendWith.
+func (c *Client) send(operation string, httpReq *http.Request) (*http.Response, error) {
+	return c.sendWith(c.httpClient, operation, httpReq)
+}
+
+// sendWith sends a request of operation to the AI service with RequestIDHeader set to the request ID
+// of the incoming gRPC call in the request context, or to a new UUID if there is none.
+// Transport errors are annotated with the request ID, and the request is recorded in c.metrics and logged.
+// Unless compression is disabled, large bodies are gzipped and gzip responses are decompressed.
+// While c.breaker is open, it fails with ErrServiceUnavailable without sending the request.
+// With fallback URLs, unreachable and failing URLs are skipped as described by doFailover.
+func (c *Client) sendWith(httpClient *http.Client, operation string, httpReq *http.Request) (*http.Response, error) {
+	if c.urlErr != nil {
+		return nil, c.urlErr
+	}
+	if !c.breaker.allow() {
+		return nil, ErrServiceUnavailable
+	}
+	resp, err := c.doFailover(httpClient, operation, httpReq)
+	c.breaker.recordResponse(httpReq.Context(), resp, err)
+	return resp, err
+}
+
+// probe sends a health check request of operation, bypassing c.breaker.
+// A healthy response closes the breaker.
+func (c *Client) probe(operation string, httpReq *http.Request) (*http.Response, error) {
+	if c.urlErr != nil {
+		return nil, c.urlErr
+	}
+	resp, err := c.doFailover(c.httpClient, operation, httpReq)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		c.breaker.record(false)
+	}
+	return resp, err
+}
+
+// do sends a request of operation as described by sendWith, ignoring c.breaker.
+// The request, including reading the response body, is bounded by the shorter of the timeout of
+// operation, or of httpClient if operation has none, and the deadline of the request context,
+// and is aborted when the context is canceled.
+func (c *Client) do(httpClient *http.Client, operation string, httpReq *http.Request) (*http.Response, error) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(httpReq.Context()); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = util.GenUUID()
+	}
+	httpReq.Header.Set(RequestIDHeader, requestID)
+	if err := c.compressRequest(httpReq); err != nil {
+		return nil, &RequestError{RequestID: requestID, Err: fmt.Errorf("failed to compress request: %w", err)}
+	}
+
+	timeout := httpClient.Timeout
+	if d, ok := c.operationTimeouts[operation]; ok {
+		timeout = d
+	}
+	httpClient, httpReq, cancel := withRequestTimeout(httpClient, httpReq, timeout)
+	start := time.Now()
+	resp, err := httpClient.Do(httpReq)
+	c.metrics.observe(operation, start, resp, err)
+	c.logRequest(httpReq.Context(), operation, httpReq, requestID, start, resp, err)
+	if err != nil {
+		cancel()
+		return nil, &RequestError{RequestID: requestID, Err: err}
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	decompressResponse(resp)
+	return resp, nil
+}
+
 // TagGenerationRequest is the request for tag generation.
 type TagGenerationRequest struct {
 	Memo struct {
-		Name        string                 `json:"name"`
-		Content     string                 `json:"content"`
-		Tags        []string               `json:"tags"`
+		Name        string            `json:"name"`
+		Content     string            `json:"content"`
+		Tags        []string          `json:"tags"`
 		Attachments []AttachmentForAI `json:"attachments"`
 	} `json:"memo"`
 	UserAllTags []string `json:"user_all_tags"`
 	MaxTags     int      `json:"max_tags"`
+	// Model overrides the tag generation model for this request. Empty uses the service default.
+	Model string `json:"model,omitempty"`
+	// ExcludeTags are tags never to suggest, matched ignoring case.
+	ExcludeTags []string `json:"exclude_tags,omitempty"`
+	// Language is the BCP-47 code of the language to generate tags in, or TagLanguageAuto.
+	// Empty leaves the language to the service.
+	Language string `json:"language,omitempty"`
 }
 
+// TagLanguageAuto asks the AI service to generate tags in the detected language of the memo.
+const TagLanguageAuto = "auto"
+
 // AttachmentForAI represents an attachment for AI service.
 type AttachmentForAI struct {
 	Name         string `json:"name,omitempty"`
@@ -65,6 +271,8 @@ type TagGenerationResponse struct {
 	Tags       []string `json:"tags"`
 	MergedTags []string `json:"merged_tags"`
 	Error      string   `json:"error,omitempty"`
+	// Usage is the token usage of the call, or nil if the AI service didn't report it.
+	Usage *TokenUsage `json:"usage,omitempty"`
 }
 
 // GenerateTags generates tags for a memo using AI service.
@@ -75,7 +283,7 @@ func (c *Client) GenerateTags(ctx context.Context, req *TagGenerationRequest) (*
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		fmt.Sprintf("%s/api/v1/tags/generate", c.baseURL),
+		c.endpoint("/api/v1/tags/generate"),
 		bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -83,28 +291,158 @@ func (c *Client) GenerateTags(ctx context.Context, req *TagGenerationRequest) (*
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send("generate_tags", httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI service returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newStatusError(resp, body)
 	}
 
 	var result TagGenerationResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	if !result.Success {
+		return nil, withRequestID(resp, fmt.Errorf("AI service error: %s", result.Error))
+	}
+
+	return &result, nil
+}
+
+// SummarizeRequest is the request for memo summarization.
+type SummarizeRequest struct {
+	Memo struct {
+		Name    string `json:"name"`
+		Content string `json:"content"`
+	} `json:"memo"`
+	MaxWords int    `json:"max_words,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// TokenUsage is the token usage of a model call.
+type TokenUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// SummarizeResponse is the response from memo summarization.
+type SummarizeResponse struct {
+	Success bool       `json:"success"`
+	Summary string     `json:"summary"`
+	Usage   TokenUsage `json:"usage"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// Summarize generates a summary of a memo using AI service.
+func (c *Client) Summarize(ctx context.Context, req *SummarizeRequest) (*SummarizeResponse, error) {
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.endpoint("/api/v1/summarize"),
+		bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.send("summarize", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp, body)
+	}
+
+	var result SummarizeResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("AI service error: %s", result.Error)
+		return nil, withRequestID(resp, fmt.Errorf("AI service error: %s", result.Error))
+	}
+
+	return &result, nil
+}
+
+// AskRequest is the request to answer a question from the memos of a user.
+type AskRequest struct {
+	Question string `json:"question"`
+	// Creator limits the memos the answer is grounded on to those of a user, e.g. "users/1".
+	Creator string `json:"creator"`
+	// TopK is the number of memos the answer is grounded on. Zero uses the service default.
+	TopK int `json:"top_k,omitempty"`
+}
+
+// AskResponse is the answer to a question.
+type AskResponse struct {
+	Success bool   `json:"success"`
+	Answer  string `json:"answer"`
+	// Citations lists the UIDs of the memos the answer cites.
+	Citations []string   `json:"citations"`
+	Usage     TokenUsage `json:"usage"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Ask answers a question grounded on the memos retrieved for it, citing the memos used.
+func (c *Client) Ask(ctx context.Context, req *AskRequest) (*AskResponse, error) {
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.endpoint("/api/v1/ask"),
+		bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.send("ask", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp, body)
+	}
+
+	var result AskResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	if !result.Success {
+		return nil, withRequestID(resp, fmt.Errorf("AI service error: %s", result.Error))
 	}
 
 	return &result, nil
@@ -112,8 +450,11 @@ func (c *Client) GenerateTags(ctx context.Context, req *TagGenerationRequest) (*
 
 // IndexMemoRequest is the request for indexing a memo.
 type IndexMemoRequest struct {
-	Memo      interface{} `json:"memo"`
-	Operation string      `json:"operation"`
+	Memo      *MemoDocument `json:"memo"`
+	Operation string        `json:"operation"`
+	// ContentHash is the HashMemoDocument of Memo, which the AI service reports as the
+	// MemoIndexInfo.ContentHash of the memo once it's indexed.
+	ContentHash string `json:"content_hash,omitempty"`
 }
 
 // IndexMemoResponse is the response from indexing a memo.
@@ -121,20 +462,71 @@ type IndexMemoResponse struct {
 	MemoUID   string `json:"memo_uid"`
 	Status    string `json:"status"`
 	Timestamp string `json:"timestamp"`
+	// TextVectors and ImageVectors are the vector counts after the operation, if reported.
+	TextVectors  int `json:"text_vectors,omitempty"`
+	ImageVectors int `json:"image_vectors,omitempty"`
+	// Accepted reports whether the AI service answered 202 Accepted, i.e. only queued the memo, which
+	// may then still fail to index.
+	Accepted bool `json:"-"`
+}
+
+// Index operations accepted by IndexMemoWithOperation.
+const (
+	// IndexOperationUpsert indexes a memo whether or not it is already indexed.
+	IndexOperationUpsert = "upsert"
+	// IndexOperationCreate indexes a memo only if it isn't indexed yet.
+	IndexOperationCreate = "create"
+	// IndexOperationReplace replaces the index of a memo that is already indexed.
+	IndexOperationReplace = "replace"
+)
+
+// IsValidIndexOperation reports whether operation is accepted by IndexMemoWithOperation.
+func IsValidIndexOperation(operation string) bool {
+	switch operation {
+	case IndexOperationUpsert, IndexOperationCreate, IndexOperationReplace:
+		return true
+	default:
+		return false
+	}
 }
 
 // IndexMemo indexes a memo in the AI service.
-func (c *Client) IndexMemo(ctx context.Context, memo interface{}) (*IndexMemoResponse, error) {
+// The AI service skips re-embedding if the memo is already indexed with the same HashMemoDocument.
+func (c *Client) IndexMemo(ctx context.Context, memo *MemoDocument) (*IndexMemoResponse, error) {
+	return c.indexMemo(ctx, memo, IndexOperationUpsert)
+}
+
+// IndexMemoWithOperation indexes a memo in the AI service with the given index operation.
+// Empty operation means IndexOperationUpsert. A create of an indexed memo or a replace of a
+// memo that isn't indexed is rejected by the AI service.
+func (c *Client) IndexMemoWithOperation(ctx context.Context, memo *MemoDocument, operation string) (*IndexMemoResponse, error) {
+	if operation == "" {
+		operation = IndexOperationUpsert
+	}
+	if !IsValidIndexOperation(operation) {
+		return nil, fmt.Errorf("invalid index operation %q", operation)
+	}
+	return c.indexMemo(ctx, memo, operation)
+}
+
+// RefreshMemoIndex forces the AI service to drop and re-embed a memo from scratch,
+// bypassing the unchanged-hash skip of the other operations.
+func (c *Client) RefreshMemoIndex(ctx context.Context, memo *MemoDocument) (*IndexMemoResponse, error) {
+	return c.indexMemo(ctx, memo, "refresh")
+}
+
+func (c *Client) indexMemo(ctx context.Context, memo *MemoDocument, operation string) (*IndexMemoResponse, error) {
 	reqBody, err := json.Marshal(&IndexMemoRequest{
-		Memo:      memo,
-		Operation: "upsert",
+		Memo:        memo,
+		Operation:   operation,
+		ContentHash: HashMemoDocument(memo),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		fmt.Sprintf("%s/internal/index/memo", c.baseURL),
+		c.endpoint("/internal/index/memo"),
 		bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -142,48 +534,204 @@ func (c *Client) IndexMemo(ctx context.Context, memo interface{}) (*IndexMemoRes
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	metricsOperation := "index"
+	if operation == "refresh" {
+		metricsOperation = "refresh_index"
+	}
+	resp, err := c.send(metricsOperation, httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
 	}
 
 	// Accept both 200 OK and 202 Accepted (async processing)
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("AI service returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newStatusError(resp, body)
 	}
 
 	var result IndexMemoResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
 	}
+	result.Accepted = resp.StatusCode == http.StatusAccepted
 
 	return &result, nil
 }
 
+// BatchIndexResult is the indexing result of a single memo in a batch.
+type BatchIndexResult struct {
+	MemoUID string `json:"memo_uid"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BatchIndexResponse is the response from indexing memos in batch.
+type BatchIndexResponse struct {
+	Results []BatchIndexResult `json:"results"`
+}
+
+// Failed returns the results of the memos that failed to index, so callers can retry only those.
+func (r *BatchIndexResponse) Failed() []BatchIndexResult {
+	failed := []BatchIndexResult{}
+	for _, result := range r.Results {
+		if result.Error != "" {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// IndexMemosBatch indexes multiple memos in the AI service.
+// Memos are sent in batches of at most the configured batch size. If the AI service rejects
+// a whole batch, its memos are retried one by one so a single bad memo doesn't fail the others.
+func (c *Client) IndexMemosBatch(ctx context.Context, memos []*MemoDocument) (*BatchIndexResponse, error) {
+	response := &BatchIndexResponse{
+		Results: make([]BatchIndexResult, 0, len(memos)),
+	}
+	for start := 0; start < len(memos); start += c.batchSize {
+		end := min(start+c.batchSize, len(memos))
+		batch := memos[start:end]
+
+		results, err := c.indexMemosBatch(ctx, batch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			results = make([]BatchIndexResult, 0, len(batch))
+			for _, memo := range batch {
+				result := BatchIndexResult{MemoUID: memo.UID}
+				resp, err := c.IndexMemo(ctx, memo)
+				if err != nil {
+					result.Status = "failed"
+					result.Error = err.Error()
+				} else {
+					result.Status = resp.Status
+				}
+				results = append(results, result)
+			}
+		}
+		response.Results = append(response.Results, results...)
+	}
+	return response, nil
+}
+
+func (c *Client) indexMemosBatch(ctx context.Context, memos []*MemoDocument) ([]BatchIndexResult, error) {
+	items := make([]IndexMemoRequest, 0, len(memos))
+	for _, memo := range memos {
+		items = append(items, IndexMemoRequest{
+			Memo:      memo,
+			Operation: "upsert",
+		})
+	}
+	reqBody, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.endpoint("/internal/index/memos/batch"),
+		bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.send("index_batch", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	// 207 Multi-Status is used when some memos of the batch failed.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusMultiStatus {
+		return nil, newStatusError(resp, body)
+	}
+
+	var result BatchIndexResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	return result.Results, nil
+}
+
 // DeleteMemoIndex deletes the index of a memo.
 func (c *Client) DeleteMemoIndex(ctx context.Context, memoUID string) error {
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete,
-		fmt.Sprintf("%s/internal/index/memo/%s", c.baseURL, memoUID),
+		c.endpoint(fmt.Sprintf("/internal/index/memo/%s", memoUID)),
 		nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send("delete_index", httpReq)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("AI service returned status %d: %s", resp.StatusCode, string(body))
+		return newStatusError(resp, body)
+	}
+
+	return nil
+}
+
+// DeleteCreatorIndex deletes the indexes of all memos of a creator.
+// It is idempotent: a creator without an index is not an error.
+// The creator, e.g. "users/1", is escaped as a single path segment.
+func (c *Client) DeleteCreatorIndex(ctx context.Context, creator string) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		c.endpoint(fmt.Sprintf("/internal/index/creator/%s", url.PathEscape(creator))),
+		nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("delete_creator_index", httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
+	}
+
+	return nil
+}
+
+// DeleteMemoChunk deletes a single indexed chunk of a memo by its document ID.
+func (c *Client) DeleteMemoChunk(ctx context.Context, memoUID, docID string) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		c.endpoint(fmt.Sprintf("/internal/index/chunks/%s/%s", memoUID, docID)),
+		nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("delete_chunk", httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
 	}
 
 	return nil
@@ -218,11 +766,16 @@ type MemoIndexInfo struct {
 	TextVectors  int              `json:"text_vectors"`
 	ImageVectors int              `json:"image_vectors"`
 	Detail       *MemoIndexDetail `json:"detail,omitempty"`
+	// ContentHash is the IndexMemoRequest.ContentHash the memo was last indexed with, empty if the
+	// service doesn't report it.
+	ContentHash string `json:"content_hash,omitempty"`
+	// IndexedAt is when the memo was last indexed, zero if the service doesn't report it.
+	IndexedAt time.Time `json:"indexed_at"`
 }
 
 // GetMemoIndexInfo gets the index info of a memo.
 func (c *Client) GetMemoIndexInfo(ctx context.Context, memoName string, includeDetail bool) (*MemoIndexInfo, error) {
-	url := fmt.Sprintf("%s/internal/index/memo/%s", c.baseURL, memoName)
+	url := c.endpoint(fmt.Sprintf("/internal/index/memo/%s", memoName))
 	if includeDetail {
 		url += "?include_detail=true"
 	}
@@ -232,9 +785,9 @@ func (c *Client) GetMemoIndexInfo(ctx context.Context, memoName string, includeD
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send("get_index_info", httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -247,29 +800,89 @@ func (c *Client) GetMemoIndexInfo(ctx context.Context, memoName string, includeD
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI service returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newStatusError(resp, body)
 	}
 
 	var result MemoIndexInfo
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
 	}
 	result.Indexed = true
 
 	return &result, nil
 }
 
+// Search modes accepted by SearchRequest.SearchMode, the names of the retrievers the AI service
+// registers, listed by its /internal/search/retrievers endpoint.
+const (
+	// SearchModeHybrid merges text and image vector search results. It is the default.
+	SearchModeHybrid = "hybrid"
+	// SearchModeVector ranks text and image chunks together by vector similarity.
+	SearchModeVector = "vector"
+	// SearchModeText searches memo text only.
+	SearchModeText = "text"
+	// SearchModeImage searches memo images only.
+	SearchModeImage = "image"
+	// SearchModeRRF fuses text and image vector search with reciprocal rank fusion.
+	SearchModeRRF = "rrf"
+	// SearchModeWeighted fuses text and image vector search with weighted scores.
+	SearchModeWeighted = "weighted"
+	// SearchModeBM25 ranks memos by BM25 keyword matches only.
+	SearchModeBM25 = "bm25"
+	// SearchModeBM25Vector fuses BM25 and vector search with reciprocal rank fusion.
+	SearchModeBM25Vector = "bm25_vector"
+	// SearchModeBM25VectorAlpha fuses BM25 and vector search with alpha weighted scores.
+	SearchModeBM25VectorAlpha = "bm25_vector_alpha"
+	// SearchModeAdaptive fuses BM25 and vector search with weights adapted to the query.
+	SearchModeAdaptive = "adaptive"
+)
+
+// SearchModes lists the search modes accepted by SearchRequest.SearchMode, the default first.
+var SearchModes = []string{
+	SearchModeHybrid,
+	SearchModeVector,
+	SearchModeText,
+	SearchModeImage,
+	SearchModeRRF,
+	SearchModeWeighted,
+	SearchModeBM25,
+	SearchModeBM25Vector,
+	SearchModeBM25VectorAlpha,
+	SearchModeAdaptive,
+}
+
+// IsValidSearchMode reports whether mode is one of SearchModes.
+func IsValidSearchMode(mode string) bool {
+	return slices.Contains(SearchModes, mode)
+}
+
 // SearchRequest is the request for AI search.
 type SearchRequest struct {
 	Query      string  `json:"query"`
 	TopK       int     `json:"top_k"`
 	SearchMode string  `json:"search_mode"`
 	MinScore   float32 `json:"min_score"`
-	Creator    string  `json:"creator"`
+	// Creator limits the search to the memos of a user, e.g. "users/1". Empty searches all users.
+	Creator string `json:"creator"`
+	// Visibility limits the search to memos with the visibility, e.g. "PUBLIC". Empty searches all visibilities.
+	Visibility string `json:"visibility,omitempty"`
+	// Offset skips the first results of the ranked list, used for pagination.
+	Offset int `json:"offset,omitempty"`
+	// Model overrides the embedding model for this request. Empty uses the service default.
+	Model string `json:"model,omitempty"`
+	// Explain asks the service to report the ScoreBreakdown of each result.
+	Explain bool `json:"explain,omitempty"`
+	// MustContain lists literal phrases every result must contain.
+	MustContain []string `json:"must_contain,omitempty"`
+	// Tags lists tags every result must carry.
+	Tags []string `json:"tags,omitempty"`
+	// CreatedAfter and CreatedBefore limit results to memos created in [CreatedAfter, CreatedBefore).
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
 }
 
 // SearchResult is a single search result.
@@ -278,6 +891,24 @@ type SearchResult struct {
 	MemoName  string  `json:"memo_name"`
 	Score     float32 `json:"score"`
 	MatchType string  `json:"match_type"`
+	// MatchedText is the chunk text that matched the query, if reported.
+	MatchedText string `json:"matched_text,omitempty"`
+	// IndexType is the index the memo matched in, "text", "image" or "both", if reported.
+	IndexType string `json:"index_type,omitempty"`
+	// MatchedChunkID is the doc ID of the chunk that matched the query, if reported.
+	MatchedChunkID string `json:"matched_chunk_id,omitempty"`
+	// ScoreBreakdown is how Score was computed, if SearchRequest.Explain was set and the service reports it.
+	ScoreBreakdown *ScoreBreakdown `json:"score_breakdown,omitempty"`
+}
+
+// ScoreBreakdown lists the components the score of a search result combines. Components that
+// didn't contribute to the score are 0.
+type ScoreBreakdown struct {
+	TextScore    float32 `json:"text_score"`
+	ImageScore   float32 `json:"image_score"`
+	KeywordScore float32 `json:"keyword_score"`
+	// FinalScore is the combined score the results are ranked by.
+	FinalScore float32 `json:"final_score"`
 }
 
 // SearchResponse is the response from AI search.
@@ -286,19 +917,15 @@ type SearchResponse struct {
 	Query        string         `json:"query"`
 	SearchMode   string         `json:"search_mode"`
 	TotalResults int            `json:"total_results"`
+	// MustContainApplied reports whether the service enforced SearchRequest.MustContain itself.
+	MustContainApplied bool `json:"must_contain_applied,omitempty"`
+	// TagsApplied reports whether the service enforced SearchRequest.Tags itself.
+	TagsApplied bool `json:"tags_applied,omitempty"`
 }
 
 // Search performs AI semantic search.
 func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
-	if req.TopK == 0 {
-		req.TopK = 10
-	}
-	if req.SearchMode == "" {
-		req.SearchMode = "hybrid"
-	}
-	if req.MinScore == 0 {
-		req.MinScore = 0.5
-	}
+	setSearchDefaults(req)
 
 	reqBody, err := json.Marshal(req)
 	if err != nil {
@@ -306,7 +933,7 @@ func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchRespons
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		fmt.Sprintf("%s/internal/search", c.baseURL),
+		c.endpoint("/internal/search"),
 		bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -314,24 +941,132 @@ func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchRespons
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send("search", httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI service returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newStatusError(resp, body)
 	}
 
 	var result SearchResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	return &result, nil
+}
+
+// SearchStream performs AI semantic search and calls fn for each result as the AI service streams it.
+// The service responds with newline-delimited JSON, one SearchResult per line.
+// Streaming stops at the first error returned by fn, which is then returned as is.
+func (c *Client) SearchStream(ctx context.Context, req *SearchRequest, fn func(*SearchResult) error) error {
+	setSearchDefaults(req)
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.endpoint("/internal/search/stream"),
+		bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/x-ndjson")
+
+	// A large result set may take longer than the client timeout to stream, so the stream is
+	// only bounded by ctx and the "search_stream" operation timeout, if any.
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	resp, err := c.sendWith(&httpClient, "search_stream", httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		// Results may already be buffered, so cancellation is checked before each one.
+		if err := ctx.Err(); err != nil {
+			return withRequestID(resp, err)
+		}
+		var result SearchResult
+		if err := decoder.Decode(&result); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return withRequestID(resp, fmt.Errorf("failed to read response: %w", ctx.Err()))
+			}
+			return withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+		}
+		if err := fn(&result); err != nil {
+			return err
+		}
+	}
+}
+
+func setSearchDefaults(req *SearchRequest) {
+	if req.TopK == 0 {
+		req.TopK = 10
+	}
+	if req.SearchMode == "" {
+		req.SearchMode = SearchModeHybrid
+	}
+	if req.MinScore == 0 {
+		req.MinScore = 0.5
+	}
+}
+
+// SimilarResponse is the response of finding memos similar to a memo.
+type SimilarResponse struct {
+	MemoUID string         `json:"memo_uid"`
+	Results []SearchResult `json:"results"`
+}
+
+// FindSimilar finds the memos most similar to the given memo by vector similarity.
+func (c *Client) FindSimilar(ctx context.Context, memoUID string, topK int) (*SimilarResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.endpoint(fmt.Sprintf("/internal/similar/%s?top_k=%d", memoUID, topK)),
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("find_similar", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp, body)
+	}
+
+	var result SimilarResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
 	}
 
 	return &result, nil
@@ -340,6 +1075,12 @@ func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchRespons
 // RebuildIndexRequest is the request to rebuild index.
 type RebuildIndexRequest struct {
 	Creator string `json:"creator"`
+	// ExcludePublic asks the AI service to skip public memos when enumerating the creator's memos.
+	ExcludePublic bool `json:"exclude_public,omitempty"`
+	// CallbackURL is where the AI service POSTs a RebuildCallback once the rebuild finishes, if set.
+	CallbackURL string `json:"callback_url,omitempty"`
+	// AllowInternalCallback lets the AI service POST the callback to loopback and private addresses.
+	AllowInternalCallback bool `json:"allow_internal_callback,omitempty"`
 }
 
 // RebuildIndexResponse is the response from rebuild index.
@@ -351,14 +1092,14 @@ type RebuildIndexResponse struct {
 }
 
 // RebuildIndex starts rebuilding all indexes for a user.
-func (c *Client) RebuildIndex(ctx context.Context, creator string) (*RebuildIndexResponse, error) {
-	reqBody, err := json.Marshal(&RebuildIndexRequest{Creator: creator})
+func (c *Client) RebuildIndex(ctx context.Context, req *RebuildIndexRequest) (*RebuildIndexResponse, error) {
+	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		fmt.Sprintf("%s/internal/index/rebuild", c.baseURL),
+		c.endpoint("/internal/index/rebuild"),
 		bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -366,29 +1107,49 @@ func (c *Client) RebuildIndex(ctx context.Context, creator string) (*RebuildInde
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send("rebuild_index", httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI service returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newStatusError(resp, body)
 	}
 
 	var result RebuildIndexResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
 	}
 
 	return &result, nil
 }
 
+// Statuses of a rebuild task.
+const (
+	RebuildStatusCompleted = "completed"
+	RebuildStatusFailed    = "failed"
+	RebuildStatusCancelled = "cancelled"
+	// RebuildStatusNotFound is reported when no rebuild task is registered for the creator (yet).
+	RebuildStatusNotFound = "not_found"
+)
+
+// DefaultRebuildPollInterval is the interval WaitForRebuild polls at when none is given.
+const DefaultRebuildPollInterval = 2 * time.Second
+
+// DefaultRebuildNotFoundGracePeriod is how long WaitForRebuild polls a rebuild task that isn't
+// registered, unless set with WithRebuildNotFoundGracePeriod.
+const DefaultRebuildNotFoundGracePeriod = 30 * time.Second
+
+// ErrRebuildNotFound is returned by WaitForRebuild when no rebuild task is registered for the creator
+// within the grace period, e.g. because the AI service accepted the rebuild but never started the task.
+var ErrRebuildNotFound = errors.New("rebuild task not found")
+
 // RebuildTaskStatus is the status of a rebuild task.
 type RebuildTaskStatus struct {
 	Status     string `json:"status"`
@@ -398,20 +1159,36 @@ type RebuildTaskStatus struct {
 	Completed  int    `json:"completed"`
 	Failed     int    `json:"failed"`
 	Error      string `json:"error,omitempty"`
+	// FailedMemos lists the memos that failed to index, if reported.
+	FailedMemos []FailedMemo `json:"failed_memos,omitempty"`
 }
 
-// GetRebuildStatus gets the status of a rebuild task.
+// RebuildCallback is the JSON body the AI service POSTs to the callback URL of a rebuild once it's
+// completed, failed or cancelled. It's the final status of the task along with its creator.
+type RebuildCallback struct {
+	Creator string `json:"creator"`
+	RebuildTaskStatus
+}
+
+// FailedMemo is a memo that failed to index during a rebuild.
+type FailedMemo struct {
+	MemoUID string `json:"memo_uid"`
+	Error   string `json:"error"`
+}
+
+// GetRebuildStatus gets the status of the rebuild task of a creator, e.g. "users/1",
+// which is escaped as a single path segment.
 func (c *Client) GetRebuildStatus(ctx context.Context, creator string) (*RebuildTaskStatus, error) {
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%s/internal/index/rebuild/%s", c.baseURL, creator),
+		c.endpoint(fmt.Sprintf("/internal/index/rebuild/%s", url.PathEscape(creator))),
 		nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send("get_rebuild_status", httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -421,31 +1198,286 @@ func (c *Client) GetRebuildStatus(ctx context.Context, creator string) (*Rebuild
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI service returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newStatusError(resp, body)
 	}
 
 	var result RebuildTaskStatus
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	return &result, nil
+}
+
+// CancelRebuild cancels the running rebuild task of a creator, e.g. "users/1", which is escaped as
+// a single path segment. The task then reports RebuildStatusCancelled. It is idempotent: a task that
+// has finished or doesn't exist is not an error.
+func (c *Client) CancelRebuild(ctx context.Context, creator string) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		c.endpoint(fmt.Sprintf("/internal/index/rebuild/%s", url.PathEscape(creator))),
+		nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("cancel_rebuild", httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
+	}
+
+	return nil
+}
+
+// WaitForRebuild polls the status of the rebuild task of a creator every pollInterval until it has
+// completed, failed or been cancelled, and returns the final status. A task that is not registered yet is polled
+// like a running one, for up to the not found grace period, after which ErrRebuildNotFound is returned.
+// If ctx is done first, the last status seen is returned with the context error.
+func (c *Client) WaitForRebuild(ctx context.Context, creator string, pollInterval time.Duration) (*RebuildTaskStatus, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultRebuildPollInterval
+	}
+	gracePeriod := c.rebuildNotFoundGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultRebuildNotFoundGracePeriod
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var last *RebuildTaskStatus
+	// notFoundSince is when the task was first seen unregistered, zero while it is registered.
+	var notFoundSince time.Time
+	for {
+		// The ticker may fire together with ctx, so cancellation is checked before each poll.
+		if err := ctx.Err(); err != nil {
+			return last, err
+		}
+		status, err := c.GetRebuildStatus(ctx, creator)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return last, ctxErr
+			}
+			return nil, err
+		}
+		if status == nil || status.Status == RebuildStatusNotFound {
+			if notFoundSince.IsZero() {
+				notFoundSince = time.Now()
+			} else if time.Since(notFoundSince) >= gracePeriod {
+				return last, fmt.Errorf("%w for %s after %s", ErrRebuildNotFound, creator, gracePeriod)
+			}
+		} else {
+			notFoundSince = time.Time{}
+			last = status
+			if status.Status == RebuildStatusCompleted || status.Status == RebuildStatusFailed || status.Status == RebuildStatusCancelled {
+				return status, nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return last, ctx.Err()
+		}
+	}
+}
+
+// IndexedMemo is the content hash of a memo in the AI index.
+type IndexedMemo struct {
+	MemoUID     string `json:"memo_uid"`
+	ContentHash string `json:"content_hash"`
+}
+
+// IndexChecksumResponse is the checksum of a creator's indexed memos.
+type IndexChecksumResponse struct {
+	Creator  string        `json:"creator"`
+	Checksum string        `json:"checksum"`
+	Memos    []IndexedMemo `json:"memos"`
+}
+
+// GetIndexChecksum gets the checksum of the memos indexed for a creator, as computed by IndexChecksum.
+// The creator, e.g. "users/1", is escaped as a single path segment.
+func (c *Client) GetIndexChecksum(ctx context.Context, creator string) (*IndexChecksumResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.endpoint(fmt.Sprintf("/internal/index/checksum/%s", url.PathEscape(creator))),
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("get_index_checksum", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp, body)
+	}
+
+	var result IndexChecksumResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
 	}
 
 	return &result, nil
 }
 
+// listIndexedMemosResponse is a page of the memos indexed for a creator.
+type listIndexedMemosResponse struct {
+	MemoUIDs      []string `json:"memo_uids"`
+	NextPageToken string   `json:"next_page_token"`
+}
+
+// ListIndexedMemos lists the UIDs of the memos indexed for a creator, one page at a time. An empty
+// pageToken gets the first page, and an empty nextToken is returned with the last one.
+// The creator, e.g. "users/1", is escaped as a single path segment.
+func (c *Client) ListIndexedMemos(ctx context.Context, creator string, pageToken string) ([]string, string, error) {
+	endpoint := c.endpoint(fmt.Sprintf("/internal/index/creator/%s/memos", url.PathEscape(creator)))
+	if pageToken != "" {
+		endpoint += "?" + url.Values{"page_token": {pageToken}}.Encode()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("list_indexed_memos", httpReq)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", newStatusError(resp, body)
+	}
+
+	var result listIndexedMemosResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, "", withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	return result.MemoUIDs, result.NextPageToken, nil
+}
+
+// ExportIndex writes the index of a creator, as newline-delimited JSON of the vectors and metadata
+// of its chunks, to w. The export is streamed to w as the AI service sends it, so it's never held in
+// memory, and it's only bounded by ctx and the "export_index" operation timeout, if any.
+// If it fails midway, w has received a truncated export.
+// The creator, e.g. "users/1", is escaped as a single path segment.
+func (c *Client) ExportIndex(ctx context.Context, creator string, w io.Writer) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.endpoint(fmt.Sprintf("/internal/index/creator/%s/export", url.PathEscape(creator))),
+		nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Accept", "application/x-ndjson")
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	resp, err := c.sendWith(&httpClient, "export_index", httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		if ctx.Err() != nil {
+			return withRequestID(resp, fmt.Errorf("failed to read response: %w", ctx.Err()))
+		}
+		return withRequestID(resp, fmt.Errorf("failed to copy export: %w", err))
+	}
+	return nil
+}
+
+// ImportIndex loads an export of ExportIndex read from r into the index of a creator. The export is
+// streamed to the AI service as it's read from r, so it's sent once, without failover or compression,
+// and it's only bounded by ctx and the "import_index" operation timeout, if any.
+// The creator, e.g. "users/1", is escaped as a single path segment.
+func (c *Client) ImportIndex(ctx context.Context, creator string, r io.Reader) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.endpoint(fmt.Sprintf("/internal/index/creator/%s/import", url.PathEscape(creator))),
+		io.NopCloser(r))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/x-ndjson")
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	resp, err := c.sendWith(&httpClient, "import_index", httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
+	}
+
+	return nil
+}
+
+// ContentHash returns the hex SHA-256 of a memo's content, as reported in IndexedMemo.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// IndexChecksum returns the checksum of a set of memos keyed by UID with their content hashes.
+// It is the hex SHA-256 of one "{uid}:{content_hash}\n" line per memo, sorted by UID.
+func IndexChecksum(contentHashes map[string]string) string {
+	uids := make([]string, 0, len(contentHashes))
+	for uid := range contentHashes {
+		uids = append(uids, uid)
+	}
+	slices.Sort(uids)
+
+	hash := sha256.New()
+	for _, uid := range uids {
+		fmt.Fprintf(hash, "%s:%s\n", uid, contentHashes[uid])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 // HealthCheck checks if the AI service is healthy.
+// It bypasses the circuit breaker, so it can probe the service for recovery.
 func (c *Client) HealthCheck(ctx context.Context) (bool, error) {
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%s/health", c.baseURL),
+		c.endpoint("/health"),
 		nil)
 	if err != nil {
 		return false, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.probe("health_check", httpReq)
 	if err != nil {
 		return false, nil // Service is not reachable
 	}
@@ -453,3 +1485,142 @@ func (c *Client) HealthCheck(ctx context.Context) (bool, error) {
 
 	return resp.StatusCode == http.StatusOK, nil
 }
+
+// HealthStatus is the detailed status reported by the AI service's health endpoint.
+type HealthStatus struct {
+	// Healthy reports whether the health endpoint responded with 200 OK.
+	Healthy        bool   `json:"-"`
+	Status         string `json:"status"`
+	Version        string `json:"version"`
+	EmbeddingModel string `json:"embedding_model"`
+	// VectorStoreOK reports whether the index backend is reachable, or is nil if not reported.
+	VectorStoreOK *bool `json:"vector_store_ok"`
+}
+
+// HealthCheckDetailed gets the detailed status of the AI service.
+// A health endpoint without a JSON body only reports Healthy.
+// Like HealthCheck, it bypasses the circuit breaker.
+func (c *Client) HealthCheckDetailed(ctx context.Context) (*HealthStatus, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.endpoint("/health"),
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.probe("health_check_detailed", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	var result HealthStatus
+	if err := json.Unmarshal(body, &result); err != nil {
+		result = HealthStatus{}
+	}
+	result.Healthy = resp.StatusCode == http.StatusOK
+	return &result, nil
+}
+
+// ServiceConfig describes what the AI service supports.
+type ServiceConfig struct {
+	// SearchModes are the search modes the AI service accepts. Nil if not reported.
+	SearchModes []string `json:"search_modes"`
+	// ImageIndexing reports whether images of memos are indexed for image search.
+	ImageIndexing bool `json:"image_indexing"`
+	// MaxTags is the number of tags generated for a memo by default.
+	MaxTags int `json:"max_tags"`
+	Models  struct {
+		TagGeneration  string `json:"tag_generation"`
+		TextEmbedding  string `json:"text_embedding"`
+		ImageEmbedding string `json:"image_embedding"`
+		ImageCaption   string `json:"image_caption"`
+	} `json:"models"`
+}
+
+// GetConfig gets what the AI service supports.
+func (c *Client) GetConfig(ctx context.Context) (*ServiceConfig, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.endpoint("/api/v1/config"),
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("get_config", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp, body)
+	}
+
+	var result ServiceConfig
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	return &result, nil
+}
+
+// Capabilities describes the models advertised by the AI service.
+type Capabilities struct {
+	Models struct {
+		TagGeneration []string `json:"tag_generation"`
+		Embedding     []string `json:"embedding"`
+	} `json:"models"`
+}
+
+// SupportsTagGenerationModel reports whether the model is advertised for tag generation.
+func (c *Capabilities) SupportsTagGenerationModel(model string) bool {
+	return slices.Contains(c.Models.TagGeneration, model)
+}
+
+// SupportsEmbeddingModel reports whether the model is advertised for embedding.
+func (c *Capabilities) SupportsEmbeddingModel(model string) bool {
+	return slices.Contains(c.Models.Embedding, model)
+}
+
+// GetCapabilities gets the models advertised by the AI service's health endpoint.
+func (c *Client) GetCapabilities(ctx context.Context) (*Capabilities, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.endpoint("/health"),
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.send("get_capabilities", httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp, body)
+	}
+
+	var result Capabilities
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, withRequestID(resp, fmt.Errorf("failed to unmarshal response: %w", err))
+	}
+
+	return &result, nil
+}
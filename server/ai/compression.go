@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressionThreshold is the request body size above which bodies are gzip-compressed.
+const compressionThreshold = 16 << 10
+
+// WithCompression sets whether request bodies above 16 KB are gzip-compressed and gzip responses
+// are accepted. It is enabled by default; disabling it keeps traffic readable for debugging.
+func WithCompression(enabled bool) Option {
+	return func(c *Client) {
+		c.compression = enabled
+	}
+}
+
+// compressRequest negotiates response compression for httpReq and gzips its body if it is large enough.
+func (c *Client) compressRequest(httpReq *http.Request) error {
+	if !c.compression {
+		// Setting the header stops the transport from negotiating gzip on its own.
+		httpReq.Header.Set("Accept-Encoding", "identity")
+		return nil
+	}
+	httpReq.Header.Set("Accept-Encoding", "gzip")
+	if httpReq.GetBody == nil || httpReq.ContentLength <= compressionThreshold {
+		return nil
+	}
+
+	body, err := httpReq.GetBody()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := io.Copy(writer, body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	compressed := buf.Bytes()
+	httpReq.Body = io.NopCloser(bytes.NewReader(compressed))
+	httpReq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	httpReq.ContentLength = int64(len(compressed))
+	httpReq.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
+// decompressResponse transparently decompresses resp if the AI service gzipped it.
+func decompressResponse(resp *http.Response) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	resp.Body = &gzipReadCloser{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipReadCloser decompresses body, reading the gzip header on the first Read
+// so that streamed responses aren't blocked until data arrives.
+type gzipReadCloser struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+func (r *gzipReadCloser) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.reader == nil {
+		r.reader, r.err = gzip.NewReader(r.body)
+		if r.err != nil {
+			return 0, r.err
+		}
+	}
+	return r.reader.Read(p)
+}
+
+func (r *gzipReadCloser) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,38 @@
+package ai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// withRequestTimeout returns a copy of httpReq whose context also expires after timeout, and a copy
+// of httpClient without a timeout. The effective timeout is thus the shorter of timeout and the
+// deadline of the request context, and both surface as context.DeadlineExceeded. A non-positive
+// timeout leaves only the context deadline. The returned cancel func must be called once the
+// response is done.
+func withRequestTimeout(httpClient *http.Client, httpReq *http.Request, timeout time.Duration) (*http.Client, *http.Request, context.CancelFunc) {
+	if httpClient.Timeout > 0 {
+		client := *httpClient
+		client.Timeout = 0
+		httpClient = &client
+	}
+	if timeout <= 0 {
+		return httpClient, httpReq, func() {}
+	}
+	ctx, cancel := context.WithTimeout(httpReq.Context(), timeout)
+	return httpClient, httpReq.WithContext(ctx), cancel
+}
+
+// cancelOnClose releases the context of a response when its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
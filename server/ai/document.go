@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+)
+
+// IndexMode is what of a memo is sent to the AI service for indexing.
+type IndexMode string
+
+const (
+	// IndexModeBoth sends the memo text and its attachments. It is the default.
+	IndexModeBoth IndexMode = "both"
+	// IndexModeTextOnly sends the memo text and the text extracted from attachments, but no
+	// attachment data, for AI services without an image model.
+	IndexModeTextOnly IndexMode = "text_only"
+	// IndexModeImagesOnly sends only the data of image attachments, without the memo text.
+	IndexModeImagesOnly IndexMode = "images_only"
+)
+
+// IndexModes lists the valid index modes.
+var IndexModes = []IndexMode{IndexModeBoth, IndexModeTextOnly, IndexModeImagesOnly}
+
+// IsValidIndexMode reports whether mode is one of IndexModes.
+func IsValidIndexMode(mode string) bool {
+	return slices.Contains(IndexModes, IndexMode(mode))
+}
+
+// MemoDocument is a memo in the form the AI service indexes it.
+// Fields are declared in the sorted order of their JSON keys, so documents serialize the same
+// way as the AI service's schema lists them.
+type MemoDocument struct {
+	// AiTags are the AI generated tags of the memo. Never null.
+	AiTags []string `json:"aiTags"`
+	// Attachments are the attachments of the memo. Never null.
+	Attachments []AttachmentDocument `json:"attachments"`
+	// Content is the memo content, possibly truncated.
+	Content string `json:"content"`
+	// CreateTime, DisplayTime and UpdateTime are RFC 3339 timestamps.
+	CreateTime string `json:"createTime"`
+	// Creator is the resource name of the memo creator, e.g. "users/1".
+	Creator     string `json:"creator"`
+	DisplayTime string `json:"displayTime"`
+	// Name is the resource name of the memo, e.g. "memos/abc".
+	Name string `json:"name"`
+	// Tags are the manual tags of the memo. Never null.
+	Tags       []string `json:"tags"`
+	UID        string   `json:"uid"`
+	UpdateTime string   `json:"updateTime"`
+	// Visibility is the memo visibility, e.g. "PUBLIC".
+	Visibility string `json:"visibility"`
+}
+
+// AttachmentDocument is an attachment of a MemoDocument.
+type AttachmentDocument struct {
+	// ExternalLink is where the AI service fetches the attachment from, either a URL or a base64
+	// data URL. Empty if the attachment is sent with metadata only.
+	ExternalLink string `json:"externalLink,omitempty"`
+	// ExtractedText is text already extracted from the attachment, if any.
+	ExtractedText string `json:"extractedText,omitempty"`
+	Filename      string `json:"filename"`
+	// Name is the attachment uid.
+	Name string `json:"name"`
+	// Type is the MIME type of the attachment.
+	Type string `json:"type"`
+}
+
+// HashMemoDocument returns the hash of memo. It covers everything sent to the AI index, including
+// the update time, so it changes whenever the memo does.
+func HashMemoDocument(memo *MemoDocument) string {
+	data, err := json.Marshal(memo)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
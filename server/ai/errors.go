@@ -0,0 +1,165 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnavailable is returned when the AI service can't be reached.
+var ErrUnavailable = errors.New("AI service unavailable")
+
+// maxErrorBodyLength is the max number of characters of a non-JSON error body kept in a StatusError.
+const maxErrorBodyLength = 256
+
+// StatusError is returned when the AI service responds with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	// Body is the response body. Bodies that aren't JSON, e.g. the HTML error page of a proxy,
+	// are collapsed to a single line and cut to maxErrorBodyLength characters.
+	Body string
+	// Message is the "error" or "detail" message of a JSON body, if any.
+	Message string
+	// unavailable is set for a 5xx response that isn't JSON, which comes from a proxy in front of
+	// the AI service rather than from the service itself.
+	unavailable bool
+}
+
+func (e *StatusError) Error() string {
+	message := e.Body
+	if e.Message != "" {
+		message = e.Message
+	}
+	return fmt.Sprintf("AI service returned status %d: %s", e.StatusCode, message)
+}
+
+// Is reports a non-JSON 5xx response as ErrUnavailable.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrUnavailable && e.unavailable
+}
+
+// ErrRateLimited is returned when the AI service responds with 429 Too Many Requests, e.g. because
+// the model provider behind it rate-limited the request. It wraps the StatusError of the response.
+type ErrRateLimited struct {
+	// RetryAfter is how long to wait before retrying, from the Retry-After header of the response.
+	// Zero if the header is missing or invalid.
+	RetryAfter time.Duration
+	Err        *StatusError
+}
+
+func (e *ErrRateLimited) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v (retry after %s)", e.Err, e.RetryAfter)
+	}
+	return e.Err.Error()
+}
+
+func (e *ErrRateLimited) Unwrap() error {
+	return e.Err
+}
+
+func newStatusError(resp *http.Response, body []byte) error {
+	statusErr := &StatusError{StatusCode: resp.StatusCode}
+	if isJSONResponse(resp, body) {
+		statusErr.Body = string(body)
+		statusErr.Message = jsonErrorMessage(body)
+	} else {
+		statusErr.Body = truncateErrorBody(string(body))
+		statusErr.unavailable = resp.StatusCode >= http.StatusInternalServerError
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return withRequestID(resp, &ErrRateLimited{
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Err:        statusErr,
+		})
+	}
+	return withRequestID(resp, statusErr)
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or an HTTP date, into the
+// duration to wait from now. It returns zero for a missing or invalid header, or a date in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	date, err := http.ParseTime(value)
+	if err != nil || !date.After(now) {
+		return 0
+	}
+	// Rounded up to the precision of HTTP dates, so the wait isn't cut short.
+	return (date.Sub(now) + time.Second - 1).Truncate(time.Second)
+}
+
+// isJSONResponse reports whether resp has a JSON content type or, lacking one, body is valid JSON.
+func isJSONResponse(resp *http.Response, body []byte) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return true
+	}
+	return json.Valid(body)
+}
+
+// jsonErrorMessage returns the "error" or "detail" string of a JSON error body, or an empty string.
+func jsonErrorMessage(body []byte) string {
+	var payload struct {
+		Error  interface{} `json:"error"`
+		Detail interface{} `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	for _, message := range []interface{}{payload.Error, payload.Detail} {
+		if message, ok := message.(string); ok && message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+// truncateErrorBody collapses the whitespace of body and cuts it to maxErrorBodyLength characters.
+func truncateErrorBody(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	runes := []rune(body)
+	if len(runes) <= maxErrorBodyLength {
+		return body
+	}
+	return string(runes[:maxErrorBodyLength]) + "..."
+}
+
+// RequestError annotates an error of a request sent to the AI service with its X-Request-ID,
+// so the failure can be found in the AI service logs.
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%v (request id: %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// withRequestID annotates err with the request ID of the request resp answers.
+func withRequestID(resp *http.Response, err error) error {
+	if resp.Request == nil {
+		return err
+	}
+	return &RequestError{
+		RequestID: resp.Request.Header.Get(RequestIDHeader),
+		Err:       err,
+	}
+}
@@ -0,0 +1,86 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WithFallbackURLs sets AI service URLs to fail over to when a request can't reach the service
+// or gets a 5xx response. Empty URLs are ignored.
+func WithFallbackURLs(urls ...string) Option {
+	return func(c *Client) {
+		for _, u := range urls {
+			if u != "" {
+				c.fallbackURLs = append(c.fallbackURLs, u)
+			}
+		}
+	}
+}
+
+// doFailover sends httpReq with do to the base URL that last worked, then to the other base URLs
+// in order, until one responds without a transport error or 5xx status. 4xx responses and requests
+// canceled by the caller are returned as is.
+func (c *Client) doFailover(httpClient *http.Client, operation string, httpReq *http.Request) (*http.Response, error) {
+	// A request without a replayable body can only be sent once.
+	replayable := httpReq.Body == nil || httpReq.Body == http.NoBody || httpReq.GetBody != nil
+	if len(c.fallbackURLs) == 0 || !replayable || !strings.HasPrefix(httpReq.URL.String(), c.baseURL) {
+		return c.do(httpClient, operation, httpReq)
+	}
+
+	baseURLs := append([]string{c.baseURL}, c.fallbackURLs...)
+	start := int(c.lastGoodURL.Load())
+	var (
+		resp *http.Response
+		err  error
+	)
+	for i := range baseURLs {
+		index := (start + i) % len(baseURLs)
+		attempt, rebaseErr := rebaseRequest(httpReq, c.baseURL, baseURLs[index])
+		if rebaseErr != nil {
+			return nil, rebaseErr
+		}
+		resp, err = c.do(httpClient, operation, attempt)
+		if !shouldFailover(httpReq.Context(), resp, err) {
+			if err == nil {
+				c.lastGoodURL.Store(int32(index))
+			}
+			return resp, err
+		}
+		if resp != nil && i < len(baseURLs)-1 {
+			resp.Body.Close()
+		}
+	}
+	return resp, err
+}
+
+// shouldFailover reports whether a request sent with ctx that returned resp and err should be retried
+// against another base URL.
+func shouldFailover(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// rebaseRequest returns a copy of httpReq, built for the base URL from, that is sent to the base URL to.
+func rebaseRequest(httpReq *http.Request, from, to string) (*http.Request, error) {
+	req := httpReq.Clone(httpReq.Context())
+	if to != from {
+		u, err := url.Parse(to + strings.TrimPrefix(httpReq.URL.String(), from))
+		if err != nil {
+			return nil, err
+		}
+		req.URL = u
+		req.Host = u.Host
+	}
+	if httpReq.GetBody != nil {
+		body, err := httpReq.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	return req, nil
+}
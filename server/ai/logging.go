@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger sets the logger requests to the AI service are logged to.
+// Nil, the default, logs to slog.Default(), so the level configured for the server applies.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// logRequest logs a request of operation sent at start that returned resp and err.
+// Successful requests are logged at debug level and failed ones at warn level.
+// Only the request line and metadata are logged, never the bodies, which may carry attachment data.
+func (c *Client) logRequest(ctx context.Context, operation string, httpReq *http.Request, requestID string, start time.Time, resp *http.Response, err error) {
+	logger := c.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	outcome := requestOutcome(resp, err)
+	level := slog.LevelDebug
+	if outcome != "ok" {
+		level = slog.LevelWarn
+	}
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", operation),
+		slog.String("method", httpReq.Method),
+		slog.String("url", httpReq.URL.Redacted()),
+		slog.String("request_id", requestID),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("outcome", outcome),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, level, "AI service request", attrs...)
+}
@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics records Prometheus metrics of AI service requests, labeled by operation and outcome.
+// A nil *Metrics records nothing.
+type Metrics struct {
+	duration *prometheus.HistogramVec
+	results  *prometheus.CounterVec
+}
+
+// NewMetrics creates the AI client metrics and registers them with registerer.
+// It returns nil if registerer is nil, so clients using it record nothing.
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	if registerer == nil {
+		return nil
+	}
+	m := &Metrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "memos",
+			Subsystem: "ai_client",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of AI service requests until the response headers are received.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "outcome"}),
+		results: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "memos",
+			Subsystem: "ai_client",
+			Name:      "requests_total",
+			Help:      "Number of AI service requests.",
+		}, []string{"operation", "outcome"}),
+	}
+	registerer.MustRegister(m.duration, m.results)
+	return m
+}
+
+// WithMetrics records the client's requests in metrics. A nil metrics records nothing.
+func WithMetrics(metrics *Metrics) Option {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
+// observe records a request of operation started at start that returned resp and err.
+func (m *Metrics) observe(operation string, start time.Time, resp *http.Response, err error) {
+	if m == nil {
+		return
+	}
+	outcome := requestOutcome(resp, err)
+	m.duration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+	m.results.WithLabelValues(operation, outcome).Inc()
+}
+
+// requestOutcome classifies a request as "ok", "error", or "timeout".
+func requestOutcome(resp *http.Response, err error) string {
+	if err != nil {
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return "timeout"
+		}
+		return "error"
+	}
+	if resp.StatusCode == http.StatusGatewayTimeout {
+		return "timeout"
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "error"
+	}
+	return "ok"
+}
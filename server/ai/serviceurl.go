@@ -0,0 +1,158 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The AI service URL is set by instance admins, and the server sends requests to it with memo
+// contents, so it's trusted to point at an AI service the operator runs. On instances with several
+// admins, or where the settings may be tampered with, it could instead be pointed at an internal
+// endpoint the server can reach but users can't, e.g. the metadata service of a cloud VM.
+//
+// ValidateServiceURL only rejects obviously dangerous targets. Loopback and private addresses are
+// allowed, since the AI service usually runs on the same host or network. Hostnames aren't resolved,
+// so a hostname resolving to a rejected address isn't caught. Operators who need a rejected URL,
+// e.g. an AI service on a link-local address, set AllowUnsafeServiceURLEnv, which admins can't
+// change from the instance settings.
+
+// AllowUnsafeServiceURLEnv is the env var that disables ValidateServiceURL in NewClient when set to
+// a true value, e.g. "true" or "1".
+const AllowUnsafeServiceURLEnv = "AI_SERVICE_ALLOW_UNSAFE_URL"
+
+// ErrUnsafeServiceURL is returned for requests of a client whose service URL fails ValidateServiceURL.
+var ErrUnsafeServiceURL = errors.New("unsafe AI service URL")
+
+// metadataHosts are hostnames of cloud metadata services.
+var metadataHosts = []string{
+	"metadata",
+	"metadata.google.internal",
+	"metadata.goog",
+}
+
+// metadataIPs are metadata service addresses outside the link-local ranges.
+var metadataIPs = []net.IP{
+	// AWS EC2 over IPv6.
+	net.ParseIP("fd00:ec2::254"),
+}
+
+// ValidateServiceURL checks that serviceURL is an http or https URL that doesn't point at a
+// link-local, unspecified or multicast address, or at a cloud metadata service.
+func ValidateServiceURL(serviceURL string) error {
+	_, host, err := parseHTTPURL(serviceURL)
+	if err != nil {
+		return err
+	}
+	return validateHost(host)
+}
+
+// ValidateCallbackURL checks that callbackURL is an http or https URL without credentials that the
+// AI service may POST to. Like ValidateServiceURL it rejects link-local, unspecified, multicast and
+// metadata service targets. Unless allowInternal, the host is also resolved and rejected if any of
+// its addresses is a loopback or private address. The AI service resolves the host again and checks
+// the address it connects to, since DNS may answer differently by the time the callback is sent.
+func ValidateCallbackURL(ctx context.Context, callbackURL string, allowInternal bool) error {
+	u, host, err := parseHTTPURL(callbackURL)
+	if err != nil {
+		return err
+	}
+	if u.User != nil {
+		return errors.New("credentials are not allowed")
+	}
+	if err := validateHost(host); err != nil {
+		return err
+	}
+	if allowInternal {
+		return nil
+	}
+
+	ips := []net.IP{}
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return fmt.Errorf("failed to resolve hostname: %w", err)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsPrivate() {
+			return fmt.Errorf("internal address %s is not allowed", ip)
+		}
+		if err := validateIP(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseHTTPURL parses rawURL and returns it with its lowercased hostname, without a trailing dot.
+func parseHTTPURL(rawURL string) (*url.URL, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", errors.New("invalid URL format")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, "", fmt.Errorf("scheme %q is not allowed, only http and https are", u.Scheme)
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return nil, "", errors.New("empty hostname")
+	}
+	return u, host, nil
+}
+
+// validateHost rejects metadata service hostnames and IP literals rejected by validateIP.
+func validateHost(host string) error {
+	for _, metadataHost := range metadataHosts {
+		if host == metadataHost {
+			return fmt.Errorf("metadata service host %s is not allowed", host)
+		}
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return validateIP(ip)
+	}
+	return nil
+}
+
+// validateIP rejects link-local, unspecified, multicast and metadata service addresses.
+func validateIP(ip net.IP) error {
+	if ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("address %s is not allowed", ip)
+	}
+	for _, metadataIP := range metadataIPs {
+		if ip.Equal(metadataIP) {
+			return fmt.Errorf("metadata service address %s is not allowed", ip)
+		}
+	}
+	return nil
+}
+
+// AllowUnsafeServiceURL reports whether AllowUnsafeServiceURLEnv is set to a true value.
+func AllowUnsafeServiceURL() bool {
+	allow, _ := strconv.ParseBool(os.Getenv(AllowUnsafeServiceURLEnv))
+	return allow
+}
+
+// validateServiceURLs checks the base and fallback URLs of c with ValidateServiceURL, unless
+// AllowUnsafeServiceURLEnv is set.
+func (c *Client) validateServiceURLs() error {
+	if AllowUnsafeServiceURL() {
+		return nil
+	}
+	for _, serviceURL := range append([]string{c.baseURL}, c.fallbackURLs...) {
+		if err := ValidateServiceURL(serviceURL); err != nil {
+			return fmt.Errorf("%w %s: %w", ErrUnsafeServiceURL, serviceURL, err)
+		}
+	}
+	return nil
+}
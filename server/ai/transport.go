@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TransportTuning configures the connection pool of the transport of a Client.
+type TransportTuning struct {
+	// MaxIdleConns is the max number of idle connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the max number of idle connections kept to each host.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept before it is closed.
+	IdleConnTimeout time.Duration
+}
+
+// DefaultTransportTuning is the connection pool used when WithTransportTuning isn't given.
+// It keeps enough idle connections per host to reuse them under sustained indexing,
+// whereas the default transport of net/http keeps only two.
+var DefaultTransportTuning = TransportTuning{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 32,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// WithTransportTuning overrides the connection pool of the internal HTTP client.
+// Zero fields keep the value of DefaultTransportTuning.
+// It has no effect together with WithHTTPClient, whose client brings its own transport.
+func WithTransportTuning(tuning TransportTuning) Option {
+	return func(c *Client) {
+		if tuning.MaxIdleConns > 0 {
+			c.transportTuning.MaxIdleConns = tuning.MaxIdleConns
+		}
+		if tuning.MaxIdleConnsPerHost > 0 {
+			c.transportTuning.MaxIdleConnsPerHost = tuning.MaxIdleConnsPerHost
+		}
+		if tuning.IdleConnTimeout > 0 {
+			c.transportTuning.IdleConnTimeout = tuning.IdleConnTimeout
+		}
+	}
+}
+
+// transports holds the transports created by sharedTransport by tuning.
+var transports sync.Map
+
+// sharedTransport returns the transport for tuning, shared by all clients with the same tuning.
+// Clients are usually created per request, so sharing the transport is what lets connections
+// be reused across requests.
+func sharedTransport(tuning TransportTuning) *http.Transport {
+	if transport, ok := transports.Load(tuning); ok {
+		return transport.(*http.Transport)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = tuning.MaxIdleConns
+	transport.MaxIdleConnsPerHost = tuning.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = tuning.IdleConnTimeout
+	actual, _ := transports.LoadOrStore(tuning, transport)
+	return actual.(*http.Transport)
+}
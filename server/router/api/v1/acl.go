@@ -104,6 +104,26 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 	return nil, status.Errorf(codes.Unauthenticated, "authentication required")
 }
 
+// AuthenticationStreamInterceptor is the stream interceptor for gRPC API.
+// It authenticates the same way as AuthenticationInterceptor and hands the authenticated context to the stream handler.
+func (in *GRPCAuthInterceptor) AuthenticationStreamInterceptor(srv any, stream grpc.ServerStream, serverInfo *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	unaryServerInfo := &grpc.UnaryServerInfo{Server: srv, FullMethod: serverInfo.FullMethod}
+	_, err := in.AuthenticationInterceptor(stream.Context(), nil, unaryServerInfo, func(ctx context.Context, _ any) (any, error) {
+		return nil, handler(srv, &authenticatedServerStream{ServerStream: stream, ctx: ctx})
+	})
+	return err
+}
+
+// authenticatedServerStream is a server stream carrying the authenticated context.
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
+
 // handleAuthenticatedRequest processes an authenticated request with the given user and auth info.
 func (in *GRPCAuthInterceptor) handleAuthenticatedRequest(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler, user *store.User, sessionID, accessToken string) (any, error) {
 	// Check user status
@@ -10,6 +10,7 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v1.UserService/GetUser":                           true,
 	"/memos.api.v1.UserService/GetUserAvatar":                     true,
 	"/memos.api.v1.UserService/GetUserStats":                      true,
+	"/memos.api.v1.UserService/ListUserTags":                      true,
 	"/memos.api.v1.UserService/ListAllUserStats":                  true,
 	"/memos.api.v1.UserService/SearchUsers":                       true,
 	"/memos.api.v1.MemoService/GetMemo":                           true,
@@ -25,6 +26,7 @@ func isUnauthorizeAllowedMethod(fullMethodName string) bool {
 var allowedMethodsOnlyForAdmin = map[string]bool{
 	"/memos.api.v1.UserService/CreateUser":                true,
 	"/memos.api.v1.InstanceService/UpdateInstanceSetting": true,
+	"/memos.api.v1.InstanceService/SanitizeDatabaseUTF8":  true,
 }
 
 // isOnlyForAdminAllowedMethod returns true if the method is allowed to be called only by admin.
@@ -44,7 +44,7 @@ func getPageToken(limit int, offset int) (string, error) {
 	})
 }
 
-func marshalPageToken(pageToken *v1pb.PageToken) (string, error) {
+func marshalPageToken(pageToken proto.Message) (string, error) {
 	b, err := proto.Marshal(pageToken)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to marshal page token")
@@ -52,7 +52,7 @@ func marshalPageToken(pageToken *v1pb.PageToken) (string, error) {
 	return base64.StdEncoding.EncodeToString(b), nil
 }
 
-func unmarshalPageToken(s string, pageToken *v1pb.PageToken) error {
+func unmarshalPageToken(s string, pageToken proto.Message) error {
 	b, err := base64.StdEncoding.DecodeString(s)
 	if err != nil {
 		return errors.Wrapf(err, "failed to decode page token")
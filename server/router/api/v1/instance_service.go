@@ -3,6 +3,8 @@ package v1
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strings"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -10,6 +12,7 @@ import (
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/ai"
 	"github.com/usememos/memos/store"
 )
 
@@ -96,6 +99,25 @@ func (s *APIV1Service) UpdateInstanceSetting(ctx context.Context, request *v1pb.
 	_ = request.UpdateMask
 
 	updateSetting := convertInstanceSettingToStore(request.Setting)
+	if aiSetting := updateSetting.GetAiSetting(); aiSetting != nil {
+		if aiSetting.AiServiceUrl != "" && !ai.AllowUnsafeServiceURL() {
+			if err := ai.ValidateServiceURL(aiSetting.AiServiceUrl); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid AI service url: %v", err)
+			}
+		}
+		if aiSetting.DefaultSearchTopK < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "default search top_k must not be negative")
+		}
+		if aiSetting.DefaultSearchMinScore < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "default search min_score must not be negative")
+		}
+		if aiSetting.DefaultSearchMode != "" && !ai.IsValidSearchMode(aiSetting.DefaultSearchMode) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid default search mode %q: must be one of %s", aiSetting.DefaultSearchMode, strings.Join(ai.SearchModes, ", "))
+		}
+		if aiSetting.IndexMode != "" && !ai.IsValidIndexMode(aiSetting.IndexMode) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid index mode %q: must be one of both, text_only, images_only", aiSetting.IndexMode)
+		}
+	}
 	instanceSetting, err := s.Store.UpsertInstanceSetting(ctx, updateSetting)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upsert instance setting: %v", err)
@@ -104,6 +126,36 @@ func (s *APIV1Service) UpdateInstanceSetting(ctx context.Context, request *v1pb.
 	return convertInstanceSettingFromStore(instanceSetting), nil
 }
 
+// SanitizeDatabaseUTF8 replaces invalid UTF-8 in the content and payload of all memos.
+func (s *APIV1Service) SanitizeDatabaseUTF8(ctx context.Context, request *v1pb.SanitizeDatabaseUTF8Request) (*v1pb.SanitizeDatabaseUTF8Response, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if user.Role != store.RoleHost {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	result, err := s.Store.SanitizeMemoUTF8(ctx, request.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sanitize memos: %v", err)
+	}
+	slog.Info("sanitized memo UTF-8",
+		slog.Bool("dryRun", request.DryRun),
+		slog.Int("scanned", result.Scanned),
+		slog.Int("invalid", result.Invalid),
+		slog.Int("fixed", result.Fixed))
+
+	return &v1pb.SanitizeDatabaseUTF8Response{
+		ScannedCount: int32(result.Scanned),
+		InvalidCount: int32(result.Invalid),
+		FixedCount:   int32(result.Fixed),
+	}, nil
+}
+
 func convertInstanceSettingFromStore(setting *storepb.InstanceSetting) *v1pb.InstanceSetting {
 	instanceSetting := &v1pb.InstanceSetting{
 		Name: fmt.Sprintf("instance/settings/%s", setting.Key.String()),
@@ -274,6 +326,8 @@ func convertInstanceMemoRelatedSettingFromStore(setting *storepb.InstanceMemoRel
 		DisableMarkdownShortcuts: setting.DisableMarkdownShortcuts,
 		EnableBlurNsfwContent:    setting.EnableBlurNsfwContent,
 		NsfwTags:                 setting.NsfwTags,
+		TagLocale:                setting.TagLocale,
+		PreserveTagCase:          setting.PreserveTagCase,
 	}
 }
 
@@ -291,6 +345,8 @@ func convertInstanceMemoRelatedSettingToStore(setting *v1pb.InstanceSetting_Memo
 		DisableMarkdownShortcuts: setting.DisableMarkdownShortcuts,
 		EnableBlurNsfwContent:    setting.EnableBlurNsfwContent,
 		NsfwTags:                 setting.NsfwTags,
+		TagLocale:                setting.TagLocale,
+		PreserveTagCase:          setting.PreserveTagCase,
 	}
 }
 
@@ -299,7 +355,20 @@ func convertInstanceAiSettingFromStore(setting *storepb.InstanceAiSetting) *v1pb
 		return nil
 	}
 	return &v1pb.InstanceSetting_AiSetting{
-		AiServiceUrl: setting.AiServiceUrl,
+		AiServiceUrl:                  setting.AiServiceUrl,
+		ExcludePublicMemos:            setting.ExcludePublicMemos,
+		TagCacheTtlSeconds:            setting.TagCacheTtlSeconds,
+		MaxAttachmentSizeMb:           setting.MaxAttachmentSizeMb,
+		IndexDeleteGracePeriodSeconds: setting.IndexDeleteGracePeriodSeconds,
+		AiRequestsPerMinute:           setting.AiRequestsPerMinute,
+		MaxContentChars:               setting.MaxContentChars,
+		FoldTagCase:                   setting.FoldTagCase,
+		MaxAttachments:                setting.MaxAttachments,
+		AllowInternalCallbackUrls:     setting.AllowInternalCallbackUrls,
+		DefaultSearchTopK:             setting.DefaultSearchTopK,
+		DefaultSearchMinScore:         setting.DefaultSearchMinScore,
+		DefaultSearchMode:             setting.DefaultSearchMode,
+		IndexMode:                     setting.IndexMode,
 	}
 }
 
@@ -308,7 +377,20 @@ func convertInstanceAiSettingToStore(setting *v1pb.InstanceSetting_AiSetting) *s
 		return nil
 	}
 	return &storepb.InstanceAiSetting{
-		AiServiceUrl: setting.AiServiceUrl,
+		AiServiceUrl:                  setting.AiServiceUrl,
+		ExcludePublicMemos:            setting.ExcludePublicMemos,
+		TagCacheTtlSeconds:            setting.TagCacheTtlSeconds,
+		MaxAttachmentSizeMb:           setting.MaxAttachmentSizeMb,
+		IndexDeleteGracePeriodSeconds: setting.IndexDeleteGracePeriodSeconds,
+		AiRequestsPerMinute:           setting.AiRequestsPerMinute,
+		MaxContentChars:               setting.MaxContentChars,
+		FoldTagCase:                   setting.FoldTagCase,
+		MaxAttachments:                setting.MaxAttachments,
+		AllowInternalCallbackUrls:     setting.AllowInternalCallbackUrls,
+		DefaultSearchTopK:             setting.DefaultSearchTopK,
+		DefaultSearchMinScore:         setting.DefaultSearchMinScore,
+		DefaultSearchMode:             setting.DefaultSearchMode,
+		IndexMode:                     setting.IndexMode,
 	}
 }
 
@@ -24,6 +24,12 @@ func (in *LoggerInterceptor) LoggerInterceptor(ctx context.Context, request any,
 	return resp, err
 }
 
+func (in *LoggerInterceptor) LoggerStreamInterceptor(srv any, stream grpc.ServerStream, serverInfo *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, stream)
+	in.loggerInterceptorDo(stream.Context(), serverInfo.FullMethod, err)
+	return err
+}
+
 func (in *LoggerInterceptor) loggerInterceptorDo(ctx context.Context, fullMethod string, err error) {
 	st := status.Convert(err)
 	var logLevel slog.Level
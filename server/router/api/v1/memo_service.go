@@ -63,7 +63,8 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	if len(create.Content) > contentLengthLimit {
 		return nil, status.Errorf(codes.InvalidArgument, "content too long (max %d characters)", contentLengthLimit)
 	}
-	if err := memopayload.RebuildMemoPayload(create, s.MarkdownService); err != nil {
+	markdownService := s.MarkdownService.WithTagNormalization(memopayload.NewTagNormalization(instanceMemoRelatedSetting))
+	if err := memopayload.RebuildMemoPayload(create, markdownService); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 	}
 	if request.Memo.Location != nil {
@@ -81,6 +82,9 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 		}
 		return nil, err
 	}
+	invalidateUserTagCache(ctx, memo.CreatorID)
+	// A memo re-created with the same ID restores it, so keep its index.
+	s.cancelMemoIndexDeletion(ctx, memo.UID)
 
 	attachments := []*store.Attachment{}
 
@@ -361,7 +365,12 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 			}
 			// Sanitize content to ensure valid UTF-8 (required for gRPC)
 			memo.Content = util.SanitizeUTF8(request.Memo.Content)
-			if err := memopayload.RebuildMemoPayload(memo, s.MarkdownService); err != nil {
+			instanceMemoRelatedSetting, err := s.Store.GetInstanceMemoRelatedSetting(ctx)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get instance memo related setting")
+			}
+			markdownService := s.MarkdownService.WithTagNormalization(memopayload.NewTagNormalization(instanceMemoRelatedSetting))
+			if err := memopayload.RebuildMemoPayload(memo, markdownService); err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 			}
 			update.Content = &memo.Content
@@ -434,6 +443,7 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	if err = s.Store.UpdateMemo(ctx, update); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update memo")
 	}
+	invalidateUserTagCache(ctx, memo.CreatorID)
 
 	memo, err = s.Store.GetMemo(ctx, &store.FindMemo{
 		ID: &memo.ID,
@@ -517,6 +527,7 @@ func (s *APIV1Service) DeleteMemo(ctx context.Context, request *v1pb.DeleteMemoR
 	if err = s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete memo")
 	}
+	invalidateUserTagCache(ctx, memo.CreatorID)
 
 	// Delete memo relation
 	if err := s.Store.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{MemoID: &memo.ID}); err != nil {
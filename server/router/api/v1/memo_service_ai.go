@@ -2,25 +2,340 @@ package v1
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"net/url"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"golang.org/x/text/language"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/markdown"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/ai"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/cache"
 )
 
+const (
+	// defaultAiMaxTags is the number of tags generated when the request doesn't specify one.
+	defaultAiMaxTags = 5
+	// maxAiMaxTags is the upper bound of tags generated for a single memo.
+	maxAiMaxTags = 20
+	// maxBatchGenerateAiTagsMemos is the max number of memos tagged by a single BatchGenerateAiTags call.
+	maxBatchGenerateAiTagsMemos = 50
+	// batchGenerateAiTagsWorkers is the number of concurrent AI service requests of a BatchGenerateAiTags call.
+	batchGenerateAiTagsWorkers = 4
+	// reconcileIndexWorkers is the number of concurrent AI service requests of a ReconcileIndex call.
+	reconcileIndexWorkers = 4
+	// defaultTagCacheTTL is how long a user's tag set is cached when the AI setting doesn't specify one.
+	defaultTagCacheTTL = 60 * time.Second
+	// defaultRelatedMemosTopK is the number of related memos returned when the request doesn't specify one.
+	defaultRelatedMemosTopK = 5
+	// maxRelatedMemosTopK is the upper bound of related memos returned.
+	maxRelatedMemosTopK = 50
+	// defaultAiAskTopK is the number of memos an answer is grounded on when the request doesn't specify one.
+	defaultAiAskTopK = 5
+	// maxAiAskTopK is the upper bound of memos an answer is grounded on.
+	maxAiAskTopK = 20
+	// defaultAiMaxContentChars is the max number of characters of memo content sent to the AI service
+	// when the AI setting doesn't specify one.
+	defaultAiMaxContentChars = 20000
+	// defaultAiMaxAttachmentSizeMb is the max size of a local attachment embedded in AI requests
+	// when the AI setting doesn't specify one.
+	defaultAiMaxAttachmentSizeMb = 5
+	// defaultAiMaxAttachments is the max number of attachments of a memo sent to the AI service
+	// when the AI setting doesn't specify one.
+	defaultAiMaxAttachments = 10
+	// maxAiSearchTopK is the max top_k of a search request the AI service accepts.
+	maxAiSearchTopK = 100
+	// maxAiSearchResults is the max number of ranked results the AI service pages through, i.e. the
+	// max offset plus top_k of a search request.
+	maxAiSearchResults = 1000
+	// maxAiSearchSnapshotSize is the max number of ranked results held by an AI search snapshot.
+	maxAiSearchSnapshotSize = 100
+	// aiSearchSnapshotTTL is how long an AI search snapshot can be paged through.
+	aiSearchSnapshotTTL = 10 * time.Minute
+	// aiRateLimiterTTL is how long the rate limiter of an idle user is kept. It is longer than the
+	// minute a limiter takes to refill, so dropping it never grants extra requests.
+	aiRateLimiterTTL = 10 * time.Minute
+	// aiServiceConfigTTL is how long the config reported by an AI service is cached.
+	aiServiceConfigTTL = time.Minute
+)
+
+// aiRateLimiterCache holds the per-minute rate limiter of each user and AI method, keyed by
+// method and user ID. Entries are refreshed on use, so only idle limiters expire.
+var aiRateLimiterCache = cache.New(cache.Config{
+	DefaultTTL:      aiRateLimiterTTL,
+	CleanupInterval: time.Minute,
+	MaxItems:        10000,
+})
+
+// aiRateLimiterMu serializes getting or creating a rate limiter in aiRateLimiterCache.
+var aiRateLimiterMu sync.Mutex
+
+// checkAiRateLimit returns a ResourceExhausted error if user exceeded the requests per minute of method.
+// Admins and the host are exempt, and a non-positive limit disables rate limiting.
+func checkAiRateLimit(ctx context.Context, user *store.User, method string, requestsPerMinute int32) error {
+	if requestsPerMinute <= 0 || isSuperUser(user) {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s/%d", method, user.ID)
+	aiRateLimiterMu.Lock()
+	var limiter *rate.Limiter
+	if cached, ok := aiRateLimiterCache.Get(ctx, key); ok {
+		limiter, _ = cached.(*rate.Limiter)
+	}
+	// The limiter is recreated when the configured limit changes.
+	if limiter == nil || limiter.Burst() != int(requestsPerMinute) {
+		limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(requestsPerMinute)), int(requestsPerMinute))
+	}
+	aiRateLimiterCache.Set(ctx, key, limiter)
+	aiRateLimiterMu.Unlock()
+
+	if !limiter.Allow() {
+		return grpcstatus.Errorf(codes.ResourceExhausted, "rate limit of %d %s requests per minute exceeded", requestsPerMinute, method)
+	}
+	return nil
+}
+
+// aiSearchSnapshotCache caches the ranked results of paged AI searches that asked for a snapshot,
+// keyed by user ID and snapshot ID.
+var aiSearchSnapshotCache = cache.New(cache.Config{
+	DefaultTTL:      aiSearchSnapshotTTL,
+	CleanupInterval: time.Minute,
+	MaxItems:        1000,
+})
+
+// aiServiceConfigCache caches the config reported by each AI service, keyed by service URL.
+var aiServiceConfigCache = cache.New(cache.Config{
+	DefaultTTL:      aiServiceConfigTTL,
+	CleanupInterval: time.Minute,
+	MaxItems:        100,
+})
+
+// userTagCache caches the tag set of each user used as context for AI tag generation.
+// Entries are invalidated whenever the user's memos change.
+var userTagCache = cache.New(cache.Config{
+	DefaultTTL:      defaultTagCacheTTL,
+	CleanupInterval: 5 * time.Minute,
+	MaxItems:        1000,
+})
+
+// ClearUserTagCache removes all cached user tag sets.
+func ClearUserTagCache(ctx context.Context) {
+	userTagCache.Clear(ctx)
+}
+
+// invalidateUserTagCache drops the cached tag set of the given user.
+func invalidateUserTagCache(ctx context.Context, userID int32) {
+	userTagCache.Delete(ctx, strconv.Itoa(int(userID)))
+}
+
+// listUserTags returns all unique tags (both manual and AI tags) of the user's memos.
+// With foldCase, tags equal after case folding are returned once, in their most used casing.
+func (s *APIV1Service) listUserTags(ctx context.Context, userID int32, ttl time.Duration, foldCase *markdown.TagNormalization) ([]string, error) {
+	tagCounts, err := s.countUserTags(ctx, userID, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if foldCase == nil {
+		userAllTags := make([]string, 0, len(tagCounts))
+		for tag := range tagCounts {
+			userAllTags = append(userAllTags, tag)
+		}
+		slices.Sort(userAllTags)
+		return userAllTags, nil
+	}
+
+	// Keep the casing used the most, and the first one in sort order on ties, so the choice is stable.
+	canonical := make(map[string]string)
+	for tag, count := range tagCounts {
+		key := markdown.NormalizeTag(tag, *foldCase)
+		current, ok := canonical[key]
+		if !ok || count > tagCounts[current] || (count == tagCounts[current] && tag < current) {
+			canonical[key] = tag
+		}
+	}
+	userAllTags := make([]string, 0, len(canonical))
+	for _, tag := range canonical {
+		userAllTags = append(userAllTags, tag)
+	}
+	slices.Sort(userAllTags)
+	return userAllTags, nil
+}
+
+// countUserTags returns the number of the user's memos carrying each tag, either as a manual or an AI tag.
+func (s *APIV1Service) countUserTags(ctx context.Context, userID int32, ttl time.Duration) (map[string]int, error) {
+	key := strconv.Itoa(int(userID))
+	if cached, ok := userTagCache.Get(ctx, key); ok {
+		if tagCounts, ok := cached.(map[string]int); ok {
+			return tagCounts, nil
+		}
+	}
+
+	// Count the tags of user's memos (including both manual tags and AI tags)
+	memoTags, err := s.Store.ListUserTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tagCounts := make(map[string]int, len(memoTags))
+	for _, memoTag := range memoTags {
+		tagCounts[memoTag.Tag] = memoTag.Count
+	}
+
+	userTagCache.SetWithTTL(ctx, key, tagCounts, ttl)
+	return tagCounts, nil
+}
+
+// aiTagFoldCase returns the case folding applied to tags for AI tag generation, or nil if tags are case-sensitive.
+// Tags are folded with the case mapping of the instance tag locale.
+func (s *APIV1Service) aiTagFoldCase(ctx context.Context, aiSetting *storepb.InstanceAiSetting) (*markdown.TagNormalization, error) {
+	if !aiSetting.FoldTagCase {
+		return nil, nil
+	}
+	memoRelatedSetting, err := s.Store.GetInstanceMemoRelatedSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &markdown.TagNormalization{Locale: memoRelatedSetting.TagLocale}, nil
+}
+
+// foldAiTagSuggestions matches the tags suggested for memo against its existing tags and userAllTags after
+// case folding. Suggested tags take the casing of the matching user tag, and tags the memo already carries
+// in another casing are dropped.
+func foldAiTagSuggestions(resp *ai.TagGenerationResponse, memo *store.Memo, userAllTags []string, foldCase markdown.TagNormalization) {
+	canonical := make(map[string]string, len(userAllTags))
+	for _, tag := range userAllTags {
+		canonical[markdown.NormalizeTag(tag, foldCase)] = tag
+	}
+	memoTags := make(map[string]string)
+	if memo.Payload != nil {
+		for _, tag := range slices.Concat(memo.Payload.Tags, memo.Payload.AiTags) {
+			if key := markdown.NormalizeTag(tag, foldCase); memoTags[key] == "" {
+				memoTags[key] = tag
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	tags := []string{}
+	for _, tag := range resp.Tags {
+		key := markdown.NormalizeTag(tag, foldCase)
+		if seen[key] || memoTags[key] != "" {
+			continue
+		}
+		seen[key] = true
+		if existing, ok := canonical[key]; ok {
+			tag = existing
+		}
+		tags = append(tags, tag)
+	}
+	resp.Tags = tags
+
+	// Merged tags keep the casing of the memo's own tags.
+	seen = make(map[string]bool)
+	mergedTags := []string{}
+	for _, tag := range resp.MergedTags {
+		key := markdown.NormalizeTag(tag, foldCase)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if existing, ok := memoTags[key]; ok {
+			tag = existing
+		} else if existing, ok := canonical[key]; ok {
+			tag = existing
+		}
+		mergedTags = append(mergedTags, tag)
+	}
+	resp.MergedTags = mergedTags
+}
+
+// aiTagLanguage returns the canonical form of the requested tag language, a BCP-47 code or
+// ai.TagLanguageAuto in any case. Empty stays empty.
+func aiTagLanguage(requested string) (string, error) {
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		return "", nil
+	}
+	if strings.EqualFold(requested, ai.TagLanguageAuto) {
+		return ai.TagLanguageAuto, nil
+	}
+	tag, err := language.Parse(requested)
+	if err != nil {
+		return "", grpcstatus.Errorf(codes.InvalidArgument, "invalid language %q: must be a BCP-47 code or %q", requested, ai.TagLanguageAuto)
+	}
+	return tag.String(), nil
+}
+
+// normalizeAiExcludeTags returns the tags of excludeTags without a leading # and with empty and
+// duplicate tags, ignoring case, dropped.
+func normalizeAiExcludeTags(excludeTags []string) []string {
+	var tags []string
+	for _, tag := range excludeTags {
+		tag = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag != "" && !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// excludeAiTagSuggestions drops the tags in excludeTags, ignoring case, from the tags suggested for memo.
+// Merged tags the memo already has are kept, as they aren't suggestions.
+func excludeAiTagSuggestions(resp *ai.TagGenerationResponse, memo *store.Memo, excludeTags []string) {
+	if len(excludeTags) == 0 {
+		return
+	}
+	excluded := func(tag string) bool {
+		return slices.ContainsFunc(excludeTags, func(t string) bool { return strings.EqualFold(t, tag) })
+	}
+	var memoTags []string
+	if memo.Payload != nil {
+		memoTags = slices.Concat(memo.Payload.Tags, memo.Payload.AiTags)
+	}
+	resp.Tags = slices.DeleteFunc(resp.Tags, excluded)
+	resp.MergedTags = slices.DeleteFunc(resp.MergedTags, func(tag string) bool {
+		return excluded(tag) && !slices.Contains(memoTags, tag)
+	})
+}
+
 func (s *APIV1Service) GenerateAiTags(ctx context.Context, request *v1pb.GenerateAiTagsRequest) (*v1pb.GenerateAiTagsResponse, error) {
 	memoUID, err := ExtractMemoUIDFromName(request.Name)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
+	maxTags, err := aiMaxTags(request.MaxTags)
+	if err != nil {
+		return nil, err
+	}
+	tagLanguage, err := aiTagLanguage(request.Language)
+	if err != nil {
+		return nil, err
+	}
 
 	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
 	if err != nil {
@@ -37,38 +352,202 @@ func (s *APIV1Service) GenerateAiTags(ctx context.Context, request *v1pb.Generat
 	if user == nil {
 		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
+	// Only the creator or an admin can generate tags for a memo.
+	if memo.CreatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
 
-	// Get all user's tags by listing their memos
-	normalStatus := store.Normal
-	userMemos, err := s.Store.ListMemos(ctx, &store.FindMemo{
-		CreatorID:       &user.ID,
-		RowStatus:       &normalStatus,
-		ExcludeComments: true,
-		ExcludeContent:  true,
-	})
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to list user memos: %v", err)
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	if err := checkAiRateLimit(ctx, user, "GenerateAiTags", aiSetting.AiRequestsPerMinute); err != nil {
+		return nil, err
 	}
 
-	// Collect all unique tags from user's memos (including both manual tags and AI tags)
-	tagSet := make(map[string]bool)
-	for _, m := range userMemos {
-		if m.Payload != nil {
-			// Add manual tags
-			for _, tag := range m.Payload.Tags {
-				tagSet[tag] = true
-			}
-			// Add AI-generated tags
-			for _, tag := range m.Payload.AiTags {
-				tagSet[tag] = true
-			}
+	foldCase, err := s.aiTagFoldCase(ctx, aiSetting)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo related setting: %v", err)
+	}
+	// Get all user's tags, cached per user
+	userAllTags, err := s.listUserTags(ctx, user.ID, aiTagCacheTTL(aiSetting), foldCase)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list user tags: %v", err)
+	}
+
+	aiReq, err := s.newTagGenerationRequest(ctx, memo, userAllTags, maxTags, aiSetting)
+	if err != nil {
+		return nil, err
+	}
+	aiReq.ExcludeTags = normalizeAiExcludeTags(request.ExcludeTags)
+	aiReq.Language = tagLanguage
+
+	// Call AI service
+	aiClient := s.newAIClient(aiSetting)
+	if request.Model != "" {
+		if !isSuperUser(user) {
+			return nil, grpcstatus.Errorf(codes.PermissionDenied, "only admins can override the AI model")
 		}
+		aiReq.Model = resolveAiModel(ctx, aiClient, request.Model, (*ai.Capabilities).SupportsTagGenerationModel)
 	}
-	userAllTags := make([]string, 0, len(tagSet))
-	for tag := range tagSet {
-		userAllTags = append(userAllTags, tag)
+	aiResp, err := aiClient.GenerateTags(ctx, aiReq)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to generate AI tags: %w", err))
+	}
+	if foldCase != nil {
+		foldAiTagSuggestions(aiResp, memo, userAllTags, *foldCase)
+	}
+	// The AI service may not support exclusion, so excluded tags are dropped here as well.
+	excludeAiTagSuggestions(aiResp, memo, aiReq.ExcludeTags)
+
+	// Always return non-nil slices so clients see empty lists rather than nulls.
+	tags, mergedTags := []string{}, []string{}
+	tags = append(tags, aiResp.Tags...)
+	mergedTags = append(mergedTags, aiResp.MergedTags...)
+	return &v1pb.GenerateAiTagsResponse{
+		Tags:       tags,
+		MergedTags: mergedTags,
+		Usage:      convertTokenUsageToProto(aiResp.Usage),
+	}, nil
+}
+
+// BatchGenerateAiTags generates AI tags for multiple memos of the current user concurrently.
+// The user's tag set is computed once for the whole batch. Each memo counts against the
+// GenerateAiTags rate limit, and failures are reported per memo.
+func (s *APIV1Service) BatchGenerateAiTags(ctx context.Context, request *v1pb.BatchGenerateAiTagsRequest) (*v1pb.BatchGenerateAiTagsResponse, error) {
+	names := []string{}
+	for _, name := range request.Names {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "names must not be empty")
+	}
+	if len(names) > maxBatchGenerateAiTagsMemos {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "at most %d memos can be tagged at once", maxBatchGenerateAiTagsMemos)
+	}
+	maxTags, err := aiMaxTags(request.MaxTags)
+	if err != nil {
+		return nil, err
+	}
+	tagLanguage, err := aiTagLanguage(request.Language)
+	if err != nil {
+		return nil, err
+	}
+	excludeTags := normalizeAiExcludeTags(request.ExcludeTags)
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	foldCase, err := s.aiTagFoldCase(ctx, aiSetting)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo related setting: %v", err)
+	}
+	userAllTags, err := s.listUserTags(ctx, user.ID, aiTagCacheTTL(aiSetting), foldCase)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list user tags: %v", err)
 	}
+	aiClient := s.newAIClient(aiSetting)
+
+	results := make(map[string]*v1pb.BatchGenerateAiTagsResponse_Result, len(names))
+	var mu sync.Mutex
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < min(batchGenerateAiTagsWorkers, len(names)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				result := &v1pb.BatchGenerateAiTagsResponse_Result{Tags: []string{}, MergedTags: []string{}}
+				aiResp, err := s.generateBatchAiTags(ctx, aiClient, user, aiSetting, userAllTags, foldCase, name, maxTags, excludeTags, tagLanguage)
+				if err != nil {
+					result.Error = grpcstatus.Convert(err).Message()
+				} else {
+					result.Tags = append(result.Tags, aiResp.Tags...)
+					result.MergedTags = append(result.MergedTags, aiResp.MergedTags...)
+					result.Usage = convertTokenUsageToProto(aiResp.Usage)
+				}
+				mu.Lock()
+				results[name] = result
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+
+	return &v1pb.BatchGenerateAiTagsResponse{Results: results}, nil
+}
+
+// generateBatchAiTags generates the tags of the memo with the given name for BatchGenerateAiTags.
+// Only the creator or an admin can generate tags for a memo.
+func (s *APIV1Service) generateBatchAiTags(ctx context.Context, aiClient *ai.Client, user *store.User, aiSetting *storepb.InstanceAiSetting, userAllTags []string, foldCase *markdown.TagNormalization, name string, maxTags int, excludeTags []string, language string) (*ai.TagGenerationResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(name)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "memo not found")
+	}
+	if memo.CreatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if err := checkAiRateLimit(ctx, user, "GenerateAiTags", aiSetting.AiRequestsPerMinute); err != nil {
+		return nil, err
+	}
+
+	aiReq, err := s.newTagGenerationRequest(ctx, memo, userAllTags, maxTags, aiSetting)
+	if err != nil {
+		return nil, err
+	}
+	aiReq.ExcludeTags = excludeTags
+	aiReq.Language = language
+	aiResp, err := aiClient.GenerateTags(ctx, aiReq)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to generate AI tags: %w", err))
+	}
+	if foldCase != nil {
+		foldAiTagSuggestions(aiResp, memo, userAllTags, *foldCase)
+	}
+	// The AI service may not support exclusion, so excluded tags are dropped here as well.
+	excludeAiTagSuggestions(aiResp, memo, excludeTags)
+	return aiResp, nil
+}
+
+// aiMaxTags returns the number of tags to generate for the requested max_tags.
+func aiMaxTags(requested int32) (int, error) {
+	if requested < 0 {
+		return 0, grpcstatus.Errorf(codes.InvalidArgument, "max_tags must be at least 1")
+	}
+	maxTags := int(requested)
+	if maxTags == 0 {
+		maxTags = defaultAiMaxTags
+	}
+	if maxTags > maxAiMaxTags {
+		maxTags = maxAiMaxTags
+	}
+	return maxTags, nil
+}
 
+// newTagGenerationRequest builds the AI service request to generate tags for memo, including its attachments.
+func (s *APIV1Service) newTagGenerationRequest(ctx context.Context, memo *store.Memo, userAllTags []string, maxTags int, aiSetting *storepb.InstanceAiSetting) (*ai.TagGenerationRequest, error) {
 	// Get attachments
 	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
 		MemoID: &memo.ID,
@@ -76,14 +555,16 @@ func (s *APIV1Service) GenerateAiTags(ctx context.Context, request *v1pb.Generat
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "failed to list attachments: %v", err)
 	}
+	attachments = selectAiAttachments(memo, attachments, aiMaxAttachments(aiSetting))
 
 	// Build AI request
 	aiReq := &ai.TagGenerationRequest{
 		UserAllTags: userAllTags,
-		MaxTags:     5,
+		MaxTags:     maxTags,
 	}
 	aiReq.Memo.Name = memo.UID
-	aiReq.Memo.Content = memo.Content
+	// The beginning of a memo is usually the most informative for tagging, so long memos lose their end.
+	aiReq.Memo.Content = truncateAiContent(memo, aiMaxContentChars(aiSetting))
 	// Ensure tags is always a list (never null)
 	aiReq.Memo.Tags = []string{}
 	if memo.Payload != nil && memo.Payload.Tags != nil {
@@ -91,6 +572,7 @@ func (s *APIV1Service) GenerateAiTags(ctx context.Context, request *v1pb.Generat
 	}
 
 	// Convert attachments to AI format
+	maxAttachmentSize := aiMaxAttachmentSize(aiSetting)
 	aiReq.Memo.Attachments = make([]ai.AttachmentForAI, 0, len(attachments))
 	for _, att := range attachments {
 		attForAI := ai.AttachmentForAI{
@@ -102,6 +584,10 @@ func (s *APIV1Service) GenerateAiTags(ctx context.Context, request *v1pb.Generat
 		// Use presigned URL for S3 and external links
 		if att.StorageType == storepb.AttachmentStorageType_S3 || att.StorageType == storepb.AttachmentStorageType_EXTERNAL {
 			attForAI.ExternalLink = att.Reference
+		} else if att.Size > maxAttachmentSize {
+			// Too large to embed, only send the metadata
+			slog.Info("Skipping oversized attachment data in AI request",
+				slog.String("memo", memo.UID), slog.String("attachment", att.Filename), slog.Int64("size", att.Size), slog.Int64("limit", maxAttachmentSize))
 		} else {
 			// For local/database storage, use base64 data URL (OpenAI can't access localhost)
 			// Get blob from attachment
@@ -124,32 +610,166 @@ func (s *APIV1Service) GenerateAiTags(ctx context.Context, request *v1pb.Generat
 
 		aiReq.Memo.Attachments = append(aiReq.Memo.Attachments, attForAI)
 	}
+	return aiReq, nil
+}
 
-	// Get AI service URL from instance settings
-	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+// ApplyAiTags writes AI tags into the memo's payload and re-indexes the memo.
+func (s *APIV1Service) ApplyAiTags(ctx context.Context, request *v1pb.ApplyAiTagsRequest) (*v1pb.ApplyAiTagsResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
 
-	// Call AI service
-	aiClient := ai.NewClient(aiSetting.AiServiceUrl)
-	aiResp, err := aiClient.GenerateTags(ctx, aiReq)
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to generate AI tags: %v", err)
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "memo not found")
 	}
 
-	return &v1pb.GenerateAiTagsResponse{
-		Tags: aiResp.Tags,
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	// Only the creator or admin can update the memo.
+	if memo.CreatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	tags := request.Tags
+	if len(tags) == 0 {
+		generated, err := s.GenerateAiTags(ctx, &v1pb.GenerateAiTagsRequest{Name: request.Name})
+		if err != nil {
+			return nil, err
+		}
+		tags = generated.Tags
+	}
+
+	payload := memo.Payload
+	if payload == nil {
+		payload = &storepb.MemoPayload{}
+	}
+	payload.AiTags = mergeAiTags(payload.Tags, payload.AiTags, tags)
+	if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:      memo.ID,
+		Payload: payload,
+	}); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to update memo: %v", err)
+	}
+	invalidateUserTagCache(ctx, memo.CreatorID)
+
+	// Re-index so AI search reflects the new tags.
+	if _, err := s.IndexMemo(ctx, &v1pb.IndexMemoRequest{Name: request.Name}); err != nil {
+		slog.Warn("Failed to re-index memo after applying AI tags", slog.String("memo", request.Name), slog.Any("err", err))
+	}
+
+	return &v1pb.ApplyAiTagsResponse{
+		AiTags: payload.AiTags,
+	}, nil
+}
+
+// mergeAiTags appends the new tags to the existing AI tags, skipping empty tags and any tag
+// that already exists as a manual or AI tag (compared case-insensitively).
+func mergeAiTags(manualTags, aiTags, newTags []string) []string {
+	seen := make(map[string]bool, len(manualTags)+len(aiTags)+len(newTags))
+	for _, tag := range manualTags {
+		seen[strings.ToLower(tag)] = true
+	}
+	merged := make([]string, 0, len(aiTags)+len(newTags))
+	for _, tag := range append(append([]string{}, aiTags...), newTags...) {
+		tag = strings.TrimSpace(strings.TrimPrefix(tag, "#"))
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, tag)
+	}
+	return merged
+}
+
+// AiSummarize generates a summary of a memo.
+func (s *APIV1Service) AiSummarize(ctx context.Context, request *v1pb.AiSummarizeRequest) (*v1pb.AiSummarizeResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	if request.MaxWords < 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "max_words must not be negative")
+	}
+
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "memo not found")
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	// Check if user owns the memo or is admin
+	if memo.CreatorID != user.ID && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	aiReq := &ai.SummarizeRequest{
+		MaxWords: int(request.MaxWords),
+		Language: request.Language,
+	}
+	aiReq.Memo.Name = memo.UID
+	aiReq.Memo.Content = memo.Content
+
+	aiClient, err := s.getAIClient(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+	}
+
+	resp, err := aiClient.Summarize(ctx, aiReq)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to summarize memo: %w", err))
+	}
+
+	return &v1pb.AiSummarizeResponse{
+		Summary: util.SanitizeUTF8(resp.Summary),
+		Usage:   convertTokenUsageToProto(&resp.Usage),
 	}, nil
 }
 
+// convertTokenUsageToProto converts the token usage reported by the AI service, keeping nil as nil.
+func convertTokenUsageToProto(usage *ai.TokenUsage) *v1pb.AiTokenUsage {
+	if usage == nil {
+		return nil
+	}
+	return &v1pb.AiTokenUsage{
+		PromptTokens:     int32(usage.PromptTokens),
+		CompletionTokens: int32(usage.CompletionTokens),
+		TotalTokens:      int32(usage.TotalTokens),
+	}
+}
+
 // getAIClient creates an AI client with the configured service URL.
 func (s *APIV1Service) getAIClient(ctx context.Context) (*ai.Client, error) {
 	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get AI settings: %w", err)
 	}
-	return ai.NewClient(aiSetting.AiServiceUrl), nil
+	return s.newAIClient(aiSetting), nil
+}
+
+// newAIClient creates a client of the AI service configured by aiSetting.
+func (s *APIV1Service) newAIClient(aiSetting *storepb.InstanceAiSetting) *ai.Client {
+	return ai.NewClient(aiSetting.AiServiceUrl, ai.WithMetrics(s.AIMetrics), ai.WithCircuitBreaker(s.AIBreaker), ai.WithLogger(s.AILogger))
 }
 
 // IndexMemo indexes a memo for AI search.
@@ -158,6 +778,13 @@ func (s *APIV1Service) IndexMemo(ctx context.Context, request *v1pb.IndexMemoReq
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
+	operation := request.Operation
+	if operation == "" {
+		operation = ai.IndexOperationUpsert
+	}
+	if !ai.IsValidIndexOperation(operation) {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid operation %q: must be upsert, create or replace", operation)
+	}
 
 	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
 	if err != nil {
@@ -174,102 +801,1944 @@ func (s *APIV1Service) IndexMemo(ctx context.Context, request *v1pb.IndexMemoReq
 	if user == nil {
 		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
-
-	// Check if user owns the memo or is admin
-	if memo.CreatorID != user.ID && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+
+	// Check if user owns the memo or is admin
+	if memo.CreatorID != user.ID && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	indexable, err := s.isMemoAiIndexable(ctx, memo, aiSetting)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo creator: %v", err)
+	}
+	if !indexable {
+		return &v1pb.IndexMemoResponse{
+			MemoUid:   memo.UID,
+			Status:    "skipped",
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Skipped:   true,
+		}, nil
+	}
+
+	// Get attachments
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list attachments: %v", err)
+	}
+
+	// Convert memo to the format expected by AI service
+	memoForAI := s.convertMemoForAI(ctx, memo, attachments, aiMaxAttachments(aiSetting), aiMaxAttachmentSize(aiSetting), aiMaxContentChars(aiSetting), aiIndexMode(aiSetting))
+
+	aiClient := s.newAIClient(aiSetting)
+
+	// An upsert of a memo that is unchanged since it was last indexed would only repeat the same work.
+	// Create and replace are still sent, so they fail as documented.
+	indexHash := ai.HashMemoDocument(memoForAI)
+	if operation == ai.IndexOperationUpsert && !request.Force && s.isMemoAiIndexCurrent(ctx, aiClient, memo, memoForAI, indexHash) {
+		return &v1pb.IndexMemoResponse{
+			MemoUid:   memo.UID,
+			Status:    "unchanged",
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Skipped:   true,
+		}, nil
+	}
+
+	resp, err := aiClient.IndexMemoWithOperation(ctx, memoForAI, operation)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to index memo: %w", err))
+	}
+	s.recordMemoAiIndexed(ctx, memo, indexHash, resp)
+
+	return &v1pb.IndexMemoResponse{
+		MemoUid:   resp.MemoUID,
+		Status:    resp.Status,
+		Timestamp: resp.Timestamp,
+	}, nil
+}
+
+// RefreshMemoIndex forces the AI service to re-read and re-embed a memo, even if its content is unchanged.
+func (s *APIV1Service) RefreshMemoIndex(ctx context.Context, request *v1pb.RefreshMemoIndexRequest) (*v1pb.RefreshMemoIndexResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "memo not found")
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	// Check if user owns the memo or is admin
+	if memo.CreatorID != user.ID && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	indexable, err := s.isMemoAiIndexable(ctx, memo, aiSetting)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo creator: %v", err)
+	}
+	if !indexable {
+		return &v1pb.RefreshMemoIndexResponse{
+			MemoUid:   memo.UID,
+			Status:    "skipped",
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		}, nil
+	}
+
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list attachments: %v", err)
+	}
+	memoForAI := s.convertMemoForAI(ctx, memo, attachments, aiMaxAttachments(aiSetting), aiMaxAttachmentSize(aiSetting), aiMaxContentChars(aiSetting), aiIndexMode(aiSetting))
+
+	aiClient := s.newAIClient(aiSetting)
+	resp, err := aiClient.RefreshMemoIndex(ctx, memoForAI)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to refresh memo index: %w", err))
+	}
+	s.recordMemoAiIndexed(ctx, memo, ai.HashMemoDocument(memoForAI), resp)
+
+	return &v1pb.RefreshMemoIndexResponse{
+		MemoUid:      resp.MemoUID,
+		Status:       resp.Status,
+		Timestamp:    resp.Timestamp,
+		TextVectors:  int32(resp.TextVectors),
+		ImageVectors: int32(resp.ImageVectors),
+	}, nil
+}
+
+// isMemoAiIndexCurrent reports whether memo is indexed as memoForAI, whose hash is indexHash. That's
+// the case if the hash was saved once the AI service confirmed indexing memo, or if the AI service
+// reports it for the index of memo, which it does once a queued index of memo succeeded. The hash is
+// then saved, so the next check is local. A failing status check reports false.
+func (s *APIV1Service) isMemoAiIndexCurrent(ctx context.Context, aiClient *ai.Client, memo *store.Memo, memoForAI *ai.MemoDocument, indexHash string) bool {
+	if memo.Payload.GetAiIndexHash() == indexHash {
+		return true
+	}
+	info, err := aiClient.GetMemoIndexInfo(ctx, memoForAI.Name, false)
+	if err != nil || !info.Indexed || info.ContentHash != indexHash {
+		return false
+	}
+	s.saveMemoAiIndexHash(ctx, memo, indexHash)
+	return true
+}
+
+// recordMemoAiIndexed saves indexHash as the AI index hash of memo if resp confirms the memo is
+// indexed. A memo the AI service only queued may still fail to index, so its hash is saved by
+// isMemoAiIndexCurrent once the AI service reports it.
+func (s *APIV1Service) recordMemoAiIndexed(ctx context.Context, memo *store.Memo, indexHash string, resp *ai.IndexMemoResponse) {
+	if !resp.Accepted {
+		s.saveMemoAiIndexHash(ctx, memo, indexHash)
+	}
+}
+
+// saveMemoAiIndexHash stores the AI index hash of memo, an empty hash forgets that the memo is indexed.
+// The memo is read again, so payload changes made meanwhile are kept, and the hash isn't stored if
+// the memo was updated since it was hashed. Failures are only logged, as they just cost a re-index.
+func (s *APIV1Service) saveMemoAiIndexHash(ctx context.Context, memo *store.Memo, hash string) {
+	current, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	if err != nil || current == nil {
+		return
+	}
+	if current.Payload.GetAiIndexHash() == hash || (hash != "" && current.UpdatedTs != memo.UpdatedTs) {
+		return
+	}
+	payload := current.Payload
+	if payload == nil {
+		payload = &storepb.MemoPayload{}
+	}
+	payload.AiIndexHash = hash
+	if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:      current.ID,
+		Payload: payload,
+	}); err != nil {
+		slog.Warn("Failed to save memo AI index hash", slog.String("memo", memo.UID), slog.Any("err", err))
+	}
+}
+
+// isMemoAiIndexable reports whether a memo may be sent to the AI index under the instance AI setting.
+// With exclude_public_memos, public memos and memos created by a guest, i.e. whose creator isn't a
+// registered user, are not indexed.
+func (s *APIV1Service) isMemoAiIndexable(ctx context.Context, memo *store.Memo, aiSetting *storepb.InstanceAiSetting) (bool, error) {
+	if !aiSetting.GetExcludePublicMemos() {
+		return true, nil
+	}
+	if memo.Visibility == store.Public {
+		return false, nil
+	}
+	creator, err := s.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil {
+		return false, err
+	}
+	return creator != nil, nil
+}
+
+// aiTagCacheTTL returns how long a user's tag set is cached for AI tag generation.
+func aiTagCacheTTL(aiSetting *storepb.InstanceAiSetting) time.Duration {
+	if aiSetting.TagCacheTtlSeconds > 0 {
+		return time.Duration(aiSetting.TagCacheTtlSeconds) * time.Second
+	}
+	return defaultTagCacheTTL
+}
+
+// aiMaxAttachmentSize returns the max size in bytes of a local attachment embedded in AI requests.
+func aiMaxAttachmentSize(aiSetting *storepb.InstanceAiSetting) int64 {
+	sizeMb := aiSetting.GetMaxAttachmentSizeMb()
+	if sizeMb <= 0 {
+		sizeMb = defaultAiMaxAttachmentSizeMb
+	}
+	return sizeMb * MebiByte
+}
+
+// aiMaxAttachments returns the max number of attachments of a memo sent to the AI service.
+func aiMaxAttachments(aiSetting *storepb.InstanceAiSetting) int {
+	if maxAttachments := aiSetting.GetMaxAttachments(); maxAttachments > 0 {
+		return int(maxAttachments)
+	}
+	return defaultAiMaxAttachments
+}
+
+// selectAiAttachments returns the attachments of memo sent to the AI service: duplicates of the
+// same attachment are dropped, and if more than maxAttachments remain, images are kept before
+// other types. The selected attachments keep their original order.
+func selectAiAttachments(memo *store.Memo, attachments []*store.Attachment, maxAttachments int) []*store.Attachment {
+	seen := make(map[string]bool, len(attachments))
+	unique := make([]*store.Attachment, 0, len(attachments))
+	for _, att := range attachments {
+		if seen[att.UID] {
+			continue
+		}
+		seen[att.UID] = true
+		unique = append(unique, att)
+	}
+	if len(unique) <= maxAttachments {
+		return unique
+	}
+
+	// Images are what the AI service indexes from attachments, so they are kept first.
+	keep := make(map[*store.Attachment]bool, maxAttachments)
+	for _, images := range []bool{true, false} {
+		for _, att := range unique {
+			if len(keep) == maxAttachments {
+				break
+			}
+			if strings.HasPrefix(att.Type, "image/") == images {
+				keep[att] = true
+			}
+		}
+	}
+	selected := make([]*store.Attachment, 0, maxAttachments)
+	for _, att := range unique {
+		if keep[att] {
+			selected = append(selected, att)
+		}
+	}
+	slog.Info("Dropping attachments over the limit in AI request",
+		slog.String("memo", memo.UID), slog.Int("attachments", len(unique)), slog.Int("limit", maxAttachments))
+	return selected
+}
+
+// aiMaxContentChars returns the max number of characters of memo content sent to the AI service.
+func aiMaxContentChars(aiSetting *storepb.InstanceAiSetting) int {
+	if maxChars := aiSetting.GetMaxContentChars(); maxChars > 0 {
+		return int(maxChars)
+	}
+	return defaultAiMaxContentChars
+}
+
+// aiIndexMode returns what of a memo is sent to the AI service for indexing.
+func aiIndexMode(aiSetting *storepb.InstanceAiSetting) ai.IndexMode {
+	if indexMode := aiSetting.GetIndexMode(); ai.IsValidIndexMode(indexMode) {
+		return ai.IndexMode(indexMode)
+	}
+	return ai.IndexModeBoth
+}
+
+// truncateAiContent returns the content of memo cut to its first maxChars characters,
+// logging when the content is truncated.
+func truncateAiContent(memo *store.Memo, maxChars int) string {
+	content, truncated := truncateRunes(memo.Content, maxChars)
+	if truncated {
+		slog.Info("Truncating memo content in AI request",
+			slog.String("memo", memo.UID), slog.Int("length", utf8.RuneCountInString(memo.Content)), slog.Int("limit", maxChars))
+	}
+	return content
+}
+
+// aiIndexedContent returns the content of memo sent to the AI service: none with
+// ai.IndexModeImagesOnly, and otherwise the content cut to its first maxChars characters, as
+// convertMemoForAI does.
+func aiIndexedContent(memo *store.Memo, maxChars int, indexMode ai.IndexMode) string {
+	if indexMode == ai.IndexModeImagesOnly {
+		return ""
+	}
+	content, _ := truncateRunes(memo.Content, maxChars)
+	return content
+}
+
+// truncateRunes returns the first maxRunes runes of s and whether s was longer.
+func truncateRunes(s string, maxRunes int) (string, bool) {
+	if len(s) <= maxRunes {
+		return s, false
+	}
+	count := 0
+	for i := range s {
+		if count == maxRunes {
+			return s[:i], true
+		}
+		count++
+	}
+	return s, false
+}
+
+// convertMemoForAI converts a memo to the document indexed by the AI service.
+// At most maxAttachments attachments are sent as selected by selectAiAttachments. Local attachments
+// larger than maxAttachmentSize are sent without their data, and content longer than maxContentChars
+// characters is truncated from the end. With ai.IndexModeTextOnly no attachment data is sent, and
+// with ai.IndexModeImagesOnly only the data of images is, without the content and extracted text.
+func (s *APIV1Service) convertMemoForAI(ctx context.Context, memo *store.Memo, attachments []*store.Attachment, maxAttachments int, maxAttachmentSize int64, maxContentChars int, indexMode ai.IndexMode) *ai.MemoDocument {
+	attachments = selectAiAttachments(memo, attachments, maxAttachments)
+
+	// Build attachments list
+	attList := make([]ai.AttachmentDocument, 0, len(attachments))
+	for _, att := range attachments {
+		attForAI := ai.AttachmentDocument{
+			Name:     att.UID,
+			Filename: att.Filename,
+			Type:     att.Type,
+		}
+		if indexMode != ai.IndexModeImagesOnly {
+			// Text already extracted from the attachment saves the AI service from extracting it again.
+			attForAI.ExtractedText = att.Payload.GetExtractedText()
+		}
+
+		switch {
+		case indexMode == ai.IndexModeTextOnly || (indexMode == ai.IndexModeImagesOnly && !strings.HasPrefix(att.Type, "image/")):
+			// The attachment data wouldn't be indexed, so it isn't read or sent.
+		case att.StorageType == storepb.AttachmentStorageType_S3 || att.StorageType == storepb.AttachmentStorageType_EXTERNAL:
+			// Use presigned URL for S3 and external links
+			attForAI.ExternalLink = att.Reference
+		case att.Size > maxAttachmentSize:
+			// Too large to embed, only send the metadata
+			slog.Info("Skipping oversized attachment data in AI request",
+				slog.String("memo", memo.UID), slog.String("attachment", att.Filename), slog.Int64("size", att.Size), slog.Int64("limit", maxAttachmentSize))
+		default:
+			// For local/database storage, use base64 data URL
+			fullAtt, err := s.Store.GetAttachment(ctx, &store.FindAttachment{
+				ID:      &att.ID,
+				GetBlob: true,
+			})
+			if err == nil && fullAtt != nil {
+				blob, err := s.GetAttachmentBlob(fullAtt)
+				if err == nil {
+					attForAI.ExternalLink = fmt.Sprintf("data:%s;base64,%s", att.Type, base64.StdEncoding.EncodeToString(blob))
+				}
+			}
+		}
+
+		attList = append(attList, attForAI)
+	}
+
+	// Get tags
+	tags := []string{}
+	aiTags := []string{}
+	if memo.Payload != nil {
+		if memo.Payload.Tags != nil {
+			tags = memo.Payload.Tags
+		}
+		if memo.Payload.AiTags != nil {
+			aiTags = memo.Payload.AiTags
+		}
+	}
+
+	content := ""
+	if indexMode != ai.IndexModeImagesOnly {
+		content = truncateAiContent(memo, maxContentChars)
+	}
+
+	return &ai.MemoDocument{
+		Name:        fmt.Sprintf("memos/%s", memo.UID),
+		UID:         memo.UID,
+		Content:     content,
+		Creator:     fmt.Sprintf("users/%d", memo.CreatorID),
+		Visibility:  memo.Visibility.String(),
+		CreateTime:  time.Unix(memo.CreatedTs, 0).Format(time.RFC3339),
+		UpdateTime:  time.Unix(memo.UpdatedTs, 0).Format(time.RFC3339),
+		DisplayTime: time.Unix(memo.CreatedTs, 0).Format(time.RFC3339),
+		Tags:        tags,
+		AiTags:      aiTags,
+		Attachments: attList,
+	}
+}
+
+// DeleteMemoIndex deletes the index of a memo.
+func (s *APIV1Service) DeleteMemoIndex(ctx context.Context, request *v1pb.DeleteMemoIndexRequest) (*v1pb.DeleteMemoIndexResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	aiClient := s.newAIClient(aiSetting)
+
+	// Defer deleting the index of a deleted memo, in case the deletion is undone.
+	if aiSetting.IndexDeleteGracePeriodSeconds > 0 {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+		}
+		if memo == nil {
+			deleteTime := time.Now().Add(time.Duration(aiSetting.IndexDeleteGracePeriodSeconds) * time.Second)
+			if err := s.scheduleMemoIndexDeletion(ctx, user.ID, memoUID, deleteTime); err != nil {
+				return nil, grpcstatus.Errorf(codes.Internal, "failed to schedule memo index deletion: %v", err)
+			}
+			return &v1pb.DeleteMemoIndexResponse{
+				Success: true,
+				Pending: true,
+			}, nil
+		}
+	}
+
+	err = aiClient.DeleteMemoIndex(ctx, memoUID)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to delete memo index: %w", err))
+	}
+	// The memo may still exist, e.g. when excluded from the index, so the next IndexMemo must send it.
+	if memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID}); err == nil && memo != nil {
+		s.saveMemoAiIndexHash(ctx, memo, "")
+	}
+
+	return &v1pb.DeleteMemoIndexResponse{
+		Success: true,
+	}, nil
+}
+
+// pendingIndexDeletion is an index deletion deferred by the grace period. It's also stored in the
+// AI_INDEX_DELETIONS setting of the user who requested it, so it survives restarts.
+type pendingIndexDeletion struct {
+	timer  *time.Timer
+	userID int32
+}
+
+// pendingIndexDeletions holds the index deletions deferred by the grace period, keyed by memo UID.
+var pendingIndexDeletions = struct {
+	sync.Mutex
+	deletions map[string]*pendingIndexDeletion
+}{deletions: map[string]*pendingIndexDeletion{}}
+
+// scheduleMemoIndexDeletion stores a pending index deletion of a memo requested by a user, and deletes
+// the index at deleteTime unless the memo has been restored by then.
+func (s *APIV1Service) scheduleMemoIndexDeletion(ctx context.Context, userID int32, memoUID string, deleteTime time.Time) error {
+	if err := s.Store.AddUserAiIndexDeletion(ctx, userID, &storepb.AiIndexDeletionsUserSetting_Deletion{
+		MemoId:     memoUID,
+		DeleteTime: timestamppb.New(deleteTime),
+	}); err != nil {
+		return err
+	}
+	s.startMemoIndexDeletionTimer(userID, memoUID, deleteTime)
+	return nil
+}
+
+// ResumeMemoIndexDeletions restarts the timers of the index deletions stored before the server stopped.
+// Deletions whose grace period ended in the meantime proceed right away.
+func (s *APIV1Service) ResumeMemoIndexDeletions(ctx context.Context) error {
+	userSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSetting_AI_INDEX_DELETIONS,
+	})
+	if err != nil {
+		return err
+	}
+	for _, userSetting := range userSettings {
+		for _, deletion := range userSetting.GetAiIndexDeletions().GetDeletions() {
+			s.startMemoIndexDeletionTimer(userSetting.UserId, deletion.MemoId, deletion.DeleteTime.AsTime())
+		}
+	}
+	return nil
+}
+
+// startMemoIndexDeletionTimer deletes the index of a memo at deleteTime, unless the memo has been
+// restored by then, and removes the stored deletion once done.
+func (s *APIV1Service) startMemoIndexDeletionTimer(userID int32, memoUID string, deleteTime time.Time) {
+	pendingIndexDeletions.Lock()
+	defer pendingIndexDeletions.Unlock()
+	if previous, ok := pendingIndexDeletions.deletions[memoUID]; ok {
+		previous.timer.Stop()
+		if previous.userID != userID {
+			if err := s.Store.RemoveUserAiIndexDeletion(context.Background(), previous.userID, memoUID); err != nil {
+				slog.Warn("Failed to remove pending memo index deletion", slog.String("memo", memoUID), slog.Any("err", err))
+			}
+		}
+	}
+
+	deletion := &pendingIndexDeletion{userID: userID}
+	deletion.timer = time.AfterFunc(time.Until(deleteTime), func() {
+		pendingIndexDeletions.Lock()
+		if pendingIndexDeletions.deletions[memoUID] != deletion {
+			// Cancelled or rescheduled in the meantime.
+			pendingIndexDeletions.Unlock()
+			return
+		}
+		delete(pendingIndexDeletions.deletions, memoUID)
+		pendingIndexDeletions.Unlock()
+
+		ctx := context.Background()
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		if err != nil {
+			slog.Warn("Failed to get memo for pending index deletion", slog.String("memo", memoUID), slog.Any("err", err))
+			return
+		}
+		// A restored memo keeps its index.
+		if memo == nil {
+			aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+			if err != nil {
+				slog.Warn("Failed to get AI settings for pending index deletion", slog.String("memo", memoUID), slog.Any("err", err))
+				return
+			}
+			if err := s.newAIClient(aiSetting).DeleteMemoIndex(ctx, memoUID); err != nil {
+				// Keep the stored deletion, so it's retried on the next start.
+				slog.Warn("Failed to delete memo index after grace period", slog.String("memo", memoUID), slog.Any("err", err))
+				return
+			}
+		}
+		if err := s.Store.RemoveUserAiIndexDeletion(ctx, userID, memoUID); err != nil {
+			slog.Warn("Failed to remove pending memo index deletion", slog.String("memo", memoUID), slog.Any("err", err))
+		}
+	})
+	pendingIndexDeletions.deletions[memoUID] = deletion
+}
+
+// isMemoIndexDeletionPending reports whether the index of a memo is waiting for its deletion grace period.
+func isMemoIndexDeletionPending(memoUID string) bool {
+	pendingIndexDeletions.Lock()
+	defer pendingIndexDeletions.Unlock()
+	_, ok := pendingIndexDeletions.deletions[memoUID]
+	return ok
+}
+
+// cancelMemoIndexDeletion cancels the pending index deletion of a memo, if any.
+func (s *APIV1Service) cancelMemoIndexDeletion(ctx context.Context, memoUID string) {
+	pendingIndexDeletions.Lock()
+	deletion, ok := pendingIndexDeletions.deletions[memoUID]
+	if ok {
+		deletion.timer.Stop()
+		delete(pendingIndexDeletions.deletions, memoUID)
+	}
+	pendingIndexDeletions.Unlock()
+	if !ok {
+		return
+	}
+	if err := s.Store.RemoveUserAiIndexDeletion(ctx, deletion.userID, memoUID); err != nil {
+		slog.Warn("Failed to remove pending memo index deletion", slog.String("memo", memoUID), slog.Any("err", err))
+	}
+}
+
+// DeleteMemoIndexChunk deletes a single indexed chunk of a memo.
+func (s *APIV1Service) DeleteMemoIndexChunk(ctx context.Context, request *v1pb.DeleteMemoIndexChunkRequest) (*v1pb.DeleteMemoIndexChunkResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	if request.DocId == "" {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "doc_id is required")
+	}
+
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "memo not found")
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	// Check if user owns the memo or is admin
+	if memo.CreatorID != user.ID && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	aiClient, err := s.getAIClient(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+	}
+
+	if err := aiClient.DeleteMemoChunk(ctx, memo.UID, request.DocId); err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to delete memo index chunk: %w", err))
+	}
+	// The memo is no longer fully indexed, so the next IndexMemo must send it.
+	s.saveMemoAiIndexHash(ctx, memo, "")
+
+	return &v1pb.DeleteMemoIndexChunkResponse{
+		Success: true,
+	}, nil
+}
+
+// GetMemoIndexInfo gets the index info of a memo.
+func (s *APIV1Service) GetMemoIndexInfo(ctx context.Context, request *v1pb.GetMemoIndexInfoRequest) (*v1pb.MemoIndexInfo, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	aiClient, err := s.getAIClient(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+	}
+
+	info, err := aiClient.GetMemoIndexInfo(ctx, request.Name, request.IncludeDetail)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to get memo index info: %w", err))
+	}
+	if info == nil {
+		return &v1pb.MemoIndexInfo{
+			MemoUid: request.Name,
+			Indexed: false,
+		}, nil
+	}
+
+	result := &v1pb.MemoIndexInfo{
+		MemoUid:      info.MemoUID,
+		Indexed:      info.Indexed,
+		TextVectors:  int32(info.TextCount),
+		ImageVectors: int32(info.ImageCount),
+	}
+	if !info.IndexedAt.IsZero() {
+		result.IndexedAt = timestamppb.New(info.IndexedAt)
+		stale, err := s.isMemoIndexStale(ctx, request.Name, info.IndexedAt)
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+		}
+		result.Stale = stale
+	}
+
+	// Add detail if requested and available
+	if request.IncludeDetail && info.Detail != nil {
+		detail := &v1pb.MemoIndexDetail{
+			TextChunks: make([]*v1pb.TextChunk, 0, len(info.Detail.TextChunks)),
+			Images:     make([]*v1pb.ImageInfo, 0, len(info.Detail.Images)),
+		}
+
+		for _, tc := range info.Detail.TextChunks {
+			detail.TextChunks = append(detail.TextChunks, &v1pb.TextChunk{
+				DocId:       tc.DocID,
+				Content:     tc.Content,
+				ContentType: tc.ContentType,
+			})
+		}
+
+		for _, img := range info.Detail.Images {
+			detail.Images = append(detail.Images, &v1pb.ImageInfo{
+				DocId:    img.DocID,
+				Filename: img.Filename,
+				Caption:  img.Caption,
+			})
+		}
+
+		result.Detail = detail
+	}
+
+	return result, nil
+}
+
+// isMemoIndexStale reports whether the memo was updated after indexedAt.
+// Memos that can't be found aren't stale.
+func (s *APIV1Service) isMemoIndexStale(ctx context.Context, name string, indexedAt time.Time) (bool, error) {
+	memoUID, err := ExtractMemoUIDFromName(name)
+	if err != nil {
+		return false, nil
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID, ExcludeContent: true})
+	if err != nil {
+		return false, err
+	}
+	if memo == nil {
+		return false, nil
+	}
+	// UpdatedTs has a precision of seconds, so an index written in the same second isn't stale.
+	return indexedAt.Unix() < memo.UpdatedTs, nil
+}
+
+// AiSearch performs AI semantic search on memos.
+func (s *APIV1Service) AiSearch(ctx context.Context, request *v1pb.AiSearchRequest) (*v1pb.AiSearchResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	if err := checkAiRateLimit(ctx, user, "AiSearch", aiSetting.AiRequestsPerMinute); err != nil {
+		return nil, err
+	}
+	aiClient := s.newAIClient(aiSetting)
+
+	searchReq, err := newAiSearchRequest(ctx, user, aiClient, aiSetting, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// topK is the top_k of the request or of the AI setting, 0 if neither is set.
+	topK := searchReq.TopK
+	// Paging is only enabled when the caller asks for it; otherwise top_k bounds the whole result set.
+	paging := request.PageSize > 0 || request.PageToken != ""
+	queryHash := hashAiSearchQuery(searchReq, request.Scope)
+	pageSize, offset := 0, 0
+	limitReached := false
+	snapshotID := ""
+	// returnedMemoUIDs are the memos of earlier pages, whose later results are skipped.
+	var returnedMemoUIDs []string
+	if paging {
+		if request.PageToken != "" {
+			var pageToken v1pb.AiSearchPageToken
+			if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+				return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+			}
+			if pageToken.QueryHash != queryHash {
+				return nil, grpcstatus.Errorf(codes.InvalidArgument, "page token does not match the search query")
+			}
+			offset = int(pageToken.Offset)
+			snapshotID = pageToken.SnapshotId
+			returnedMemoUIDs = pageToken.ReturnedMemoUids
+		}
+		pageSize = int(request.PageSize)
+		if pageSize <= 0 {
+			pageSize = DefaultPageSize
+		}
+		if pageSize > MaxPageSize {
+			pageSize = MaxPageSize
+		}
+		// A memo may have several results, e.g. one for its text and one for an image, so fetch twice
+		// the page size to merge them, plus one result to know whether there is a next page.
+		searchReq.TopK = min(2*pageSize+1, maxAiSearchTopK)
+		limit := maxAiSearchResults
+		if topK > 0 {
+			limit = min(topK, maxAiSearchResults)
+		}
+		remaining := limit - offset
+		if remaining <= 0 {
+			return &v1pb.AiSearchResponse{
+				Results:    []*v1pb.AiSearchResult{},
+				Query:      request.Query,
+				SearchMode: searchReq.SearchMode,
+			}, nil
+		}
+		if remaining <= searchReq.TopK {
+			searchReq.TopK = remaining
+			limitReached = true
+		}
+		searchReq.Offset = offset
+	}
+
+	var resp *ai.SearchResponse
+	if snapshotID != "" || (paging && request.Snapshot) {
+		resp, snapshotID, err = s.searchAiSnapshot(ctx, aiClient, user.ID, searchReq, snapshotID, topK)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		resp, err = aiClient.Search(ctx, searchReq)
+		if err != nil {
+			return nil, aiErrorToStatus(fmt.Errorf("failed to search: %w", err))
+		}
+	}
+
+	nextPageToken := ""
+	if paging {
+		// Offsets count the results of the AI service, or of the snapshot, before merging, so the
+		// next page starts after the results the page consumed.
+		fetched := len(resp.Results)
+		page, pageMemoUIDs, consumed, cut := cutAiSearchPage(resp.Results, pageSize, returnedMemoUIDs)
+		resp.Results = page
+		if cut || (!limitReached && fetched >= searchReq.TopK) {
+			pageToken := &v1pb.AiSearchPageToken{
+				Offset:     int32(offset + consumed),
+				QueryHash:  queryHash,
+				SnapshotId: snapshotID,
+			}
+			// Snapshots hold one merged result per memo, so only pages of the AI service may meet a
+			// memo of an earlier page again.
+			if snapshotID == "" {
+				pageToken.ReturnedMemoUids = append(returnedMemoUIDs, pageMemoUIDs...)
+			}
+			nextPageToken, err = marshalPageToken(pageToken)
+			if err != nil {
+				return nil, grpcstatus.Errorf(codes.Internal, "failed to get next page token: %v", err)
+			}
+		}
+	}
+	// Snapshots are merged when cached, which makes this a no-op for them.
+	dedupeAiSearchResponse(resp)
+
+	// Filter out results missing a quoted phrase or a tag if the AI service couldn't do it.
+	// The date range is always re-checked in case the AI service ignored it.
+	// This may leave a page with fewer than page_size results.
+	postFilter := &aiSearchPostFilter{
+		createdAfter:  searchReq.CreatedAfter,
+		createdBefore: searchReq.CreatedBefore,
+		scope:         request.Scope,
+		userID:        user.ID,
+	}
+	if !resp.MustContainApplied {
+		postFilter.phrases = searchReq.MustContain
+	}
+	tagFilterMode := ""
+	if len(searchReq.Tags) > 0 {
+		tagFilterMode = "service"
+		if !resp.TagsApplied {
+			tagFilterMode = "post_filter"
+			postFilter.tags = searchReq.Tags
+		}
+	}
+	if !postFilter.isEmpty() {
+		resp.Results, err = s.filterAiSearchResults(ctx, resp.Results, postFilter)
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "failed to filter search results: %v", err)
+		}
+	}
+
+	memos, hidden, err := s.listAiSearchMemos(ctx, user, resp.Results)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	displayWithUpdateTime, err := s.aiSearchDisplayWithUpdateTime(ctx, request.IncludeMemo)
+	if err != nil {
+		return nil, err
+	}
+	terms := aiSearchTerms(searchReq)
+	results := make([]*v1pb.AiSearchResult, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		memoUID := aiSearchResultMemoUID(&r)
+		// The matched text of a memo the user can't see is as private as its content.
+		if hidden[memoUID] {
+			r.MatchedText = ""
+		}
+		memo := memos[memoUID]
+		content := ""
+		if memo != nil {
+			content = memo.Content
+		}
+		result := convertAiSearchResultToProto(&r, content, terms)
+		if request.IncludeMemo && memo != nil {
+			if result.Memo, err = s.convertAiSearchMemoInfo(memo, displayWithUpdateTime); err != nil {
+				return nil, grpcstatus.Errorf(codes.Internal, "failed to convert memo: %v", err)
+			}
+		}
+		if searchReq.Explain {
+			result.ScoreBreakdown = convertAiScoreBreakdown(r.ScoreBreakdown)
+		}
+		results = append(results, result)
+	}
+
+	return &v1pb.AiSearchResponse{
+		Results:       results,
+		Query:         resp.Query,
+		SearchMode:    resp.SearchMode,
+		TotalResults:  int32(resp.TotalResults),
+		NextPageToken: nextPageToken,
+		TagFilterMode: tagFilterMode,
+	}, nil
+}
+
+// searchAiSnapshot returns the page of searchReq from the ranked result snapshot with the given ID.
+// If snapshotID is empty, the full ranked result set is fetched from the AI service first and cached
+// as a new snapshot, whose ID is returned.
+func (s *APIV1Service) searchAiSnapshot(ctx context.Context, aiClient *ai.Client, userID int32, searchReq *ai.SearchRequest, snapshotID string, topK int) (*ai.SearchResponse, string, error) {
+	var snapshot *ai.SearchResponse
+	if snapshotID == "" {
+		snapshotReq := *searchReq
+		snapshotReq.TopK, snapshotReq.Offset = topK, 0
+		if snapshotReq.TopK <= 0 || snapshotReq.TopK > maxAiSearchSnapshotSize {
+			snapshotReq.TopK = maxAiSearchSnapshotSize
+		}
+		var err error
+		snapshot, err = aiClient.Search(ctx, &snapshotReq)
+		if err != nil {
+			return nil, "", aiErrorToStatus(fmt.Errorf("failed to search: %w", err))
+		}
+		dedupeAiSearchResponse(snapshot)
+		snapshotID = util.GenUUID()
+		aiSearchSnapshotCache.Set(ctx, aiSearchSnapshotKey(userID, snapshotID), snapshot)
+	} else {
+		cached, ok := aiSearchSnapshotCache.Get(ctx, aiSearchSnapshotKey(userID, snapshotID))
+		if ok {
+			snapshot, ok = cached.(*ai.SearchResponse)
+		}
+		if !ok {
+			return nil, "", grpcstatus.Errorf(codes.InvalidArgument, "search snapshot expired, restart the search")
+		}
+	}
+
+	// Copy the snapshot so paging and post-filtering don't modify the cached results.
+	resp := *snapshot
+	start := min(searchReq.Offset, len(snapshot.Results))
+	end := min(searchReq.Offset+searchReq.TopK, len(snapshot.Results))
+	resp.Results = slices.Clone(snapshot.Results[start:end])
+	return &resp, snapshotID, nil
+}
+
+func aiSearchSnapshotKey(userID int32, snapshotID string) string {
+	return fmt.Sprintf("%d/%s", userID, snapshotID)
+}
+
+// AiSearchStream performs AI semantic search on memos and streams the results one at a time.
+func (s *APIV1Service) AiSearchStream(request *v1pb.AiSearchRequest, stream grpc.ServerStreamingServer[v1pb.AiSearchResult]) error {
+	ctx := stream.Context()
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	// Streamed searches share the AiSearch limit.
+	if err := checkAiRateLimit(ctx, user, "AiSearch", aiSetting.AiRequestsPerMinute); err != nil {
+		return err
+	}
+	aiClient := s.newAIClient(aiSetting)
+
+	searchReq, err := newAiSearchRequest(ctx, user, aiClient, aiSetting, request)
+	if err != nil {
+		return err
+	}
+
+	// The stream carries no flags telling whether the AI service enforced quoted phrases and tags,
+	// so every constraint is re-checked against the store.
+	postFilter := &aiSearchPostFilter{
+		phrases:       searchReq.MustContain,
+		tags:          searchReq.Tags,
+		createdAfter:  searchReq.CreatedAfter,
+		createdBefore: searchReq.CreatedBefore,
+		scope:         request.Scope,
+		userID:        user.ID,
+	}
+	displayWithUpdateTime, err := s.aiSearchDisplayWithUpdateTime(ctx, request.IncludeMemo)
+	if err != nil {
+		return err
+	}
+	terms := aiSearchTerms(searchReq)
+	var sendErr error
+	err = aiClient.SearchStream(ctx, searchReq, func(r *ai.SearchResult) error {
+		// The memo is needed to post-filter the result, to check that its matched text may be shown,
+		// to compute its snippet or to embed it.
+		memoUID := aiSearchResultMemoUID(r)
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		if err != nil {
+			sendErr = grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+			return sendErr
+		}
+		if !postFilter.isEmpty() && (memo == nil || !postFilter.matches(memo)) {
+			return nil
+		}
+		if memo != nil && !isAiSearchMemoVisible(memo, user) {
+			hiddenResult := *r
+			hiddenResult.MatchedText = ""
+			r, memo = &hiddenResult, nil
+		}
+		content := ""
+		if memo != nil {
+			content = memo.Content
+		}
+		result := convertAiSearchResultToProto(r, content, terms)
+		if request.IncludeMemo && memo != nil {
+			if result.Memo, sendErr = s.convertAiSearchMemoInfo(memo, displayWithUpdateTime); sendErr != nil {
+				sendErr = grpcstatus.Errorf(codes.Internal, "failed to convert memo: %v", sendErr)
+				return sendErr
+			}
+		}
+		if searchReq.Explain {
+			result.ScoreBreakdown = convertAiScoreBreakdown(r.ScoreBreakdown)
+		}
+		sendErr = stream.Send(result)
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return aiErrorToStatus(fmt.Errorf("failed to search: %w", err))
+	}
+	return nil
+}
+
+// newAiSearchRequest builds the AI service search request shared by AiSearch and AiSearchStream.
+// The top_k, search mode and min_score the request leaves unset are taken from aiSetting. Those
+// unset there too are left unset, so that the AI client applies its built-in defaults.
+func newAiSearchRequest(ctx context.Context, user *store.User, aiClient *ai.Client, aiSetting *storepb.InstanceAiSetting, request *v1pb.AiSearchRequest) (*ai.SearchRequest, error) {
+	// Use current user as creator if not specified, unless searching beyond the user's own memos.
+	// Memos of other users are limited by the post filter.
+	creator := request.Creator
+	if creator != "" {
+		normalized, _, err := parseAiCreator(creator)
+		if err != nil {
+			return nil, err
+		}
+		creator = normalized
+	}
+	visibility := ""
+	switch request.Scope {
+	case v1pb.AiSearchRequest_SCOPE_UNSPECIFIED, v1pb.AiSearchRequest_SCOPE_OWN:
+		// Own scopes aren't post-filtered by visibility, so only admins may search other users' memos.
+		ownCreator := fmt.Sprintf("%s%d", UserNamePrefix, user.ID)
+		if creator == "" {
+			creator = ownCreator
+		} else if creator != ownCreator && !isSuperUser(user) {
+			return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+		}
+	case v1pb.AiSearchRequest_SCOPE_PUBLIC:
+		// Let the AI service skip non-public memos up front, the post filter still checks them.
+		visibility = store.Public.String()
+	case v1pb.AiSearchRequest_SCOPE_ALL_ACCESSIBLE:
+	default:
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid scope: %v", request.Scope)
+	}
+
+	if request.SearchMode != "" && !ai.IsValidSearchMode(request.SearchMode) {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid search mode %q: must be one of %s", request.SearchMode, strings.Join(ai.SearchModes, ", "))
+	}
+
+	searchReq := &ai.SearchRequest{
+		Query:      request.Query,
+		TopK:       int(request.TopK),
+		SearchMode: request.SearchMode,
+		MinScore:   request.MinScore,
+		Creator:    creator,
+		Visibility: visibility,
+	}
+	if searchReq.TopK == 0 {
+		searchReq.TopK = int(aiSetting.GetDefaultSearchTopK())
+	}
+	if searchReq.SearchMode == "" {
+		searchReq.SearchMode = aiSetting.GetDefaultSearchMode()
+	}
+	if searchReq.MinScore == 0 {
+		searchReq.MinScore = aiSetting.GetDefaultSearchMinScore()
+	}
+	if request.Model != "" {
+		if !isSuperUser(user) {
+			return nil, grpcstatus.Errorf(codes.PermissionDenied, "only admins can override the AI model")
+		}
+		searchReq.Model = resolveAiModel(ctx, aiClient, request.Model, (*ai.Capabilities).SupportsEmbeddingModel)
+	}
+	// Score breakdowns expose how the AI service ranks memos, so they're for debugging by admins only.
+	if request.Explain {
+		if !isSuperUser(user) {
+			return nil, grpcstatus.Errorf(codes.PermissionDenied, "only admins can explain search results")
+		}
+		searchReq.Explain = true
+	}
+	// Quoted phrases in the query must appear verbatim in the results.
+	searchReq.Query, searchReq.MustContain = parseAiSearchQuery(request.Query)
+	for _, tag := range request.Tags {
+		if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" {
+			searchReq.Tags = append(searchReq.Tags, tag)
+		}
+	}
+	if request.CreatedAfter != nil {
+		createdAfter := request.CreatedAfter.AsTime()
+		searchReq.CreatedAfter = &createdAfter
+	}
+	if request.CreatedBefore != nil {
+		createdBefore := request.CreatedBefore.AsTime()
+		searchReq.CreatedBefore = &createdBefore
+	}
+	if searchReq.CreatedAfter != nil && searchReq.CreatedBefore != nil && !searchReq.CreatedAfter.Before(*searchReq.CreatedBefore) {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "created_after must be before created_before")
+	}
+	return searchReq, nil
+}
+
+// parseAiCreator validates a creator of the form users/{id} passed to the AI service and returns it in
+// canonical form, e.g. "users/007" becomes "users/7", along with the user ID.
+func parseAiCreator(creator string) (string, int32, error) {
+	creatorID, err := ExtractUserIDFromName(creator)
+	if err != nil {
+		return "", 0, grpcstatus.Errorf(codes.InvalidArgument, "invalid creator: %v", err)
+	}
+	if creatorID <= 0 {
+		return "", 0, grpcstatus.Errorf(codes.InvalidArgument, "invalid creator: invalid user ID %d", creatorID)
+	}
+	return fmt.Sprintf("%s%d", UserNamePrefix, creatorID), creatorID, nil
+}
+
+// aiErrorToStatus converts an error returned by the AI client to a gRPC status error.
+func aiErrorToStatus(err error) error {
+	code := codes.Internal
+	var statusErr *ai.StatusError
+	var rateLimitedErr *ai.ErrRateLimited
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	// Checked before ErrUnavailable, which callers wrap transport errors in: the request was never sent.
+	case errors.Is(err, ai.ErrUnsafeServiceURL):
+		code = codes.FailedPrecondition
+	case errors.Is(err, ai.ErrUnavailable):
+		code = codes.Unavailable
+	case errors.As(err, &rateLimitedErr):
+		// The retry hint is passed on in the status details, so clients can back off accordingly.
+		st := grpcstatus.New(codes.ResourceExhausted, err.Error())
+		if rateLimitedErr.RetryAfter > 0 {
+			if withDetails, detailsErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(rateLimitedErr.RetryAfter)}); detailsErr == nil {
+				st = withDetails
+			}
+		}
+		return st.Err()
+	case errors.As(err, &statusErr):
+		code = aiHTTPStatusToCode(statusErr.StatusCode)
+	}
+	return grpcstatus.Error(code, err.Error())
+}
+
+// aiHTTPStatusToCode maps an HTTP status returned by the AI service to a gRPC code.
+func aiHTTPStatusToCode(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	// Auth failures and conflicts come from the AI service's own configuration or state,
+	// not from the caller's credentials.
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict, http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// resolveAiModel returns the requested model if the AI service advertises it, or an empty string
+// so the service falls back to its default model.
+func resolveAiModel(ctx context.Context, aiClient *ai.Client, model string, supports func(*ai.Capabilities, string) bool) string {
+	capabilities, err := aiClient.GetCapabilities(ctx)
+	if err != nil {
+		slog.Warn("Failed to get AI service capabilities, using the default model", slog.String("model", model), slog.Any("err", err))
+		return ""
+	}
+	if !supports(capabilities, model) {
+		slog.Warn("AI model is not supported by the AI service, using the default model", slog.String("model", model))
+		return ""
+	}
+	return model
+}
+
+// AiAsk answers a question about the current user's memos.
+func (s *APIV1Service) AiAsk(ctx context.Context, request *v1pb.AiAskRequest) (*v1pb.AiAskResponse, error) {
+	question := strings.TrimSpace(request.Question)
+	if question == "" {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "question is required")
+	}
+	if request.TopK < 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "top_k must not be negative")
+	}
+	topK := int(request.TopK)
+	if topK == 0 {
+		topK = defaultAiAskTopK
+	}
+	if topK > maxAiAskTopK {
+		topK = maxAiAskTopK
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	if err := checkAiRateLimit(ctx, user, "AiAsk", aiSetting.AiRequestsPerMinute); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.newAIClient(aiSetting).Ask(ctx, &ai.AskRequest{
+		Question: question,
+		Creator:  fmt.Sprintf("users/%d", user.ID),
+		TopK:     topK,
+	})
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to ask: %w", err))
+	}
+
+	citations, err := s.filterAiCitations(ctx, user, resp.Citations)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+
+	return &v1pb.AiAskResponse{
+		Answer:        util.SanitizeUTF8(resp.Answer),
+		CitedMemoUids: citations,
+		Usage:         convertTokenUsageToProto(&resp.Usage),
+	}, nil
+}
+
+// filterAiCitations returns the cited memo UIDs visible to user, deduplicated and in citation order.
+// Citations of missing memos are dropped.
+func (s *APIV1Service) filterAiCitations(ctx context.Context, user *store.User, uids []string) ([]string, error) {
+	citations := []string{}
+	if len(uids) == 0 {
+		return citations, nil
+	}
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		UIDList:        uids,
+		ExcludeContent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	visibleMemos := map[string]bool{}
+	for _, memo := range memos {
+		if memo.Visibility != store.Private || memo.CreatorID == user.ID {
+			visibleMemos[memo.UID] = true
+		}
+	}
+	for _, uid := range uids {
+		if visibleMemos[uid] && !slices.Contains(citations, uid) {
+			citations = append(citations, uid)
+		}
+	}
+	return citations, nil
+}
+
+// ListAiSearchModes lists the search modes accepted by AiSearch.
+func (s *APIV1Service) ListAiSearchModes(ctx context.Context, _ *v1pb.ListAiSearchModesRequest) (*v1pb.ListAiSearchModesResponse, error) {
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	defaultSearchMode := aiSetting.GetDefaultSearchMode()
+	if defaultSearchMode == "" {
+		defaultSearchMode = ai.SearchModeHybrid
+	}
+	return &v1pb.ListAiSearchModesResponse{
+		SearchModes:       slices.Clone(ai.SearchModes),
+		DefaultSearchMode: defaultSearchMode,
+	}, nil
+}
+
+// GetAiServiceConfig gets what the configured AI service supports. The config is cached for
+// aiServiceConfigTTL, so changes of the AI service show up with a delay.
+func (s *APIV1Service) GetAiServiceConfig(ctx context.Context, _ *v1pb.GetAiServiceConfigRequest) (*v1pb.AiServiceConfig, error) {
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+
+	key := ai.ResolveServiceURL(aiSetting.GetAiServiceUrl())
+	var config *ai.ServiceConfig
+	if cached, ok := aiServiceConfigCache.Get(ctx, key); ok {
+		config, _ = cached.(*ai.ServiceConfig)
+	}
+	if config == nil {
+		config, err = s.newAIClient(aiSetting).GetConfig(ctx)
+		if err != nil {
+			return nil, aiErrorToStatus(fmt.Errorf("failed to get AI service config: %w", err))
+		}
+		aiServiceConfigCache.Set(ctx, key, config)
+	}
+
+	// Only modes AiSearch accepts are usable, so the reported modes are narrowed to those.
+	searchModes := slices.Clone(ai.SearchModes)
+	if config.SearchModes != nil {
+		searchModes = slices.DeleteFunc(searchModes, func(mode string) bool {
+			return !slices.Contains(config.SearchModes, mode)
+		})
+	}
+	return &v1pb.AiServiceConfig{
+		SearchModes:          searchModes,
+		ImageIndexingEnabled: config.ImageIndexing,
+		MaxTags:              int32(config.MaxTags),
+		TagGenerationModel:   util.SanitizeUTF8(config.Models.TagGeneration),
+		TextEmbeddingModel:   util.SanitizeUTF8(config.Models.TextEmbedding),
+		ImageEmbeddingModel:  util.SanitizeUTF8(config.Models.ImageEmbedding),
+		ImageCaptionModel:    util.SanitizeUTF8(config.Models.ImageCaption),
+	}, nil
+}
+
+// GetRelatedMemos finds memos similar to a memo, limited to memos visible to the caller.
+func (s *APIV1Service) GetRelatedMemos(ctx context.Context, request *v1pb.GetRelatedMemosRequest) (*v1pb.GetRelatedMemosResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	if request.TopK < 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "top_k must not be negative")
+	}
+	topK := int(request.TopK)
+	if topK == 0 {
+		topK = defaultRelatedMemosTopK
+	}
+	if topK > maxRelatedMemosTopK {
+		topK = maxRelatedMemosTopK
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "memo not found")
+	}
+	if memo.Visibility == store.Private && memo.CreatorID != user.ID {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	aiClient, err := s.getAIClient(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+	}
+
+	// Ask for one more result since the source memo may be among them.
+	resp, err := aiClient.FindSimilar(ctx, memo.UID, topK+1)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to find similar memos: %w", err))
+	}
+
+	uids := make([]string, 0, len(resp.Results))
+	for i := range resp.Results {
+		if uid := aiSearchResultMemoUID(&resp.Results[i]); uid != memo.UID {
+			uids = append(uids, uid)
+		}
+	}
+	visibleMemos := map[string]bool{}
+	if len(uids) > 0 {
+		normalStatus := store.Normal
+		memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+			UIDList:        uids,
+			RowStatus:      &normalStatus,
+			ExcludeContent: true,
+		})
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "failed to list memos: %v", err)
+		}
+		for _, m := range memos {
+			if m.Visibility != store.Private || m.CreatorID == user.ID {
+				visibleMemos[m.UID] = true
+			}
+		}
+	}
+
+	results := make([]*v1pb.AiSearchResult, 0, topK)
+	for _, r := range resp.Results {
+		if len(results) == topK {
+			break
+		}
+		if uid := aiSearchResultMemoUID(&r); uid == memo.UID || !visibleMemos[uid] {
+			continue
+		}
+		results = append(results, convertAiSearchResultToProto(&r, "", nil))
+	}
+
+	return &v1pb.GetRelatedMemosResponse{
+		Results: results,
+	}, nil
+}
+
+var aiSearchPhraseRegexp = regexp.MustCompile(`"([^"]*)"`)
+
+// parseAiSearchQuery splits a search query into the semantic query and the quoted phrases that
+// results must contain. The phrase text is kept in the semantic query, e.g.
+// `deploy "E1234" failed` yields the query `deploy E1234 failed` and the phrase `E1234`.
+func parseAiSearchQuery(query string) (string, []string) {
+	var phrases []string
+	for _, match := range aiSearchPhraseRegexp.FindAllStringSubmatch(query, -1) {
+		if phrase := strings.TrimSpace(match[1]); phrase != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+	if len(phrases) == 0 {
+		return query, nil
+	}
+	semanticQuery := aiSearchPhraseRegexp.ReplaceAllString(query, " $1 ")
+	return strings.Join(strings.Fields(semanticQuery), " "), phrases
+}
+
+// aiSearchPostFilter is the set of constraints checked against the store after an AI search.
+type aiSearchPostFilter struct {
+	// phrases must all be contained in the memo content, ignoring case.
+	phrases []string
+	// tags must all be carried by the memo, either as a manual or an AI tag.
+	tags []string
+	// createdAfter and createdBefore limit the memo creation time to [createdAfter, createdBefore).
+	createdAfter  *time.Time
+	createdBefore *time.Time
+	// scope limits the memo visibility for the user with userID. Own scopes don't limit it.
+	scope  v1pb.AiSearchRequest_Scope
+	userID int32
+}
+
+func (f *aiSearchPostFilter) isEmpty() bool {
+	return len(f.phrases) == 0 && len(f.tags) == 0 && f.createdAfter == nil && f.createdBefore == nil && !f.limitsVisibility()
+}
+
+func (f *aiSearchPostFilter) limitsVisibility() bool {
+	return f.scope == v1pb.AiSearchRequest_SCOPE_PUBLIC || f.scope == v1pb.AiSearchRequest_SCOPE_ALL_ACCESSIBLE
+}
+
+func (f *aiSearchPostFilter) matches(memo *store.Memo) bool {
+	switch f.scope {
+	case v1pb.AiSearchRequest_SCOPE_PUBLIC:
+		if memo.Visibility != store.Public {
+			return false
+		}
+	case v1pb.AiSearchRequest_SCOPE_ALL_ACCESSIBLE:
+		if memo.Visibility != store.Public && memo.CreatorID != f.userID {
+			return false
+		}
+	}
+	if f.createdAfter != nil && memo.CreatedTs < f.createdAfter.Unix() {
+		return false
+	}
+	if f.createdBefore != nil && memo.CreatedTs >= f.createdBefore.Unix() {
+		return false
+	}
+	return memoContainsPhrases(memo, f.phrases) && memoHasTags(memo, f.tags)
+}
+
+// filterAiSearchResults keeps the results whose memo matches the post filter.
+func (s *APIV1Service) filterAiSearchResults(ctx context.Context, results []ai.SearchResult, postFilter *aiSearchPostFilter) ([]ai.SearchResult, error) {
+	if len(results) == 0 {
+		return results, nil
+	}
+	uids := make([]string, 0, len(results))
+	for _, r := range results {
+		uids = append(uids, aiSearchResultMemoUID(&r))
+	}
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{UIDList: uids})
+	if err != nil {
+		return nil, err
+	}
+	memosByUID := make(map[string]*store.Memo, len(memos))
+	for _, memo := range memos {
+		memosByUID[memo.UID] = memo
+	}
+
+	filtered := make([]ai.SearchResult, 0, len(results))
+	for _, r := range results {
+		memo, ok := memosByUID[aiSearchResultMemoUID(&r)]
+		if !ok {
+			continue
+		}
+		if postFilter.matches(memo) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+// aiSearchResultMemoUID returns the UID of the memo of r. The AI service reports the memo name,
+// e.g. "memos/abc", as its memo_uid, so a "memos/" prefix is stripped.
+func aiSearchResultMemoUID(r *ai.SearchResult) string {
+	return strings.TrimPrefix(r.MemoUID, MemoNamePrefix)
+}
+
+func memoContainsPhrases(memo *store.Memo, phrases []string) bool {
+	content := strings.ToLower(memo.Content)
+	for _, phrase := range phrases {
+		if !strings.Contains(content, strings.ToLower(phrase)) {
+			return false
+		}
+	}
+	return true
+}
+
+func memoHasTags(memo *store.Memo, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	if memo.Payload == nil {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.Contains(memo.Payload.Tags, tag) && !slices.Contains(memo.Payload.AiTags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// dedupeAiSearchResponse merges the results of resp with the same memo UID, which the AI service
+// returns when a memo matches on both a text chunk and an image, and lowers resp.TotalResults by
+// the number of merged results. The merged result takes the place of the first one, with the
+// fields of the highest scoring one and the distinct match types joined by "+", e.g. "image+text".
+func dedupeAiSearchResponse(resp *ai.SearchResponse) {
+	indexes := make(map[string]int, len(resp.Results))
+	matchTypes := make(map[string][]string, len(resp.Results))
+	results := make([]ai.SearchResult, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		if r.MatchType != "" && !slices.Contains(matchTypes[r.MemoUID], r.MatchType) {
+			matchTypes[r.MemoUID] = append(matchTypes[r.MemoUID], r.MatchType)
+		}
+		i, ok := indexes[r.MemoUID]
+		if !ok {
+			indexes[r.MemoUID] = len(results)
+			results = append(results, r)
+			continue
+		}
+		if r.IndexType != "" && results[i].IndexType != "" && r.IndexType != results[i].IndexType {
+			r.IndexType = "both"
+		}
+		if r.Score > results[i].Score {
+			results[i] = r
+		} else if r.IndexType == "both" {
+			results[i].IndexType = r.IndexType
+		}
+	}
+	if len(results) == len(resp.Results) {
+		return
+	}
+	for i := range results {
+		if types := matchTypes[results[i].MemoUID]; len(types) > 1 {
+			slices.Sort(types)
+			results[i].MatchType = strings.Join(types, "+")
+		}
+	}
+	resp.TotalResults = max(resp.TotalResults-(len(resp.Results)-len(results)), len(results))
+	resp.Results = results
+}
+
+// cutAiSearchPage returns the results of the first pageSize memos in results, in which a memo may
+// have several results, along with the UIDs of those memos and the number of results before the
+// first result of the next memo. If there is no next memo, all results are consumed and cut is
+// false. Later results of the page's memos are kept so they can be merged. Results of the memos
+// in returnedMemoUIDs, which earlier pages returned, are skipped.
+func cutAiSearchPage(results []ai.SearchResult, pageSize int, returnedMemoUIDs []string) (page []ai.SearchResult, memoUIDs []string, consumed int, cut bool) {
+	returned := make(map[string]bool, len(returnedMemoUIDs))
+	for _, uid := range returnedMemoUIDs {
+		returned[uid] = true
+	}
+	onPage := make(map[string]bool, pageSize)
+	consumed = len(results)
+	for i := range results {
+		uid := aiSearchResultMemoUID(&results[i])
+		if returned[uid] || onPage[uid] {
+			continue
+		}
+		if len(onPage) == pageSize {
+			consumed, cut = i, true
+			break
+		}
+		onPage[uid] = true
+		memoUIDs = append(memoUIDs, uid)
+	}
+	page = make([]ai.SearchResult, 0, len(results))
+	for i := range results {
+		if onPage[aiSearchResultMemoUID(&results[i])] {
+			page = append(page, results[i])
+		}
+	}
+	return page, memoUIDs, consumed, cut
+}
+
+// maxAiSearchSnippetLength is the maximum length of a search result snippet in runes.
+const maxAiSearchSnippetLength = 200
+
+// convertAiSearchResultToProto converts an AI search result. If the AI service reported no
+// matched text, the snippet is taken from content, which may be empty.
+func convertAiSearchResultToProto(r *ai.SearchResult, content string, terms []string) *v1pb.AiSearchResult {
+	snippet, highlights := aiSearchSnippet(r.MatchedText, content, terms)
+	return &v1pb.AiSearchResult{
+		MemoUid:        aiSearchResultMemoUID(r),
+		MemoName:       r.MemoName,
+		Score:          r.Score,
+		MatchType:      r.MatchType,
+		MatchedText:    util.SanitizeUTF8(r.MatchedText),
+		Snippet:        snippet,
+		Highlights:     highlights,
+		IndexType:      r.IndexType,
+		MatchedChunkId: r.MatchedChunkID,
+	}
+}
+
+// convertAiScoreBreakdown converts the score breakdown of an AI search result, which is nil if the
+// AI service didn't report one.
+func convertAiScoreBreakdown(breakdown *ai.ScoreBreakdown) *v1pb.AiSearchResult_ScoreBreakdown {
+	if breakdown == nil {
+		return nil
+	}
+	return &v1pb.AiSearchResult_ScoreBreakdown{
+		TextScore:    breakdown.TextScore,
+		ImageScore:   breakdown.ImageScore,
+		KeywordScore: breakdown.KeywordScore,
+		FinalScore:   breakdown.FinalScore,
+	}
+}
+
+// listAiSearchMemos returns the memos of results visible to user by memo UID, and the UIDs of the
+// memos user can't see, whose matched text must not be shown either. Both are keyed by the UIDs
+// returned by aiSearchResultMemoUID.
+func (s *APIV1Service) listAiSearchMemos(ctx context.Context, user *store.User, results []ai.SearchResult) (map[string]*store.Memo, map[string]bool, error) {
+	memos := map[string]*store.Memo{}
+	hidden := map[string]bool{}
+	if len(results) == 0 {
+		return memos, hidden, nil
+	}
+	uids := make([]string, 0, len(results))
+	for _, r := range results {
+		uids = append(uids, aiSearchResultMemoUID(&r))
+	}
+	list, err := s.Store.ListMemos(ctx, &store.FindMemo{UIDList: uids})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, memo := range list {
+		if isAiSearchMemoVisible(memo, user) {
+			memos[memo.UID] = memo
+		} else {
+			hidden[memo.UID] = true
+		}
+	}
+	return memos, hidden, nil
+}
+
+// isAiSearchMemoVisible reports whether the content of memo may be shown to user in search results.
+func isAiSearchMemoVisible(memo *store.Memo, user *store.User) bool {
+	return memo.Visibility != store.Private || memo.CreatorID == user.ID
+}
+
+// aiSearchDisplayWithUpdateTime reports whether the display time of memos embedded in search results
+// is their update time. It's only looked up if memos are embedded.
+func (s *APIV1Service) aiSearchDisplayWithUpdateTime(ctx context.Context, includeMemo bool) (bool, error) {
+	if !includeMemo {
+		return false, nil
+	}
+	memoRelatedSetting, err := s.Store.GetInstanceMemoRelatedSetting(ctx)
+	if err != nil {
+		return false, grpcstatus.Errorf(codes.Internal, "failed to get instance memo related setting: %v", err)
+	}
+	return memoRelatedSetting.DisplayWithUpdateTime, nil
+}
+
+// convertAiSearchMemoInfo converts memo to the info embedded in its search result.
+func (s *APIV1Service) convertAiSearchMemoInfo(memo *store.Memo, displayWithUpdateTime bool) (*v1pb.AiSearchResult_MemoInfo, error) {
+	displayTs := memo.CreatedTs
+	if displayWithUpdateTime {
+		displayTs = memo.UpdatedTs
+	}
+	snippet, err := s.getMemoContentSnippet(memo.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.AiSearchResult_MemoInfo{
+		DisplayTime: timestamppb.New(time.Unix(displayTs, 0)),
+		Snippet:     snippet,
+		Pinned:      memo.Pinned,
+		Visibility:  convertVisibilityFromStore(memo.Visibility),
+	}, nil
+}
+
+// aiSearchTerms returns the lowercased terms highlighted in search snippets:
+// the words of the semantic query and the quoted phrases.
+func aiSearchTerms(searchReq *ai.SearchRequest) []string {
+	terms := []string{}
+	for _, term := range append(strings.Fields(searchReq.Query), searchReq.MustContain...) {
+		term = strings.ToLower(term)
+		if !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// aiSearchSnippet returns the snippet of a search result and the ranges of it matching terms.
+// The snippet is the matched text if any, otherwise the line of content that best matches terms.
+// Long snippets are cut to a window starting shortly before the first match.
+func aiSearchSnippet(matchedText, content string, terms []string) (string, []*v1pb.AiSearchResult_HighlightRange) {
+	text := strings.TrimSpace(util.SanitizeUTF8(matchedText))
+	if text == "" {
+		text = bestMatchingLine(util.SanitizeUTF8(content), terms)
+	}
+	runes := []rune(text)
+	ranges := highlightRanges(runes, terms)
+	if len(runes) <= maxAiSearchSnippetLength {
+		return text, ranges
+	}
+
+	start := 0
+	if len(ranges) > 0 {
+		start = min(max(int(ranges[0].Start)-maxAiSearchSnippetLength/4, 0), len(runes)-maxAiSearchSnippetLength)
+	}
+	end := start + maxAiSearchSnippetLength
+	windowRanges := []*v1pb.AiSearchResult_HighlightRange{}
+	for _, r := range ranges {
+		if rangeStart, rangeEnd := max(int(r.Start), start), min(int(r.End), end); rangeStart < rangeEnd {
+			windowRanges = append(windowRanges, &v1pb.AiSearchResult_HighlightRange{
+				Start: int32(rangeStart - start),
+				End:   int32(rangeEnd - start),
+			})
+		}
+	}
+	return string(runes[start:end]), windowRanges
+}
+
+// bestMatchingLine returns the line of content containing the most terms,
+// or the first non-blank line if no line contains any.
+func bestMatchingLine(content string, terms []string) string {
+	best, bestCount := "", 0
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if best == "" {
+			best = line
+		}
+		lowerLine := strings.ToLower(line)
+		count := 0
+		for _, term := range terms {
+			if strings.Contains(lowerLine, term) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = line, count
+		}
+	}
+	return best
+}
+
+// highlightRanges returns the merged ranges of text matching any of terms, ignoring case.
+func highlightRanges(text []rune, terms []string) []*v1pb.AiSearchResult_HighlightRange {
+	// Runes are lowercased one by one so that offsets in the lowercased text match text.
+	lowerText := lowerRunes(string(text))
+	matched := make([]bool, len(text))
+	for _, term := range terms {
+		lowerTerm := lowerRunes(term)
+		if len(lowerTerm) == 0 {
+			continue
+		}
+		for i := 0; i+len(lowerTerm) <= len(lowerText); i++ {
+			if slices.Equal(lowerText[i:i+len(lowerTerm)], lowerTerm) {
+				for j := i; j < i+len(lowerTerm); j++ {
+					matched[j] = true
+				}
+			}
+		}
+	}
+
+	ranges := []*v1pb.AiSearchResult_HighlightRange{}
+	for i := 0; i < len(matched); {
+		if !matched[i] {
+			i++
+			continue
+		}
+		end := i
+		for end < len(matched) && matched[end] {
+			end++
+		}
+		ranges = append(ranges, &v1pb.AiSearchResult_HighlightRange{Start: int32(i), End: int32(end)})
+		i = end
+	}
+	return ranges
+}
+
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// hashAiSearchQuery returns a stable hash of the parameters that define an AI search result set.
+// It is embedded in page tokens so a token issued for one query can't be replayed against another.
+func hashAiSearchQuery(searchReq *ai.SearchRequest, scope v1pb.AiSearchRequest_Scope) string {
+	// Paging fields don't change the result set.
+	key := struct {
+		ai.SearchRequest
+		Scope v1pb.AiSearchRequest_Scope `json:"scope,omitempty"`
+	}{*searchReq, scope}
+	key.TopK, key.Offset = 0, 0
+	data, _ := json.Marshal(&key)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// RebuildIndex rebuilds all memo indexes for a user.
+func (s *APIV1Service) RebuildIndex(ctx context.Context, request *v1pb.RebuildIndexRequest) (*v1pb.RebuildIndexResponse, error) {
+	creator, creatorID, err := parseAiCreator(request.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if creatorID != user.ID && !isSuperUser(user) {
 		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	// Get attachments
-	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
-		MemoID: &memo.ID,
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	if request.CallbackUrl != "" {
+		if err := ai.ValidateCallbackURL(ctx, request.CallbackUrl, aiSetting.AllowInternalCallbackUrls); err != nil {
+			return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid callback url: %v", err)
+		}
+	}
+	aiClient := s.newAIClient(aiSetting)
+
+	resp, err := aiClient.RebuildIndex(ctx, &ai.RebuildIndexRequest{
+		Creator:       creator,
+		ExcludePublic: aiSetting.ExcludePublicMemos,
+		CallbackURL:   request.CallbackUrl,
+		// Passed on so the AI service applies the same check to the address it connects to.
+		AllowInternalCallback: aiSetting.AllowInternalCallbackUrls,
 	})
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to list attachments: %v", err)
+		return nil, aiErrorToStatus(fmt.Errorf("failed to rebuild index: %w", err))
 	}
 
-	// Convert memo to the format expected by AI service
-	memoForAI := s.convertMemoForAI(ctx, memo, attachments)
+	return &v1pb.RebuildIndexResponse{
+		Creator:    resp.Creator,
+		Status:     resp.Status,
+		TotalMemos: int32(resp.TotalMemos),
+		Timestamp:  resp.Timestamp,
+	}, nil
+}
 
-	aiClient, err := s.getAIClient(ctx)
+// VerifyIndex checks whether the AI index of a user matches their memos without rebuilding it.
+func (s *APIV1Service) VerifyIndex(ctx context.Context, request *v1pb.VerifyIndexRequest) (*v1pb.VerifyIndexResponse, error) {
+	creator, creatorID, err := parseAiCreator(request.Creator)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+		return nil, err
 	}
 
-	resp, err := aiClient.IndexMemo(ctx, memoForAI)
+	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to index memo: %v", err)
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if creatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	return &v1pb.IndexMemoResponse{
-		MemoUid:   resp.MemoUID,
-		Status:    resp.Status,
-		Timestamp: resp.Timestamp,
-	}, nil
-}
-
-// convertMemoForAI converts a memo to the format expected by the AI service.
-func (s *APIV1Service) convertMemoForAI(ctx context.Context, memo *store.Memo, attachments []*store.Attachment) map[string]interface{} {
-	// Build attachments list
-	attList := make([]map[string]interface{}, 0, len(attachments))
-	for _, att := range attachments {
-		attForAI := map[string]interface{}{
-			"name":     att.UID,
-			"filename": att.Filename,
-			"type":     att.Type,
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
+	}
+	normalStatus := store.Normal
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		CreatorID: &creatorID,
+		RowStatus: &normalStatus,
+	})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	contentHashes := make(map[string]string, len(memos))
+	maxContentChars, indexMode := aiMaxContentChars(aiSetting), aiIndexMode(aiSetting)
+	for _, memo := range memos {
+		indexable, err := s.isMemoAiIndexable(ctx, memo, aiSetting)
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo creator: %v", err)
 		}
-
-		// Use presigned URL for S3 and external links
-		if att.StorageType == storepb.AttachmentStorageType_S3 || att.StorageType == storepb.AttachmentStorageType_EXTERNAL {
-			attForAI["externalLink"] = att.Reference
-		} else {
-			// For local/database storage, use base64 data URL
-			fullAtt, err := s.Store.GetAttachment(ctx, &store.FindAttachment{
-				ID:      &att.ID,
-				GetBlob: true,
-			})
-			if err == nil && fullAtt != nil {
-				blob, err := s.GetAttachmentBlob(fullAtt)
-				if err == nil {
-					attForAI["externalLink"] = fmt.Sprintf("data:%s;base64,%s", att.Type, base64.StdEncoding.EncodeToString(blob))
-				}
-			}
+		if indexable {
+			contentHashes[memo.UID] = ai.ContentHash(aiIndexedContent(memo, maxContentChars, indexMode))
 		}
-
-		attList = append(attList, attForAI)
 	}
 
-	// Get tags
-	tags := []string{}
-	aiTags := []string{}
-	if memo.Payload != nil {
-		if memo.Payload.Tags != nil {
-			tags = memo.Payload.Tags
-		}
-		if memo.Payload.AiTags != nil {
-			aiTags = memo.Payload.AiTags
-		}
+	aiClient, err := s.getAIClient(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+	}
+	resp, err := aiClient.GetIndexChecksum(ctx, creator)
+	if err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to get index checksum: %w", err))
 	}
 
-	return map[string]interface{}{
-		"name":        fmt.Sprintf("memos/%s", memo.UID),
-		"uid":         memo.UID,
-		"content":     memo.Content,
-		"creator":     fmt.Sprintf("users/%d", memo.CreatorID),
-		"createTime":  time.Unix(memo.CreatedTs, 0).Format(time.RFC3339),
-		"updateTime":  time.Unix(memo.UpdatedTs, 0).Format(time.RFC3339),
-		"displayTime": time.Unix(memo.CreatedTs, 0).Format(time.RFC3339),
-		"tags":        tags,
-		"aiTags":      aiTags,
-		"attachments": attList,
+	checksum := ai.IndexChecksum(contentHashes)
+	response := &v1pb.VerifyIndexResponse{
+		Creator:       creator,
+		Match:         checksum == resp.Checksum,
+		Checksum:      checksum,
+		IndexChecksum: resp.Checksum,
+	}
+	if !response.Match {
+		response.MismatchedMemoUids = diffIndexedMemos(contentHashes, resp.Memos)
 	}
+	return response, nil
 }
 
-// DeleteMemoIndex deletes the index of a memo.
-func (s *APIV1Service) DeleteMemoIndex(ctx context.Context, request *v1pb.DeleteMemoIndexRequest) (*v1pb.DeleteMemoIndexResponse, error) {
-	memoUID, err := ExtractMemoUIDFromName(request.Name)
+// DeleteCreatorIndex deletes the AI indexes of all memos of a user.
+func (s *APIV1Service) DeleteCreatorIndex(ctx context.Context, request *v1pb.DeleteCreatorIndexRequest) (*v1pb.DeleteCreatorIndexResponse, error) {
+	creator, creatorID, err := parseAiCreator(request.Creator)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+		return nil, err
 	}
 
 	user, err := s.GetCurrentUser(ctx)
@@ -279,24 +2748,41 @@ func (s *APIV1Service) DeleteMemoIndex(ctx context.Context, request *v1pb.Delete
 	if user == nil {
 		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
+	if creatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
 
 	aiClient, err := s.getAIClient(ctx)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
 	}
-
-	err = aiClient.DeleteMemoIndex(ctx, memoUID)
+	if err := aiClient.DeleteCreatorIndex(ctx, creator); err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to delete creator index: %w", err))
+	}
+	// None of the creator's memos are indexed anymore, so the next IndexMemo of each must send it.
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{CreatorID: &creatorID, ExcludeContent: true})
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to delete memo index: %v", err)
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	for _, memo := range memos {
+		if memo.Payload.GetAiIndexHash() != "" {
+			s.saveMemoAiIndexHash(ctx, memo, "")
+		}
 	}
 
-	return &v1pb.DeleteMemoIndexResponse{
+	return &v1pb.DeleteCreatorIndexResponse{
 		Success: true,
 	}, nil
 }
 
-// GetMemoIndexInfo gets the index info of a memo.
-func (s *APIV1Service) GetMemoIndexInfo(ctx context.Context, request *v1pb.GetMemoIndexInfoRequest) (*v1pb.MemoIndexInfo, error) {
+// ListIndexedMemos lists the memos the AI service has indexed for a user, to reconcile the index
+// with the store. Only admins can list them.
+func (s *APIV1Service) ListIndexedMemos(ctx context.Context, request *v1pb.ListIndexedMemosRequest) (*v1pb.ListIndexedMemosResponse, error) {
+	creator, _, err := parseAiCreator(request.Creator)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
@@ -304,61 +2790,36 @@ func (s *APIV1Service) GetMemoIndexInfo(ctx context.Context, request *v1pb.GetMe
 	if user == nil {
 		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
+	if !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
 
 	aiClient, err := s.getAIClient(ctx)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
 	}
-
-	info, err := aiClient.GetMemoIndexInfo(ctx, request.Name, request.IncludeDetail)
+	memoUIDs, nextPageToken, err := aiClient.ListIndexedMemos(ctx, creator, request.PageToken)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo index info: %v", err)
-	}
-	if info == nil {
-		return &v1pb.MemoIndexInfo{
-			MemoUid: request.Name,
-			Indexed: false,
-		}, nil
-	}
-
-	result := &v1pb.MemoIndexInfo{
-		MemoUid:      info.MemoUID,
-		Indexed:      info.Indexed,
-		TextVectors:  int32(info.TextCount),
-		ImageVectors: int32(info.ImageCount),
+		return nil, aiErrorToStatus(fmt.Errorf("failed to list indexed memos: %w", err))
 	}
 
-	// Add detail if requested and available
-	if request.IncludeDetail && info.Detail != nil {
-		detail := &v1pb.MemoIndexDetail{
-			TextChunks: make([]*v1pb.TextChunk, 0, len(info.Detail.TextChunks)),
-			Images:     make([]*v1pb.ImageInfo, 0, len(info.Detail.Images)),
-		}
-
-		for _, tc := range info.Detail.TextChunks {
-			detail.TextChunks = append(detail.TextChunks, &v1pb.TextChunk{
-				DocId:       tc.DocID,
-				Content:     tc.Content,
-				ContentType: tc.ContentType,
-			})
-		}
-
-		for _, img := range info.Detail.Images {
-			detail.Images = append(detail.Images, &v1pb.ImageInfo{
-				DocId:    img.DocID,
-				Filename: img.Filename,
-				Caption:  img.Caption,
-			})
-		}
+	// Always return a non-nil slice so clients see an empty list rather than null.
+	return &v1pb.ListIndexedMemosResponse{
+		MemoUids:      append([]string{}, memoUIDs...),
+		NextPageToken: nextPageToken,
+	}, nil
+}
 
-		result.Detail = detail
+// ReconcileIndex syncs the AI index of a user with their memos. Indexable memos missing from the index
+// are indexed, and indexes of memos that are no longer stored or indexable are deleted, except those
+// already waiting for their deletion grace period. Memos are processed concurrently, and a memo that
+// fails is reported without stopping the others.
+func (s *APIV1Service) ReconcileIndex(ctx context.Context, request *v1pb.ReconcileIndexRequest) (*v1pb.ReconcileIndexResponse, error) {
+	creator, creatorID, err := parseAiCreator(request.Creator)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
-}
-
-// AiSearch performs AI semantic search on memos.
-func (s *APIV1Service) AiSearch(ctx context.Context, request *v1pb.AiSearchRequest) (*v1pb.AiSearchResponse, error) {
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
@@ -366,79 +2827,196 @@ func (s *APIV1Service) AiSearch(ctx context.Context, request *v1pb.AiSearchReque
 	if user == nil {
 		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
+	if creatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
 
-	aiClient, err := s.getAIClient(ctx)
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI settings: %v", err)
 	}
-
-	// Use current user as creator if not specified
-	creator := request.Creator
-	if creator == "" {
-		creator = fmt.Sprintf("users/%d", user.ID)
+	normalStatus := store.Normal
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		CreatorID: &creatorID,
+		RowStatus: &normalStatus,
+	})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	storedMemos := make(map[string]*store.Memo, len(memos))
+	for _, memo := range memos {
+		storedMemos[memo.UID] = memo
 	}
 
-	searchReq := &ai.SearchRequest{
-		Query:      request.Query,
-		TopK:       int(request.TopK),
-		SearchMode: request.SearchMode,
-		MinScore:   request.MinScore,
-		Creator:    creator,
+	aiClient := s.newAIClient(aiSetting)
+	indexed := make(map[string]bool)
+	pageToken := ""
+	for {
+		memoUIDs, nextPageToken, err := aiClient.ListIndexedMemos(ctx, creator, pageToken)
+		if err != nil {
+			return nil, aiErrorToStatus(fmt.Errorf("failed to list indexed memos: %w", err))
+		}
+		for _, uid := range memoUIDs {
+			indexed[uid] = true
+		}
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
 	}
 
-	resp, err := aiClient.Search(ctx, searchReq)
-	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to search: %v", err)
+	response := &v1pb.ReconcileIndexResponse{
+		Creator:  creator,
+		Failures: []*v1pb.ReconcileIndexResponse_Failure{},
+	}
+	// Stored memos to index, and UIDs of orphaned indexes to delete.
+	var missing []*store.Memo
+	var orphaned []string
+	for _, memo := range memos {
+		indexable, err := s.isMemoAiIndexable(ctx, memo, aiSetting)
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo creator: %v", err)
+		}
+		if !indexable {
+			continue
+		}
+		if indexed[memo.UID] {
+			response.Unchanged++
+		} else {
+			missing = append(missing, memo)
+		}
+	}
+	for uid := range indexed {
+		if memo, ok := storedMemos[uid]; ok {
+			indexable, err := s.isMemoAiIndexable(ctx, memo, aiSetting)
+			if err != nil {
+				return nil, grpcstatus.Errorf(codes.Internal, "failed to get memo creator: %v", err)
+			}
+			if indexable {
+				continue
+			}
+		}
+		if !isMemoIndexDeletionPending(uid) {
+			orphaned = append(orphaned, uid)
+		}
 	}
 
-	results := make([]*v1pb.AiSearchResult, 0, len(resp.Results))
-	for _, r := range resp.Results {
-		results = append(results, &v1pb.AiSearchResult{
-			MemoUid:   r.MemoUID,
-			MemoName:  r.MemoName,
-			Score:     r.Score,
-			MatchType: r.MatchType,
-		})
+	// Each job indexes a missing memo, or deletes an orphaned index if memo is nil.
+	type reconcileJob struct {
+		memoUID string
+		memo    *store.Memo
+	}
+	jobs := make(chan reconcileJob)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < min(reconcileIndexWorkers, len(missing)+len(orphaned)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				var err error
+				if job.memo != nil {
+					err = s.reconcileMissingMemo(ctx, aiClient, aiSetting, job.memo)
+				} else {
+					err = s.reconcileOrphanedIndex(ctx, aiClient, job.memoUID, storedMemos[job.memoUID])
+				}
+				mu.Lock()
+				switch {
+				case err != nil:
+					response.Failures = append(response.Failures, &v1pb.ReconcileIndexResponse_Failure{
+						MemoUid: job.memoUID,
+						Error:   err.Error(),
+					})
+				case job.memo != nil:
+					response.Added++
+				default:
+					response.Removed++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, memo := range missing {
+		jobs <- reconcileJob{memoUID: memo.UID, memo: memo}
 	}
+	for _, uid := range orphaned {
+		jobs <- reconcileJob{memoUID: uid}
+	}
+	close(jobs)
+	wg.Wait()
 
-	return &v1pb.AiSearchResponse{
-		Results:      results,
-		Query:        resp.Query,
-		SearchMode:   resp.SearchMode,
-		TotalResults: int32(resp.TotalResults),
-	}, nil
+	slices.SortFunc(response.Failures, func(a, b *v1pb.ReconcileIndexResponse_Failure) int {
+		return strings.Compare(a.MemoUid, b.MemoUid)
+	})
+	return response, nil
 }
 
-// RebuildIndex rebuilds all memo indexes for a user.
-func (s *APIV1Service) RebuildIndex(ctx context.Context, request *v1pb.RebuildIndexRequest) (*v1pb.RebuildIndexResponse, error) {
-	user, err := s.GetCurrentUser(ctx)
+// reconcileMissingMemo indexes a memo found missing from the AI index by ReconcileIndex.
+func (s *APIV1Service) reconcileMissingMemo(ctx context.Context, aiClient *ai.Client, aiSetting *storepb.InstanceAiSetting, memo *store.Memo) error {
+	// Once the context is done, the remaining memos fail without a request.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		MemoID: &memo.ID,
+	})
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+		return fmt.Errorf("failed to list attachments: %w", err)
 	}
-	if user == nil {
-		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	memoForAI := s.convertMemoForAI(ctx, memo, attachments, aiMaxAttachments(aiSetting), aiMaxAttachmentSize(aiSetting), aiMaxContentChars(aiSetting), aiIndexMode(aiSetting))
+	resp, err := aiClient.IndexMemoWithOperation(ctx, memoForAI, ai.IndexOperationUpsert)
+	if err != nil {
+		return fmt.Errorf("failed to index memo: %w", err)
 	}
+	s.recordMemoAiIndexed(ctx, memo, ai.HashMemoDocument(memoForAI), resp)
+	return nil
+}
 
-	aiClient, err := s.getAIClient(ctx)
-	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+// reconcileOrphanedIndex deletes the index of a memo found orphaned by ReconcileIndex. The memo is nil
+// if it is no longer stored.
+func (s *APIV1Service) reconcileOrphanedIndex(ctx context.Context, aiClient *ai.Client, memoUID string, memo *store.Memo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := aiClient.DeleteMemoIndex(ctx, memoUID); err != nil {
+		return fmt.Errorf("failed to delete memo index: %w", err)
 	}
+	if memo != nil {
+		s.saveMemoAiIndexHash(ctx, memo, "")
+	}
+	return nil
+}
 
-	resp, err := aiClient.RebuildIndex(ctx, request.Creator)
-	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to rebuild index: %v", err)
+// diffIndexedMemos returns the sorted UIDs of memos whose content hash differs between the store and the index,
+// including memos present on only one side.
+func diffIndexedMemos(contentHashes map[string]string, indexed []ai.IndexedMemo) []string {
+	indexedHashes := make(map[string]string, len(indexed))
+	for _, memo := range indexed {
+		indexedHashes[memo.MemoUID] = memo.ContentHash
 	}
 
-	return &v1pb.RebuildIndexResponse{
-		Creator:    resp.Creator,
-		Status:     resp.Status,
-		TotalMemos: int32(resp.TotalMemos),
-		Timestamp:  resp.Timestamp,
-	}, nil
+	mismatched := []string{}
+	for uid, hash := range contentHashes {
+		if indexedHash, ok := indexedHashes[uid]; !ok || indexedHash != hash {
+			mismatched = append(mismatched, uid)
+		}
+	}
+	for uid := range indexedHashes {
+		if _, ok := contentHashes[uid]; !ok {
+			mismatched = append(mismatched, uid)
+		}
+	}
+	slices.Sort(mismatched)
+	return mismatched
 }
 
 // GetRebuildStatus gets the rebuild index task status.
 func (s *APIV1Service) GetRebuildStatus(ctx context.Context, request *v1pb.GetRebuildStatusRequest) (*v1pb.RebuildTaskStatus, error) {
+	creator, creatorID, err := parseAiCreator(request.Creator)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
@@ -446,26 +3024,26 @@ func (s *APIV1Service) GetRebuildStatus(ctx context.Context, request *v1pb.GetRe
 	if user == nil {
 		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
+	if creatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
 
 	aiClient, err := s.getAIClient(ctx)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
 	}
 
-	// URL encode the creator parameter
-	creator := url.PathEscape(request.Creator)
-
 	taskStatus, err := aiClient.GetRebuildStatus(ctx, creator)
 	if err != nil {
-		return nil, grpcstatus.Errorf(codes.Internal, "failed to get rebuild status: %v", err)
+		return nil, aiErrorToStatus(fmt.Errorf("failed to get rebuild status: %w", err))
 	}
 	if taskStatus == nil {
 		return &v1pb.RebuildTaskStatus{
-			Status: "not_found",
+			Status: ai.RebuildStatusNotFound,
 		}, nil
 	}
 
-	return &v1pb.RebuildTaskStatus{
+	result := &v1pb.RebuildTaskStatus{
 		Status:     taskStatus.Status,
 		StartedAt:  taskStatus.StartedAt,
 		FinishedAt: taskStatus.FinishedAt,
@@ -473,6 +3051,45 @@ func (s *APIV1Service) GetRebuildStatus(ctx context.Context, request *v1pb.GetRe
 		Completed:  int32(taskStatus.Completed),
 		Failed:     int32(taskStatus.Failed),
 		Error:      taskStatus.Error,
+	}
+	for _, failed := range taskStatus.FailedMemos {
+		result.FailedMemos = append(result.FailedMemos, &v1pb.RebuildTaskStatus_FailedMemo{
+			MemoUid: failed.MemoUID,
+			Error:   util.SanitizeUTF8(failed.Error),
+		})
+	}
+	return result, nil
+}
+
+// CancelRebuild cancels the running rebuild index task of a user.
+// Cancelling a task that has finished or doesn't exist succeeds too.
+func (s *APIV1Service) CancelRebuild(ctx context.Context, request *v1pb.CancelRebuildRequest) (*v1pb.CancelRebuildResponse, error) {
+	creator, creatorID, err := parseAiCreator(request.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, grpcstatus.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if creatorID != user.ID && !isSuperUser(user) {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	aiClient, err := s.getAIClient(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "failed to get AI client: %v", err)
+	}
+	if err := aiClient.CancelRebuild(ctx, creator); err != nil {
+		return nil, aiErrorToStatus(fmt.Errorf("failed to cancel rebuild: %w", err))
+	}
+
+	return &v1pb.CancelRebuildResponse{
+		Success: true,
 	}, nil
 }
 
@@ -485,8 +3102,18 @@ func (s *APIV1Service) AiHealthCheck(ctx context.Context, request *v1pb.AiHealth
 		}, nil
 	}
 
-	healthy, _ := aiClient.HealthCheck(ctx)
+	health, err := aiClient.HealthCheckDetailed(ctx)
+	if err != nil {
+		// Service is not reachable
+		return &v1pb.AiHealthCheckResponse{
+			Healthy: false,
+		}, nil
+	}
 	return &v1pb.AiHealthCheckResponse{
-		Healthy: healthy,
+		Healthy:        health.Healthy,
+		Status:         util.SanitizeUTF8(health.Status),
+		Version:        util.SanitizeUTF8(health.Version),
+		EmbeddingModel: util.SanitizeUTF8(health.EmbeddingModel),
+		VectorStoreOk:  health.VectorStoreOK,
 	}, nil
 }
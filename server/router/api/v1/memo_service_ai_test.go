@@ -0,0 +1,403 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/ai"
+	"github.com/usememos/memos/store"
+)
+
+func TestAiErrorToStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{
+			name:     "service unreachable",
+			err:      fmt.Errorf("failed to send request: %w: %w", ai.ErrUnavailable, errors.New("connection refused")),
+			expected: codes.Unavailable,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("failed to send request: %w: %w", ai.ErrUnavailable, context.DeadlineExceeded),
+			expected: codes.DeadlineExceeded,
+		},
+		{
+			name:     "canceled",
+			err:      fmt.Errorf("failed to send request: %w: %w", ai.ErrUnavailable, context.Canceled),
+			expected: codes.Canceled,
+		},
+		{
+			name:     "bad request",
+			err:      &ai.StatusError{StatusCode: http.StatusBadRequest},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "unprocessable entity",
+			err:      &ai.StatusError{StatusCode: http.StatusUnprocessableEntity},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "not found",
+			err:      &ai.StatusError{StatusCode: http.StatusNotFound},
+			expected: codes.NotFound,
+		},
+		{
+			name:     "rate limited",
+			err:      &ai.StatusError{StatusCode: http.StatusTooManyRequests},
+			expected: codes.ResourceExhausted,
+		},
+		{
+			name:     "unauthorized",
+			err:      &ai.StatusError{StatusCode: http.StatusUnauthorized},
+			expected: codes.FailedPrecondition,
+		},
+		{
+			name:     "forbidden",
+			err:      &ai.StatusError{StatusCode: http.StatusForbidden},
+			expected: codes.FailedPrecondition,
+		},
+		{
+			name:     "conflict",
+			err:      &ai.StatusError{StatusCode: http.StatusConflict},
+			expected: codes.FailedPrecondition,
+		},
+		{
+			name:     "service unavailable",
+			err:      &ai.StatusError{StatusCode: http.StatusServiceUnavailable},
+			expected: codes.Unavailable,
+		},
+		{
+			name:     "gateway timeout",
+			err:      &ai.StatusError{StatusCode: http.StatusGatewayTimeout},
+			expected: codes.Unavailable,
+		},
+		{
+			name:     "internal server error",
+			err:      &ai.StatusError{StatusCode: http.StatusInternalServerError},
+			expected: codes.Internal,
+		},
+		{
+			name:     "wrapped status error",
+			err:      fmt.Errorf("failed to search: %w", &ai.StatusError{StatusCode: http.StatusNotFound}),
+			expected: codes.NotFound,
+		},
+		{
+			name:     "untyped error",
+			err:      errors.New("AI service error: model overloaded"),
+			expected: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := aiErrorToStatus(tt.err)
+			require.Equal(t, tt.expected, grpcstatus.Code(err))
+			require.Equal(t, tt.err.Error(), grpcstatus.Convert(err).Message())
+		})
+	}
+}
+
+func TestAiErrorToStatusRetryInfo(t *testing.T) {
+	statusErr := &ai.StatusError{StatusCode: http.StatusTooManyRequests, Message: "rate limit reached"}
+
+	err := aiErrorToStatus(fmt.Errorf("failed to search: %w", &ai.ErrRateLimited{RetryAfter: 30 * time.Second, Err: statusErr}))
+	require.Equal(t, codes.ResourceExhausted, grpcstatus.Code(err))
+	details := grpcstatus.Convert(err).Details()
+	require.Len(t, details, 1)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.Equal(t, 30*time.Second, retryInfo.RetryDelay.AsDuration())
+
+	// Without a hint, there are no details.
+	err = aiErrorToStatus(&ai.ErrRateLimited{Err: statusErr})
+	require.Equal(t, codes.ResourceExhausted, grpcstatus.Code(err))
+	require.Empty(t, grpcstatus.Convert(err).Details())
+}
+
+func TestParseAiSearchQuery(t *testing.T) {
+	tests := []struct {
+		query           string
+		expectedQuery   string
+		expectedPhrases []string
+	}{
+		{
+			query:         "deploy failed",
+			expectedQuery: "deploy failed",
+		},
+		{
+			query:           `deploy "E1234" failed`,
+			expectedQuery:   "deploy E1234 failed",
+			expectedPhrases: []string{"E1234"},
+		},
+		{
+			query:           `"connection reset" "port 8080"`,
+			expectedQuery:   "connection reset port 8080",
+			expectedPhrases: []string{"connection reset", "port 8080"},
+		},
+		{
+			query:         `empty "" phrase`,
+			expectedQuery: `empty "" phrase`,
+		},
+		{
+			query:         `unterminated "quote`,
+			expectedQuery: `unterminated "quote`,
+		},
+	}
+
+	for _, tt := range tests {
+		query, phrases := parseAiSearchQuery(tt.query)
+		require.Equal(t, tt.expectedQuery, query, tt.query)
+		require.Equal(t, tt.expectedPhrases, phrases, tt.query)
+	}
+}
+
+func TestAiSearchSnippet(t *testing.T) {
+	t.Run("falls back to the first line", func(t *testing.T) {
+		snippet, highlights := aiSearchSnippet("", "\n  first line  \nsecond line", []string{"missing"})
+		require.Equal(t, "first line", snippet)
+		require.Empty(t, highlights)
+	})
+
+	t.Run("merges overlapping matches", func(t *testing.T) {
+		snippet, highlights := aiSearchSnippet("Größe der Datei", "", []string{"größe", "öße der"})
+		require.Equal(t, "Größe der Datei", snippet)
+		require.Len(t, highlights, 1)
+		require.Equal(t, int32(0), highlights[0].Start)
+		require.Equal(t, int32(9), highlights[0].End)
+	})
+
+	t.Run("cuts long snippets around the first match", func(t *testing.T) {
+		content := strings.Repeat("x", 500) + " needle " + strings.Repeat("y", 500)
+		snippet, highlights := aiSearchSnippet("", content, []string{"needle"})
+		require.Equal(t, maxAiSearchSnippetLength, len([]rune(snippet)))
+		require.Len(t, highlights, 1)
+		start, end := highlights[0].Start, highlights[0].End
+		require.Equal(t, "needle", string([]rune(snippet)[start:end]))
+	})
+}
+
+func TestParseAiCreator(t *testing.T) {
+	tests := []struct {
+		creator           string
+		expectedCreator   string
+		expectedCreatorID int32
+	}{
+		{creator: "users/1", expectedCreator: "users/1", expectedCreatorID: 1},
+		{creator: "users/007", expectedCreator: "users/7", expectedCreatorID: 7},
+		{creator: ""},
+		{creator: "users/"},
+		{creator: "users/0"},
+		{creator: "users/-1"},
+		{creator: "users/abc"},
+		{creator: "users/1/2"},
+		{creator: "users/1%2F2"},
+		{creator: "users/1?x=2"},
+		{creator: "users/1 "},
+		{creator: "memos/1"},
+		{creator: "users/99999999999"},
+	}
+
+	for _, tt := range tests {
+		creator, creatorID, err := parseAiCreator(tt.creator)
+		if tt.expectedCreator == "" {
+			require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err), tt.creator)
+			continue
+		}
+		require.NoError(t, err, tt.creator)
+		require.Equal(t, tt.expectedCreator, creator)
+		require.Equal(t, tt.expectedCreatorID, creatorID)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		s         string
+		maxRunes  int
+		expected  string
+		truncated bool
+	}{
+		{s: "hello", maxRunes: 10, expected: "hello"},
+		{s: "hello", maxRunes: 5, expected: "hello"},
+		{s: "hello world", maxRunes: 5, expected: "hello", truncated: true},
+		// Multi-byte characters count as one and are never split.
+		{s: "你好世界", maxRunes: 3, expected: "你好世", truncated: true},
+		{s: "你好世界", maxRunes: 4, expected: "你好世界"},
+		{s: "héllo", maxRunes: 2, expected: "hé", truncated: true},
+	}
+
+	for _, tt := range tests {
+		got, truncated := truncateRunes(tt.s, tt.maxRunes)
+		require.Equal(t, tt.expected, got, tt.s)
+		require.Equal(t, tt.truncated, truncated, tt.s)
+	}
+}
+
+func TestConvertMemoForAIContract(t *testing.T) {
+	memo := &store.Memo{
+		UID:        "abc",
+		CreatorID:  1,
+		CreatedTs:  1700000000,
+		UpdatedTs:  1700000100,
+		Content:    "hello #world",
+		Visibility: store.Protected,
+		Payload:    &storepb.MemoPayload{Tags: []string{"world"}},
+	}
+	attachments := []*store.Attachment{
+		{
+			UID:         "att1",
+			Filename:    "receipt.png",
+			Type:        "image/png",
+			StorageType: storepb.AttachmentStorageType_S3,
+			Reference:   "https://s3.example.com/receipt.png",
+			Payload:     &storepb.AttachmentPayload{ExtractedText: "TOTAL 42.00"},
+		},
+	}
+
+	doc := (&APIV1Service{}).convertMemoForAI(context.Background(), memo, attachments, 10, 0, 1000, ai.IndexModeBoth)
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	createTime := time.Unix(memo.CreatedTs, 0).Format(time.RFC3339)
+	updateTime := time.Unix(memo.UpdatedTs, 0).Format(time.RFC3339)
+	expected := `{"aiTags":[],` +
+		`"attachments":[{"externalLink":"https://s3.example.com/receipt.png","extractedText":"TOTAL 42.00","filename":"receipt.png","name":"att1","type":"image/png"}],` +
+		`"content":"hello #world","createTime":"` + createTime + `","creator":"users/1","displayTime":"` + createTime + `",` +
+		`"name":"memos/abc","tags":["world"],"uid":"abc","updateTime":"` + updateTime + `","visibility":"PROTECTED"}`
+	require.Equal(t, expected, string(data))
+
+	// Empty lists are sent as arrays rather than null.
+	memo.Payload = nil
+	data, err = json.Marshal((&APIV1Service{}).convertMemoForAI(context.Background(), memo, nil, 10, 0, 1000, ai.IndexModeBoth))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `{"aiTags":[],"attachments":[],`)
+	require.Contains(t, string(data), `"tags":[],`)
+}
+
+func TestConvertMemoForAIIndexMode(t *testing.T) {
+	memo := &store.Memo{
+		UID:        "abc",
+		CreatorID:  1,
+		Content:    "hello",
+		Visibility: store.Private,
+	}
+	attachments := []*store.Attachment{
+		{
+			UID:         "image",
+			Filename:    "receipt.png",
+			Type:        "image/png",
+			StorageType: storepb.AttachmentStorageType_S3,
+			Reference:   "https://s3.example.com/receipt.png",
+			Payload:     &storepb.AttachmentPayload{ExtractedText: "TOTAL 42.00"},
+		},
+		{
+			UID:         "pdf",
+			Filename:    "report.pdf",
+			Type:        "application/pdf",
+			StorageType: storepb.AttachmentStorageType_EXTERNAL,
+			Reference:   "https://example.com/report.pdf",
+			Payload:     &storepb.AttachmentPayload{ExtractedText: "Q3 report"},
+		},
+		// Reading the blob of a local attachment would panic without a store.
+		{UID: "local", Filename: "local.txt", Type: "text/plain", Size: 5},
+	}
+	links := func(doc *ai.MemoDocument) []string {
+		result := []string{}
+		for _, att := range doc.Attachments {
+			result = append(result, att.ExternalLink)
+		}
+		return result
+	}
+	extractedTexts := func(doc *ai.MemoDocument) []string {
+		result := []string{}
+		for _, att := range doc.Attachments {
+			result = append(result, att.ExtractedText)
+		}
+		return result
+	}
+
+	doc := (&APIV1Service{}).convertMemoForAI(context.Background(), memo, attachments, 10, 0, 1000, ai.IndexModeTextOnly)
+	require.Equal(t, "hello", doc.Content)
+	require.Equal(t, []string{"", "", ""}, links(doc))
+	require.Equal(t, []string{"TOTAL 42.00", "Q3 report", ""}, extractedTexts(doc))
+
+	doc = (&APIV1Service{}).convertMemoForAI(context.Background(), memo, attachments, 10, 0, 1000, ai.IndexModeImagesOnly)
+	require.Empty(t, doc.Content)
+	require.Equal(t, []string{"https://s3.example.com/receipt.png", "", ""}, links(doc))
+	require.Equal(t, []string{"", "", ""}, extractedTexts(doc))
+}
+
+func TestSelectAiAttachments(t *testing.T) {
+	memo := &store.Memo{UID: "abc"}
+	attachment := func(uid, mimeType string) *store.Attachment {
+		return &store.Attachment{UID: uid, Type: mimeType}
+	}
+	uids := func(attachments []*store.Attachment) []string {
+		result := []string{}
+		for _, att := range attachments {
+			result = append(result, att.UID)
+		}
+		return result
+	}
+
+	// Duplicates are dropped, keeping the first.
+	attachments := []*store.Attachment{
+		attachment("a", "image/png"),
+		attachment("b", "application/pdf"),
+		attachment("a", "image/png"),
+	}
+	require.Equal(t, []string{"a", "b"}, uids(selectAiAttachments(memo, attachments, 10)))
+
+	// Images are kept first when over the limit, in their original order.
+	attachments = []*store.Attachment{
+		attachment("doc1", "application/pdf"),
+		attachment("img1", "image/png"),
+		attachment("doc2", "text/plain"),
+		attachment("img2", "image/jpeg"),
+		attachment("img1", "image/png"),
+	}
+	require.Equal(t, []string{"img1", "img2"}, uids(selectAiAttachments(memo, attachments, 2)))
+	require.Equal(t, []string{"doc1", "img1", "img2"}, uids(selectAiAttachments(memo, attachments, 3)))
+	require.Empty(t, selectAiAttachments(memo, nil, 2))
+}
+
+func TestAiTagLanguage(t *testing.T) {
+	tests := []struct {
+		requested string
+		expected  string
+		wantErr   bool
+	}{
+		{requested: "", expected: ""},
+		{requested: "auto", expected: ai.TagLanguageAuto},
+		{requested: "AUTO", expected: ai.TagLanguageAuto},
+		{requested: "de", expected: "de"},
+		{requested: " de-DE ", expected: "de-DE"},
+		{requested: "zh-hans-cn", expected: "zh-Hans-CN"},
+		{requested: "not a language", wantErr: true},
+		{requested: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := aiTagLanguage(tt.requested)
+		if tt.wantErr {
+			require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err), tt.requested)
+			continue
+		}
+		require.NoError(t, err, tt.requested)
+		require.Equal(t, tt.expected, got, tt.requested)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
 )
 
 func TestGetInstanceProfile(t *testing.T) {
@@ -204,3 +207,161 @@ func TestGetInstanceSetting(t *testing.T) {
 		require.Contains(t, err.Error(), "invalid instance setting name")
 	})
 }
+
+func TestSanitizeDatabaseUTF8(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	badContent, err := ts.Store.CreateMemo(ctx, &store.Memo{UID: "bad-content", CreatorID: user.ID, Content: "hello", Visibility: store.Private})
+	require.NoError(t, err)
+	badPayload, err := ts.Store.CreateMemo(ctx, &store.Memo{UID: "bad-payload", CreatorID: user.ID, Content: "tagged", Visibility: store.Private})
+	require.NoError(t, err)
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{UID: "good", CreatorID: user.ID, Content: "fine", Visibility: store.Private})
+	require.NoError(t, err)
+	// The store sanitizes memos it writes, so seed the invalid rows directly.
+	db := ts.Store.GetDriver().GetDB()
+	_, err = db.ExecContext(ctx, "UPDATE memo SET content = ? WHERE id = ?", "hello \xff world", badContent.ID)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "UPDATE memo SET payload = ? WHERE id = ?", "{\"tags\":[\"t\xc3\"]}", badPayload.ID)
+	require.NoError(t, err)
+
+	t.Run("only the host can sanitize", func(t *testing.T) {
+		_, err := ts.Service.SanitizeDatabaseUTF8(userCtx, &v1pb.SanitizeDatabaseUTF8Request{})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		resp, err := ts.Service.SanitizeDatabaseUTF8(hostCtx, &v1pb.SanitizeDatabaseUTF8Request{DryRun: true})
+		require.NoError(t, err)
+		require.Equal(t, int32(3), resp.ScannedCount)
+		require.Equal(t, int32(2), resp.InvalidCount)
+		require.Equal(t, int32(0), resp.FixedCount)
+	})
+
+	t.Run("fixes invalid memos", func(t *testing.T) {
+		resp, err := ts.Service.SanitizeDatabaseUTF8(hostCtx, &v1pb.SanitizeDatabaseUTF8Request{})
+		require.NoError(t, err)
+		require.Equal(t, int32(2), resp.InvalidCount)
+		require.Equal(t, int32(2), resp.FixedCount)
+
+		memo, err := ts.Store.GetMemo(ctx, &store.FindMemo{ID: &badContent.ID})
+		require.NoError(t, err)
+		require.Equal(t, "hello � world", memo.Content)
+		memo, err = ts.Store.GetMemo(ctx, &store.FindMemo{ID: &badPayload.ID})
+		require.NoError(t, err)
+		require.Equal(t, []string{"t�"}, memo.Payload.Tags)
+
+		resp, err = ts.Service.SanitizeDatabaseUTF8(hostCtx, &v1pb.SanitizeDatabaseUTF8Request{})
+		require.NoError(t, err)
+		require.Equal(t, int32(0), resp.InvalidCount)
+	})
+}
+
+func TestUpdateInstanceAiSettingServiceURL(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+	update := func(serviceURL string) error {
+		_, err := ts.Service.UpdateInstanceSetting(hostCtx, &v1pb.UpdateInstanceSettingRequest{
+			Setting: &v1pb.InstanceSetting{
+				Name: "instance/settings/AI",
+				Value: &v1pb.InstanceSetting_AiSetting_{
+					AiSetting: &v1pb.InstanceSetting_AiSetting{AiServiceUrl: serviceURL},
+				},
+			},
+		})
+		return err
+	}
+
+	t.Setenv("AI_SERVICE_ALLOW_UNSAFE_URL", "")
+	require.NoError(t, update("http://127.0.0.1:8000"))
+	require.Equal(t, codes.InvalidArgument, status.Code(update("http://169.254.169.254/latest")))
+	require.Equal(t, codes.InvalidArgument, status.Code(update("file:///etc/passwd")))
+
+	// Rejected URLs are not saved.
+	aiSetting, err := ts.Store.GetInstanceAiSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "http://127.0.0.1:8000", aiSetting.AiServiceUrl)
+
+	// Operators can allow any URL.
+	t.Setenv("AI_SERVICE_ALLOW_UNSAFE_URL", "true")
+	require.NoError(t, update("http://169.254.169.254/latest"))
+}
+
+func TestUpdateInstanceAiSettingSearchDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+	update := func(aiSetting *v1pb.InstanceSetting_AiSetting) (*v1pb.InstanceSetting, error) {
+		return ts.Service.UpdateInstanceSetting(hostCtx, &v1pb.UpdateInstanceSettingRequest{
+			Setting: &v1pb.InstanceSetting{
+				Name:  "instance/settings/AI",
+				Value: &v1pb.InstanceSetting_AiSetting_{AiSetting: aiSetting},
+			},
+		})
+	}
+
+	setting, err := update(&v1pb.InstanceSetting_AiSetting{DefaultSearchTopK: 20, DefaultSearchMinScore: 0.4, DefaultSearchMode: "bm25"})
+	require.NoError(t, err)
+	require.EqualValues(t, 20, setting.GetAiSetting().DefaultSearchTopK)
+	require.Equal(t, float32(0.4), setting.GetAiSetting().DefaultSearchMinScore)
+	require.Equal(t, "bm25", setting.GetAiSetting().DefaultSearchMode)
+
+	_, err = update(&v1pb.InstanceSetting_AiSetting{DefaultSearchMode: "hybrd"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = update(&v1pb.InstanceSetting_AiSetting{DefaultSearchTopK: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = update(&v1pb.InstanceSetting_AiSetting{DefaultSearchMinScore: -0.1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateInstanceAiSettingIndexMode(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+	update := func(indexMode string) (*v1pb.InstanceSetting, error) {
+		return ts.Service.UpdateInstanceSetting(hostCtx, &v1pb.UpdateInstanceSettingRequest{
+			Setting: &v1pb.InstanceSetting{
+				Name: "instance/settings/AI",
+				Value: &v1pb.InstanceSetting_AiSetting_{
+					AiSetting: &v1pb.InstanceSetting_AiSetting{IndexMode: indexMode},
+				},
+			},
+		})
+	}
+
+	for _, indexMode := range []string{"", "both", "text_only", "images_only"} {
+		setting, err := update(indexMode)
+		require.NoError(t, err, indexMode)
+		require.Equal(t, indexMode, setting.GetAiSetting().IndexMode)
+	}
+	_, err = update("text")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
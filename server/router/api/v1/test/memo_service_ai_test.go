@@ -0,0 +1,3221 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/ai"
+	apiv1service "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// NewFakeAIService starts an httptest server with the given handler and points the instance AI setting at it.
+func (ts *TestService) NewFakeAIService(ctx context.Context, t *testing.T, handler http.Handler) *httptest.Server {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	_, err := ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl: server.URL,
+			},
+		},
+	})
+	require.NoError(t, err)
+	return server
+}
+
+func TestAiSearchPagination(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// The fake AI service holds 25 ranked results and honors top_k/offset.
+	var lastRequest ai.SearchRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/search" {
+			http.NotFound(w, r)
+			return
+		}
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		const total = 25
+		results := []ai.SearchResult{}
+		for i := lastRequest.Offset; i < total && len(results) < lastRequest.TopK; i++ {
+			results = append(results, ai.SearchResult{
+				MemoUID:  fmt.Sprintf("memo-%d", i),
+				MemoName: fmt.Sprintf("memos/memo-%d", i),
+				Score:    1 - float32(i)/100,
+			})
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{
+			Results:      results,
+			Query:        lastRequest.Query,
+			SearchMode:   lastRequest.SearchMode,
+			TotalResults: total,
+		})
+	}))
+
+	t.Run("pages through all results", func(t *testing.T) {
+		seen := []string{}
+		pageToken := ""
+		for {
+			resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+				Query:     "hello",
+				PageSize:  10,
+				PageToken: pageToken,
+			})
+			require.NoError(t, err)
+			for _, r := range resp.Results {
+				seen = append(seen, r.MemoUid)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			pageToken = resp.NextPageToken
+		}
+		require.Len(t, seen, 25)
+		require.Equal(t, "memo-0", seen[0])
+		require.Equal(t, "memo-24", seen[24])
+	})
+
+	t.Run("top_k caps the paged result set", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+			Query:    "hello",
+			TopK:     15,
+			PageSize: 10,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 10)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+			Query:     "hello",
+			TopK:      15,
+			PageSize:  10,
+			PageToken: resp.NextPageToken,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 5)
+		require.Empty(t, resp.NextPageToken)
+		require.Equal(t, 10, lastRequest.Offset)
+	})
+
+	t.Run("top_k without paging returns everything at once", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+			Query: "hello",
+			TopK:  20,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 20)
+		require.Empty(t, resp.NextPageToken)
+		require.Zero(t, lastRequest.Offset)
+	})
+
+	t.Run("large pages fetch at most the service top_k", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+			Query:    "hello",
+			PageSize: 500,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 25)
+		require.Empty(t, resp.NextPageToken)
+		require.Equal(t, 100, lastRequest.TopK)
+	})
+
+	t.Run("token for a different query is rejected", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+			Query:    "hello",
+			PageSize: 10,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+			Query:     "goodbye",
+			PageSize:  10,
+			PageToken: resp.NextPageToken,
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestAiSearchSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// The fake AI service ranks the current memos and honors top_k/offset.
+	ranking := []string{}
+	for i := 0; i < 7; i++ {
+		ranking = append(ranking, fmt.Sprintf("memo-%d", i))
+	}
+	searchCount := 0
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ai.SearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		searchCount++
+		results := []ai.SearchResult{}
+		for i := req.Offset; i < len(ranking) && len(results) < req.TopK; i++ {
+			results = append(results, ai.SearchResult{MemoUID: ranking[i], MemoName: "memos/" + ranking[i]})
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TotalResults: len(ranking)})
+	}))
+
+	resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "hello", PageSize: 3, Snapshot: true})
+	require.NoError(t, err)
+	seen := []string{}
+	for _, r := range resp.Results {
+		seen = append(seen, r.MemoUid)
+	}
+
+	// A memo added mid-pagination ranks first, but doesn't shift the snapshot's pages.
+	ranking = append([]string{"new-memo"}, ranking...)
+	for resp.NextPageToken != "" {
+		resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "hello", PageSize: 3, PageToken: resp.NextPageToken})
+		require.NoError(t, err)
+		for _, r := range resp.Results {
+			seen = append(seen, r.MemoUid)
+		}
+	}
+	require.Equal(t, []string{"memo-0", "memo-1", "memo-2", "memo-3", "memo-4", "memo-5", "memo-6"}, seen)
+	require.Equal(t, 1, searchCount)
+
+	// Without a snapshot, the new memo shifts the second page, though memos of the first page are
+	// skipped rather than returned again.
+	resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "hello", PageSize: 3})
+	require.NoError(t, err)
+	ranking = append([]string{"newer-memo"}, ranking...)
+	resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "hello", PageSize: 3, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+	require.Equal(t, "memo-2", resp.Results[0].MemoUid)
+
+	// Snapshots are private to the user who took them, even to admins allowed to search the same memos.
+	resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "hello", PageSize: 3, Snapshot: true})
+	require.NoError(t, err)
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	_, err = ts.Service.AiSearch(ts.CreateUserContext(ctx, hostUser.ID), &apiv1.AiSearchRequest{
+		Query:     "hello",
+		Creator:   fmt.Sprintf("users/%d", user.ID),
+		PageSize:  3,
+		PageToken: resp.NextPageToken,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAiSearchMatchedText(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{
+			Results: []ai.SearchResult{
+				{MemoUID: "memos/with-chunk", MemoName: "memos/with-chunk", Score: 0.9, MatchType: "text", MatchedText: "The deploy failed with E1234.", IndexType: "both", MatchedChunkID: "chunk-2"},
+				{MemoUID: "without-chunk", MemoName: "memos/without-chunk", Score: 0.8, MatchType: "image"},
+			},
+			TotalResults: 2,
+		})
+	}))
+
+	resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy error"})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	require.Equal(t, "The deploy failed with E1234.", resp.Results[0].MatchedText)
+	require.Equal(t, "both", resp.Results[0].IndexType)
+	require.Equal(t, "chunk-2", resp.Results[0].MatchedChunkId)
+	// Services that don't report them leave the fields empty.
+	require.Empty(t, resp.Results[1].MatchedText)
+	require.Empty(t, resp.Results[1].IndexType)
+	require.Empty(t, resp.Results[1].MatchedChunkId)
+}
+
+func TestAiSearchDedupe(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{
+			Results: []ai.SearchResult{
+				{MemoUID: "both", MemoName: "memos/both", Score: 0.7, MatchType: "text", MatchedText: "text chunk", IndexType: "text", MatchedChunkID: "chunk-1"},
+				{MemoUID: "text-only", MemoName: "memos/text-only", Score: 0.8, MatchType: "text", MatchedText: "other chunk"},
+				{MemoUID: "both", MemoName: "memos/both", Score: 0.9, MatchType: "image", MatchedText: "image caption", IndexType: "image", MatchedChunkID: "chunk-2"},
+				{MemoUID: "text-only", MemoName: "memos/text-only", Score: 0.6, MatchType: "text", MatchedText: "weaker chunk"},
+			},
+			TotalResults: 4,
+		})
+	}))
+
+	resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy"})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	require.EqualValues(t, 2, resp.TotalResults)
+	// Merged results keep the position of the first one and the fields of the highest scoring one.
+	require.Equal(t, "both", resp.Results[0].MemoUid)
+	require.Equal(t, float32(0.9), resp.Results[0].Score)
+	require.Equal(t, "image+text", resp.Results[0].MatchType)
+	require.Equal(t, "image caption", resp.Results[0].MatchedText)
+	require.Equal(t, "both", resp.Results[0].IndexType)
+	require.Equal(t, "chunk-2", resp.Results[0].MatchedChunkId)
+	require.Equal(t, "text-only", resp.Results[1].MemoUid)
+	require.Equal(t, float32(0.8), resp.Results[1].Score)
+	require.Equal(t, "text", resp.Results[1].MatchType)
+	require.Equal(t, "other chunk", resp.Results[1].MatchedText)
+}
+
+func TestAiSearchPaginationDedupe(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// The fake AI service ranks the text and image results of memos separately and honors top_k/offset.
+	// The image result of memo-1 ranks last, pages after memo-1 is returned.
+	ranking := []ai.SearchResult{
+		{MemoUID: "memo-0", MatchType: "text"},
+		{MemoUID: "memo-0", MatchType: "image"},
+		{MemoUID: "memo-1", MatchType: "text"},
+		{MemoUID: "memo-2", MatchType: "text"},
+		{MemoUID: "memo-3", MatchType: "text"},
+		{MemoUID: "memo-2", MatchType: "image"},
+		{MemoUID: "memo-4", MatchType: "text"},
+		{MemoUID: "memo-4", MatchType: "image"},
+		{MemoUID: "memo-5", MatchType: "text"},
+		{MemoUID: "memo-1", MatchType: "image"},
+	}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ai.SearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		results := []ai.SearchResult{}
+		for i := req.Offset; i < len(ranking) && len(results) < req.TopK; i++ {
+			results = append(results, ranking[i])
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TotalResults: len(ranking)})
+	}))
+
+	for _, snapshot := range []bool{false, true} {
+		// Every memo shows up once, even when its results span two pages of the AI service.
+		pages := [][]string{}
+		matchTypes := map[string]string{}
+		pageToken := ""
+		for {
+			resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+				Query:     "deploy",
+				PageSize:  2,
+				PageToken: pageToken,
+				Snapshot:  snapshot,
+			})
+			require.NoError(t, err)
+			page := []string{}
+			for _, r := range resp.Results {
+				page = append(page, r.MemoUid)
+				matchTypes[r.MemoUid] = r.MatchType
+			}
+			pages = append(pages, page)
+			if resp.NextPageToken == "" {
+				break
+			}
+			pageToken = resp.NextPageToken
+		}
+		require.Equal(t, [][]string{{"memo-0", "memo-1"}, {"memo-2", "memo-3"}, {"memo-4", "memo-5"}}, pages, "snapshot: %v", snapshot)
+		require.Equal(t, "image+text", matchTypes["memo-2"], "snapshot: %v", snapshot)
+		require.Equal(t, "text", matchTypes["memo-3"], "snapshot: %v", snapshot)
+	}
+}
+
+func TestAiSearchExplain(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+	var explained []bool
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var searchReq ai.SearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&searchReq); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		explained = append(explained, searchReq.Explain)
+		// The breakdown is reported even when not asked for, it must still be dropped.
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{
+			Results: []ai.SearchResult{{
+				MemoUID:        "memo",
+				MemoName:       "memos/memo",
+				Score:          0.8,
+				MatchedText:    "deploy",
+				ScoreBreakdown: &ai.ScoreBreakdown{TextScore: 0.7, ImageScore: 0.1, KeywordScore: 0.5, FinalScore: 0.8},
+			}},
+			TotalResults: 1,
+		})
+	}))
+
+	resp, err := ts.Service.AiSearch(hostCtx, &apiv1.AiSearchRequest{Query: "deploy", Explain: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	breakdown := resp.Results[0].ScoreBreakdown
+	require.NotNil(t, breakdown)
+	require.Equal(t, float32(0.7), breakdown.TextScore)
+	require.Equal(t, float32(0.1), breakdown.ImageScore)
+	require.Equal(t, float32(0.5), breakdown.KeywordScore)
+	require.Equal(t, float32(0.8), breakdown.FinalScore)
+
+	resp, err = ts.Service.AiSearch(hostCtx, &apiv1.AiSearchRequest{Query: "deploy"})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Nil(t, resp.Results[0].ScoreBreakdown)
+	require.Equal(t, []bool{true, false}, explained)
+
+	// Only admins can explain results.
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy", Explain: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy", Explain: true}, &fakeAiSearchStream{ctx: userCtx})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Len(t, explained, 2)
+}
+
+func TestAiSearchSnippet(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "own",
+		CreatorID:  user.ID,
+		Content:    "Shopping list\nThe Deploy failed at noon\nMore notes",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "private",
+		CreatorID:  otherUser.ID,
+		Content:    "secret deploy notes",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	results := []ai.SearchResult{
+		{MemoUID: "memos/with-chunk", MemoName: "memos/with-chunk", MatchedText: "deploy \xffok"},
+		{MemoUID: "memos/own", MemoName: "memos/own"},
+		{MemoUID: "memos/private", MemoName: "memos/private"},
+	}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal/search/stream" {
+			for _, result := range results {
+				_ = json.NewEncoder(w).Encode(&result)
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TotalResults: len(results)})
+	}))
+
+	check := func(t *testing.T, results []*apiv1.AiSearchResult) {
+		require.Len(t, results, 3)
+		// The matched text reported by the AI service is used as is, sanitized.
+		require.Equal(t, "deploy \uFFFDok", results[0].Snippet)
+		require.Len(t, results[0].Highlights, 1)
+		require.Equal(t, int32(0), results[0].Highlights[0].Start)
+		require.Equal(t, int32(6), results[0].Highlights[0].End)
+		// Otherwise the best matching line of the memo content is used.
+		require.Equal(t, "The Deploy failed at noon", results[1].Snippet)
+		require.Len(t, results[1].Highlights, 2)
+		require.Equal(t, int32(4), results[1].Highlights[0].Start)
+		require.Equal(t, int32(10), results[1].Highlights[0].End)
+		require.Equal(t, int32(11), results[1].Highlights[1].Start)
+		require.Equal(t, int32(17), results[1].Highlights[1].End)
+		// Content of memos the caller can't see is never exposed.
+		require.Empty(t, results[2].Snippet)
+		require.Empty(t, results[2].Highlights)
+	}
+
+	t.Run("search", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy failed"})
+		require.NoError(t, err)
+		check(t, resp.Results)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		stream := &fakeAiSearchStream{ctx: userCtx}
+		err := ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy failed"}, stream)
+		require.NoError(t, err)
+		check(t, stream.results)
+	})
+}
+
+func TestAiSearchOtherUsersMemos(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "private",
+		CreatorID:  otherUser.ID,
+		Content:    "secret deploy notes",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	results := []ai.SearchResult{{MemoUID: "memos/private", MemoName: "memos/private", Score: 0.9, MatchedText: "secret deploy notes"}}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal/search/stream" {
+			for _, result := range results {
+				_ = json.NewEncoder(w).Encode(&result)
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TotalResults: len(results)})
+	}))
+
+	// Own scopes aren't filtered by visibility, so users can't name another creator.
+	otherCreator := fmt.Sprintf("users/%d", otherUser.ID)
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy", Creator: otherCreator})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy", Creator: otherCreator}, &fakeAiSearchStream{ctx: userCtx})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Admins can, but neither the matched text nor the snippet of private memos of others is shown.
+	resp, err := ts.Service.AiSearch(hostCtx, &apiv1.AiSearchRequest{Query: "deploy", Creator: otherCreator})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Empty(t, resp.Results[0].MatchedText)
+	require.Empty(t, resp.Results[0].Snippet)
+
+	stream := &fakeAiSearchStream{ctx: hostCtx}
+	require.NoError(t, ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy", Creator: otherCreator}, stream))
+	require.Len(t, stream.results, 1)
+	require.Empty(t, stream.results[0].MatchedText)
+	require.Empty(t, stream.results[0].Snippet)
+}
+
+func TestAiSearchIncludeMemo(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+
+	own, err := ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "own",
+		CreatorID:  user.ID,
+		Content:    "The **deploy** failed at noon",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	pinned := true
+	require.NoError(t, ts.Store.UpdateMemo(ctx, &store.UpdateMemo{ID: own.ID, Pinned: &pinned}))
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "protected",
+		CreatorID:  otherUser.ID,
+		Content:    "Shared deploy notes",
+		Visibility: store.Protected,
+	})
+	require.NoError(t, err)
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "private",
+		CreatorID:  otherUser.ID,
+		Content:    "secret deploy notes",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	results := []ai.SearchResult{
+		{MemoUID: "memos/own", MemoName: "memos/own", MatchedText: "deploy failed"},
+		{MemoUID: "memos/protected", MemoName: "memos/protected"},
+		{MemoUID: "memos/private", MemoName: "memos/private"},
+		{MemoUID: "memos/deleted", MemoName: "memos/deleted", MatchedText: "deploy"},
+	}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal/search/stream" {
+			for _, result := range results {
+				_ = json.NewEncoder(w).Encode(&result)
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TotalResults: len(results)})
+	}))
+
+	check := func(t *testing.T, results []*apiv1.AiSearchResult) {
+		require.Len(t, results, 4)
+		require.NotNil(t, results[0].Memo)
+		require.Equal(t, "The deploy failed at noon", results[0].Memo.Snippet)
+		require.True(t, results[0].Memo.Pinned)
+		require.Equal(t, apiv1.Visibility_PRIVATE, results[0].Memo.Visibility)
+		require.Equal(t, own.CreatedTs, results[0].Memo.DisplayTime.AsTime().Unix())
+		require.NotNil(t, results[1].Memo)
+		require.Equal(t, "Shared deploy notes", results[1].Memo.Snippet)
+		require.Equal(t, apiv1.Visibility_PROTECTED, results[1].Memo.Visibility)
+		// Memos the caller can't see, or that no longer exist, are not embedded.
+		require.Nil(t, results[2].Memo)
+		require.Nil(t, results[3].Memo)
+	}
+
+	t.Run("search", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy", IncludeMemo: true})
+		require.NoError(t, err)
+		check(t, resp.Results)
+
+		resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy"})
+		require.NoError(t, err)
+		for _, result := range resp.Results {
+			require.Nil(t, result.Memo)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		stream := &fakeAiSearchStream{ctx: userCtx}
+		err := ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy", IncludeMemo: true}, stream)
+		require.NoError(t, err)
+		check(t, stream.results)
+	})
+}
+
+func TestIndexMemoExcludesPublicMemos(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	indexed := []string{}
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var item ai.IndexMemoRequest
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		uid := item.Memo.UID
+		indexed = append(indexed, uid)
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{MemoUID: uid, Status: "queued"})
+	}))
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl:       server.URL,
+				ExcludePublicMemos: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	publicMemo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "public memo", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	privateMemo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "private memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	resp, err := ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: publicMemo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "skipped", resp.Status)
+
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: privateMemo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "queued", resp.Status)
+
+	// Memos created by a guest, whose creator isn't a registered user, are excluded too.
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	guestMemo, err := ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "guest-memo",
+		CreatorID:  9999,
+		Content:    "guest memo",
+		Visibility: store.Protected,
+	})
+	require.NoError(t, err)
+	resp, err = ts.Service.IndexMemo(ts.CreateUserContext(ctx, hostUser.ID), &apiv1.IndexMemoRequest{Name: "memos/" + guestMemo.UID})
+	require.NoError(t, err)
+	require.Equal(t, "skipped", resp.Status)
+
+	require.Len(t, indexed, 1)
+	require.Equal(t, privateMemo.Name, "memos/"+indexed[0])
+}
+
+func TestGenerateAiTagsMaxTags(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastRequest ai.TagGenerationRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.TagGenerationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{"work"}})
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Quarterly planning notes", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		maxTags  int32
+		expected int
+	}{
+		{maxTags: 0, expected: 5},
+		{maxTags: 1, expected: 1},
+		{maxTags: 12, expected: 12},
+		{maxTags: 100, expected: 20},
+	}
+	for _, tt := range tests {
+		_, err := ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name, MaxTags: tt.maxTags})
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, lastRequest.MaxTags, "max_tags=%d", tt.maxTags)
+	}
+
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name, MaxTags: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGenerateAiTagsResponse(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var aiResp ai.TagGenerationResponse
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(&aiResp)
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Quarterly planning notes #work", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	aiResp = ai.TagGenerationResponse{
+		Success:    true,
+		Tags:       []string{"Work", "planning"},
+		MergedTags: []string{"work"},
+		Usage:      &ai.TokenUsage{PromptTokens: 120, CompletionTokens: 8, TotalTokens: 128},
+	}
+	resp, err := ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Work", "planning"}, resp.Tags)
+	require.Equal(t, []string{"work"}, resp.MergedTags)
+	require.Equal(t, int32(120), resp.Usage.GetPromptTokens())
+	require.Equal(t, int32(8), resp.Usage.GetCompletionTokens())
+	require.Equal(t, int32(128), resp.Usage.GetTotalTokens())
+
+	// Missing lists come back empty, not nil.
+	aiResp = ai.TagGenerationResponse{Success: true}
+	resp, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Tags)
+	require.Empty(t, resp.Tags)
+	require.NotNil(t, resp.MergedTags)
+	require.Empty(t, resp.MergedTags)
+	// Unreported usage stays nil rather than zeroed.
+	require.Nil(t, resp.Usage)
+}
+
+func TestApplyAiTags(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	indexCount := 0
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/tags/generate":
+			_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{"Planning", "work", "q3"}})
+		case "/internal/index/memo":
+			indexCount++
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{Status: "queued"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Quarterly planning notes #work", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	// Generated tags are applied, skipping the existing manual tag.
+	resp, err := ts.Service.ApplyAiTags(userCtx, &apiv1.ApplyAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Planning", "q3"}, resp.AiTags)
+	require.Equal(t, 1, indexCount)
+
+	// A caller-supplied subset is merged without duplicates.
+	resp, err = ts.Service.ApplyAiTags(userCtx, &apiv1.ApplyAiTagsRequest{Name: memo.Name, Tags: []string{"planning", "#roadmap"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Planning", "q3", "roadmap"}, resp.AiTags)
+
+	got, err := ts.Service.GetMemo(userCtx, &apiv1.GetMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, []string{"work"}, got.Tags)
+
+	_, err = ts.Service.ApplyAiTags(otherUserCtx, &apiv1.ApplyAiTagsRequest{Name: memo.Name, Tags: []string{"spam"}})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestRefreshMemoIndex(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	// The fake AI service skips upserts of unchanged content, like the real one does.
+	indexedContent := map[string]string{}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var item ai.IndexMemoRequest
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		uid, content := item.Memo.UID, item.Memo.Content
+
+		resp := &ai.IndexMemoResponse{MemoUID: uid, Status: "indexed", TextVectors: 3, ImageVectors: 1}
+		if item.Operation == "upsert" && indexedContent[uid] == content {
+			resp.Status = "skipped"
+		}
+		indexedContent[uid] = content
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "unchanged memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	indexResp, err := ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "indexed", indexResp.Status)
+	indexResp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Force: true})
+	require.NoError(t, err)
+	require.Equal(t, "skipped", indexResp.Status)
+
+	// Refresh re-embeds even though the content is unchanged.
+	resp, err := ts.Service.RefreshMemoIndex(userCtx, &apiv1.RefreshMemoIndexRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "indexed", resp.Status)
+	require.Equal(t, int32(3), resp.TextVectors)
+	require.Equal(t, int32(1), resp.ImageVectors)
+
+	_, err = ts.Service.RefreshMemoIndex(otherUserCtx, &apiv1.RefreshMemoIndexRequest{Name: memo.Name})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGenerateAiTagsCachesUserTags(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastRequest ai.TagGenerationRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.TagGenerationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{}})
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "#alpha", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"alpha"}, lastRequest.UserAllTags)
+
+	// A memo written directly to the store bypasses invalidation, so the cached tag set is used.
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "direct-memo",
+		CreatorID:  user.ID,
+		Content:    "#beta",
+		Visibility: store.Private,
+		Payload:    &storepb.MemoPayload{Tags: []string{"beta"}},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"alpha"}, lastRequest.UserAllTags)
+
+	// Creating a memo through the API invalidates the user's cached tag set.
+	_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "#gamma", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"alpha", "beta", "gamma"}, lastRequest.UserAllTags)
+
+	// Clearing the cache forces the tag set to be rebuilt.
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "direct-memo-2",
+		CreatorID:  user.ID,
+		Content:    "#delta",
+		Visibility: store.Private,
+		Payload:    &storepb.MemoPayload{Tags: []string{"delta"}},
+	})
+	require.NoError(t, err)
+	apiv1service.ClearUserTagCache(ctx)
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"alpha", "beta", "gamma", "delta"}, lastRequest.UserAllTags)
+}
+
+func TestGenerateAiTagsExcludeTags(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// The service ignores the excluded tags, so they are only dropped by the post filter.
+	var lastRequest ai.TagGenerationRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.TagGenerationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{
+			Success:    true,
+			Tags:       []string{"Note", "work", "MISC"},
+			MergedTags: []string{"misc", "MISC", "Note", "work"},
+		})
+	}))
+
+	_, err = ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "memo-misc",
+		CreatorID:  user.ID,
+		Content:    "notes #misc",
+		Visibility: store.Private,
+		Payload:    &storepb.MemoPayload{Tags: []string{"misc"}},
+	})
+	require.NoError(t, err)
+
+	resp, err := ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{
+		Name:        "memos/memo-misc",
+		ExcludeTags: []string{"#note", " Misc ", "NOTE", ""},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"note", "Misc"}, lastRequest.ExcludeTags)
+	require.Equal(t, []string{"work"}, resp.Tags)
+	// The memo's own tag is kept, though excluded from suggestions.
+	require.Equal(t, []string{"misc", "work"}, resp.MergedTags)
+
+	// Without excluded tags, nothing is dropped or sent.
+	resp, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: "memos/memo-misc"})
+	require.NoError(t, err)
+	require.Nil(t, lastRequest.ExcludeTags)
+	require.Equal(t, []string{"Note", "work", "MISC"}, resp.Tags)
+}
+
+func TestGenerateAiTagsLanguage(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var body map[string]interface{}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{"Reise"}})
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Urlaub in Berlin", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name, Language: "de-de"})
+	require.NoError(t, err)
+	require.Equal(t, "de-DE", body["language"])
+
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name, Language: "Auto"})
+	require.NoError(t, err)
+	require.Equal(t, ai.TagLanguageAuto, body["language"])
+
+	// Unset, the language isn't sent at all.
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.NotContains(t, body, "language")
+
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name, Language: "not a language"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGenerateAiTagsFoldTagCase(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastRequest ai.TagGenerationRequest
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.TagGenerationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{
+			Success:    true,
+			Tags:       []string{"work", "IDEAS", "Travel", "travel"},
+			MergedTags: []string{"WORK", "work", "IDEAS", "Travel"},
+		})
+	}))
+
+	for i, tags := range [][]string{{"WORK"}, {"Work"}, {"Work", "Ideas"}, {"work"}} {
+		_, err := ts.Store.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    "notes",
+			Visibility: store.Private,
+			Payload:    &storepb.MemoPayload{Tags: tags},
+		})
+		require.NoError(t, err)
+	}
+
+	// Tags are case-sensitive by default.
+	resp, err := ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: "memos/memo-0"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Ideas", "WORK", "Work", "work"}, lastRequest.UserAllTags)
+	require.Equal(t, []string{"work", "IDEAS", "Travel", "travel"}, resp.Tags)
+	require.Equal(t, []string{"WORK", "work", "IDEAS", "Travel"}, resp.MergedTags)
+
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl: server.URL,
+				FoldTagCase:  true,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// The most used casing is kept, suggestions take the user's casing and tags of the memo are not suggested again.
+	resp, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: "memos/memo-0"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Ideas", "Work"}, lastRequest.UserAllTags)
+	require.Equal(t, []string{"Ideas", "Travel"}, resp.Tags)
+	require.Equal(t, []string{"WORK", "Ideas", "Travel"}, resp.MergedTags)
+
+	batchResp, err := ts.Service.BatchGenerateAiTags(userCtx, &apiv1.BatchGenerateAiTagsRequest{Names: []string{"memos/memo-0"}})
+	require.NoError(t, err)
+	result := batchResp.Results["memos/memo-0"]
+	require.Empty(t, result.Error)
+	require.Equal(t, []string{"Ideas", "Travel"}, result.Tags)
+	require.Equal(t, []string{"WORK", "Ideas", "Travel"}, result.MergedTags)
+}
+
+func TestAiModelOverride(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastTagRequest ai.TagGenerationRequest
+	var lastSearchRequest ai.SearchRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			_, _ = w.Write([]byte(`{"status":"healthy","models":{"tag_generation":["tagger-large"],"embedding":["embedder-v2"]}}`))
+		case "/api/v1/tags/generate":
+			lastTagRequest = ai.TagGenerationRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&lastTagRequest); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{}})
+		case "/internal/search":
+			lastSearchRequest = ai.SearchRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&lastSearchRequest); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: []ai.SearchResult{}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	memo, err := ts.Service.CreateMemo(hostCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "model override", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	t.Run("supported model is forwarded", func(t *testing.T) {
+		_, err := ts.Service.GenerateAiTags(hostCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name, Model: "tagger-large"})
+		require.NoError(t, err)
+		require.Equal(t, "tagger-large", lastTagRequest.Model)
+
+		_, err = ts.Service.AiSearch(hostCtx, &apiv1.AiSearchRequest{Query: "hello", Model: "embedder-v2"})
+		require.NoError(t, err)
+		require.Equal(t, "embedder-v2", lastSearchRequest.Model)
+	})
+
+	t.Run("unsupported model falls back to the default", func(t *testing.T) {
+		_, err := ts.Service.GenerateAiTags(hostCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name, Model: "embedder-v2"})
+		require.NoError(t, err)
+		require.Empty(t, lastTagRequest.Model)
+
+		_, err = ts.Service.AiSearch(hostCtx, &apiv1.AiSearchRequest{Query: "hello", Model: "unknown"})
+		require.NoError(t, err)
+		require.Empty(t, lastSearchRequest.Model)
+	})
+
+	t.Run("regular users cannot override the model", func(t *testing.T) {
+		_, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "hello", Model: "embedder-v2"})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestAiRequestsSkipOversizedAttachments(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastTagRequest ai.TagGenerationRequest
+	var lastIndexedMemo *ai.MemoDocument
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/tags/generate":
+			lastTagRequest = ai.TagGenerationRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&lastTagRequest); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{}})
+		case "/internal/index/memo":
+			var item ai.IndexMemoRequest
+			if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			lastIndexedMemo = item.Memo
+			_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{Status: "indexed"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl:        server.URL,
+				MaxAttachmentSizeMb: 1,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "memo with images", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	memoUID := memo.Name[len("memos/"):]
+	storeMemo, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	require.NoError(t, err)
+
+	for _, att := range []*store.Attachment{
+		{UID: "small-image", Filename: "small.png", Type: "image/png", Blob: []byte("small"), Size: 5},
+		{UID: "large-image", Filename: "large.png", Type: "image/png", Blob: []byte("large"), Size: 2 * 1024 * 1024},
+	} {
+		att.CreatorID = user.ID
+		att.MemoID = &storeMemo.ID
+		_, err := ts.Store.CreateAttachment(ctx, att)
+		require.NoError(t, err)
+	}
+
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Len(t, lastTagRequest.Memo.Attachments, 2)
+	for _, att := range lastTagRequest.Memo.Attachments {
+		if att.Filename == "large.png" {
+			require.Empty(t, att.ExternalLink)
+		} else {
+			require.Equal(t, "data:image/png;base64,c21hbGw=", att.ExternalLink)
+		}
+	}
+
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Len(t, lastIndexedMemo.Attachments, 2)
+	for _, att := range lastIndexedMemo.Attachments {
+		if att.Filename == "large.png" {
+			require.Empty(t, att.ExternalLink)
+		} else {
+			require.Equal(t, "data:image/png;base64,c21hbGw=", att.ExternalLink)
+		}
+	}
+}
+
+func TestIndexMemoTextOnly(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastIndexedMemo *ai.MemoDocument
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var item ai.IndexMemoRequest
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		lastIndexedMemo = item.Memo
+		_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{Status: "indexed"})
+	}))
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl: server.URL,
+				IndexMode:    "text_only",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "memo with an image", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	memoUID := memo.Name[len("memos/"):]
+	storeMemo, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	require.NoError(t, err)
+	_, err = ts.Store.CreateAttachment(ctx, &store.Attachment{
+		UID:       "image",
+		CreatorID: user.ID,
+		MemoID:    &storeMemo.ID,
+		Filename:  "image.png",
+		Type:      "image/png",
+		Blob:      []byte("image"),
+		Size:      5,
+	})
+	require.NoError(t, err)
+
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "memo with an image", lastIndexedMemo.Content)
+	require.Len(t, lastIndexedMemo.Attachments, 1)
+	require.Equal(t, "image.png", lastIndexedMemo.Attachments[0].Filename)
+	require.Empty(t, lastIndexedMemo.Attachments[0].ExternalLink)
+}
+
+func TestAiSummarize(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	var lastRequest ai.SummarizeRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/summarize" {
+			http.NotFound(w, r)
+			return
+		}
+		lastRequest = ai.SummarizeRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SummarizeResponse{
+			Success: true,
+			Summary: "A long day of debugging.",
+			Usage:   ai.TokenUsage{PromptTokens: 120, CompletionTokens: 8, TotalTokens: 128},
+		})
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Spent the whole day chasing a flaky test.", Visibility: apiv1.Visibility_PROTECTED},
+	})
+	require.NoError(t, err)
+
+	resp, err := ts.Service.AiSummarize(userCtx, &apiv1.AiSummarizeRequest{Name: memo.Name, MaxWords: 30, Language: "en"})
+	require.NoError(t, err)
+	require.Equal(t, "A long day of debugging.", resp.Summary)
+	require.Equal(t, int32(128), resp.Usage.TotalTokens)
+	require.Equal(t, "Spent the whole day chasing a flaky test.", lastRequest.Memo.Content)
+	require.Equal(t, 30, lastRequest.MaxWords)
+	require.Equal(t, "en", lastRequest.Language)
+
+	_, err = ts.Service.AiSummarize(otherUserCtx, &apiv1.AiSummarizeRequest{Name: memo.Name})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = ts.Service.AiSummarize(userCtx, &apiv1.AiSummarizeRequest{Name: memo.Name, MaxWords: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetRelatedMemos(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	createMemo := func(ctx context.Context, content string, visibility apiv1.Visibility) string {
+		memo, err := ts.Service.CreateMemo(ctx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: content, Visibility: visibility},
+		})
+		require.NoError(t, err)
+		return memo.Name[len("memos/"):]
+	}
+	source := createMemo(userCtx, "source", apiv1.Visibility_PRIVATE)
+	ownPrivate := createMemo(userCtx, "own private", apiv1.Visibility_PRIVATE)
+	otherPrivate := createMemo(otherUserCtx, "other private", apiv1.Visibility_PRIVATE)
+	otherPublic := createMemo(otherUserCtx, "other public", apiv1.Visibility_PUBLIC)
+
+	var lastPath, lastTopK string
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.URL.Path
+		lastTopK = r.URL.Query().Get("top_k")
+		results := []ai.SearchResult{}
+		for i, uid := range []string{source, otherPrivate, ownPrivate, otherPublic, "deleted-memo"} {
+			results = append(results, ai.SearchResult{MemoUID: "memos/" + uid, MemoName: "memos/" + uid, Score: 1 - float32(i)/10})
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SimilarResponse{MemoUID: "memos/" + source, Results: results})
+	}))
+
+	resp, err := ts.Service.GetRelatedMemos(userCtx, &apiv1.GetRelatedMemosRequest{Name: "memos/" + source})
+	require.NoError(t, err)
+	require.Equal(t, "/internal/similar/"+source, lastPath)
+	require.Equal(t, "6", lastTopK)
+	uids := []string{}
+	for _, r := range resp.Results {
+		uids = append(uids, r.MemoUid)
+	}
+	require.Equal(t, []string{ownPrivate, otherPublic}, uids)
+
+	resp, err = ts.Service.GetRelatedMemos(userCtx, &apiv1.GetRelatedMemosRequest{Name: "memos/" + source, TopK: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+
+	_, err = ts.Service.GetRelatedMemos(userCtx, &apiv1.GetRelatedMemosRequest{Name: "memos/" + source, TopK: 100})
+	require.NoError(t, err)
+	require.Equal(t, "51", lastTopK)
+
+	_, err = ts.Service.GetRelatedMemos(otherUserCtx, &apiv1.GetRelatedMemosRequest{Name: "memos/" + source})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestDeleteMemoIndexChunk(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "a long memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	memoUID := memo.Name[len("memos/"):]
+
+	// The fake AI service holds three chunks of the memo.
+	chunks := []ai.TextChunk{
+		{DocID: "chunk-1", Content: "intro", ContentType: "memo_content"},
+		{DocID: "chunk-2", Content: "removed section", ContentType: "memo_content"},
+		{DocID: "chunk-3", Content: "outro", ContentType: "memo_content"},
+	}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/internal/index/chunks/"+memoUID+"/"):
+			docID := strings.TrimPrefix(r.URL.Path, "/internal/index/chunks/"+memoUID+"/")
+			for i, chunk := range chunks {
+				if chunk.DocID == docID {
+					chunks = append(chunks[:i], chunks[i+1:]...)
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+			http.NotFound(w, r)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/internal/index/memo/"):
+			_ = json.NewEncoder(w).Encode(&ai.MemoIndexInfo{
+				MemoUID:   memoUID,
+				TextCount: len(chunks),
+				Detail:    &ai.MemoIndexDetail{TextChunks: chunks},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	_, err = ts.Service.DeleteMemoIndexChunk(userCtx, &apiv1.DeleteMemoIndexChunkRequest{Name: memo.Name, DocId: "chunk-2"})
+	require.NoError(t, err)
+
+	info, err := ts.Service.GetMemoIndexInfo(userCtx, &apiv1.GetMemoIndexInfoRequest{Name: memo.Name, IncludeDetail: true})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), info.TextVectors)
+	docIDs := []string{}
+	for _, chunk := range info.Detail.TextChunks {
+		docIDs = append(docIDs, chunk.DocId)
+	}
+	require.Equal(t, []string{"chunk-1", "chunk-3"}, docIDs)
+
+	_, err = ts.Service.DeleteMemoIndexChunk(userCtx, &apiv1.DeleteMemoIndexChunkRequest{Name: memo.Name, DocId: "chunk-2"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = ts.Service.DeleteMemoIndexChunk(otherUserCtx, &apiv1.DeleteMemoIndexChunkRequest{Name: memo.Name, DocId: "chunk-1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Len(t, chunks, 2)
+}
+
+func TestAiSearchExactPhrase(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	withPhrase, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Deploy failed with error e1234 again", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	withoutPhrase, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Deploy failed with error E5678", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	// The fake AI service ranks both memos as semantically similar and can't enforce phrases.
+	var lastRequest ai.SearchRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		results := []ai.SearchResult{}
+		for _, name := range []string{withoutPhrase.Name, withPhrase.Name} {
+			// Like the AI service, report the memo name as the memo UID.
+			results = append(results, ai.SearchResult{MemoUID: name, MemoName: name, Score: 0.9})
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TotalResults: len(results)})
+	}))
+
+	resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: `deploy failure "E1234"`})
+	require.NoError(t, err)
+	require.Equal(t, "deploy failure E1234", lastRequest.Query)
+	require.Equal(t, []string{"E1234"}, lastRequest.MustContain)
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, withPhrase.Name, resp.Results[0].MemoName)
+
+	// Without a quoted phrase, semantic results are returned as is.
+	resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "deploy failure E1234"})
+	require.NoError(t, err)
+	require.Empty(t, lastRequest.MustContain)
+	require.Len(t, resp.Results, 2)
+}
+
+func TestAiSearchTagFilter(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	names := []string{}
+	for _, content := range []string{"#work #urgent fix the build", "#work plan the sprint", "#home fix the sink"} {
+		memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: content, Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		names = append(names, memo.Name)
+	}
+
+	var lastRequest ai.SearchRequest
+	serviceFilters := false
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		results := []ai.SearchResult{}
+		for _, name := range names {
+			// Like the AI service, report the memo name as the memo UID.
+			results = append(results, ai.SearchResult{MemoUID: name, MemoName: name, Score: 0.9})
+		}
+		if serviceFilters {
+			// Pretend the service filtered by tags on its own.
+			results = results[:1]
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TagsApplied: serviceFilters})
+	}))
+
+	t.Run("post-filters when the service doesn't filter", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "fix", Tags: []string{"work", "#urgent"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"work", "urgent"}, lastRequest.Tags)
+		require.Equal(t, "post_filter", resp.TagFilterMode)
+		require.Len(t, resp.Results, 1)
+		require.Equal(t, names[0], resp.Results[0].MemoName)
+
+		resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "fix", Tags: []string{"work"}})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+	})
+
+	t.Run("trusts the service when it filters", func(t *testing.T) {
+		serviceFilters = true
+		defer func() { serviceFilters = false }()
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "fix", Tags: []string{"work"}})
+		require.NoError(t, err)
+		require.Equal(t, "service", resp.TagFilterMode)
+		require.Len(t, resp.Results, 1)
+	})
+
+	t.Run("no tags filter", func(t *testing.T) {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "fix"})
+		require.NoError(t, err)
+		require.Empty(t, lastRequest.Tags)
+		require.Empty(t, resp.TagFilterMode)
+		require.Len(t, resp.Results, 3)
+	})
+}
+
+func TestDeleteMemoIndexGracePeriod(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	deleted := make(chan string, 10)
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.NotFound(w, r)
+			return
+		}
+		deleted <- strings.TrimPrefix(r.URL.Path, "/internal/index/memo/")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl:                  server.URL,
+				IndexDeleteGracePeriodSeconds: 1,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	deleteMemo := func(memoID string) string {
+		memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo:   &apiv1.Memo{Content: "memo " + memoID, Visibility: apiv1.Visibility_PRIVATE},
+			MemoId: memoID,
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.DeleteMemo(userCtx, &apiv1.DeleteMemoRequest{Name: memo.Name})
+		require.NoError(t, err)
+		resp, err := ts.Service.DeleteMemoIndex(userCtx, &apiv1.DeleteMemoIndexRequest{Name: memo.Name})
+		require.NoError(t, err)
+		require.True(t, resp.Pending)
+		return memo.Name
+	}
+
+	// Restoring the memo within the grace period cancels the pending index deletion.
+	restoredName := deleteMemo("restored-memo")
+	deletions, err := ts.Store.GetUserAiIndexDeletions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, deletions, 1)
+	require.Equal(t, "restored-memo", deletions[0].MemoId)
+	_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo:   &apiv1.Memo{Content: "memo restored-memo", Visibility: apiv1.Visibility_PRIVATE},
+		MemoId: "restored-memo",
+	})
+	require.NoError(t, err)
+	deletions, err = ts.Store.GetUserAiIndexDeletions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, deletions)
+
+	// Without a restore, the deletion proceeds after the grace period.
+	deleteMemo("deleted-memo")
+	select {
+	case uid := <-deleted:
+		require.Equal(t, "deleted-memo", uid)
+	case <-time.After(5 * time.Second):
+		t.Fatal("memo index was not deleted after the grace period")
+	}
+	select {
+	case uid := <-deleted:
+		t.Fatalf("unexpected index deletion of %s", uid)
+	case <-time.After(500 * time.Millisecond):
+	}
+	require.Eventually(t, func() bool {
+		deletions, err := ts.Store.GetUserAiIndexDeletions(ctx, user.ID)
+		return err == nil && len(deletions) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// An existing memo's index is deleted immediately.
+	resp, err := ts.Service.DeleteMemoIndex(userCtx, &apiv1.DeleteMemoIndexRequest{Name: restoredName})
+	require.NoError(t, err)
+	require.False(t, resp.Pending)
+	require.Equal(t, "restored-memo", <-deleted)
+
+	// Deletions stored before a restart proceed once resumed, right away if their grace period has ended.
+	require.NoError(t, ts.Store.AddUserAiIndexDeletion(ctx, user.ID, &storepb.AiIndexDeletionsUserSetting_Deletion{
+		MemoId:     "deleted-before-restart",
+		DeleteTime: timestamppb.New(time.Now().Add(-time.Minute)),
+	}))
+	require.NoError(t, ts.Service.ResumeMemoIndexDeletions(ctx))
+	select {
+	case uid := <-deleted:
+		require.Equal(t, "deleted-before-restart", uid)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stored memo index deletion was not resumed")
+	}
+	require.Eventually(t, func() bool {
+		deletions, err := ts.Store.GetUserAiIndexDeletions(ctx, user.ID)
+		return err == nil && len(deletions) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestAiSearchDateRange(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// Memos about vacation plans, created in 2021, 2024 and 2025.
+	names := []string{}
+	for _, year := range []int{2021, 2024, 2025} {
+		memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: fmt.Sprintf("vacation plans %d", year), Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		memoUID := memo.Name[len("memos/"):]
+		storeMemo, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		require.NoError(t, err)
+		createdTs := time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC).Unix()
+		require.NoError(t, ts.Store.UpdateMemo(ctx, &store.UpdateMemo{ID: storeMemo.ID, CreatedTs: &createdTs}))
+		names = append(names, memo.Name)
+	}
+
+	// The fake AI service ignores the date range.
+	var lastRequest ai.SearchRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		results := []ai.SearchResult{}
+		for _, name := range names {
+			// Like the AI service, report the memo name as the memo UID.
+			results = append(results, ai.SearchResult{MemoUID: name, MemoName: name, Score: 0.9})
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results})
+	}))
+
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+		Query:         "vacation plans",
+		CreatedAfter:  timestamppb.New(createdAfter),
+		CreatedBefore: timestamppb.New(createdBefore),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, lastRequest.CreatedAfter)
+	require.True(t, createdAfter.Equal(*lastRequest.CreatedAfter))
+	require.NotNil(t, lastRequest.CreatedBefore)
+	require.True(t, createdBefore.Equal(*lastRequest.CreatedBefore))
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, names[1], resp.Results[0].MemoName)
+
+	resp, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+		Query:        "vacation plans",
+		CreatedAfter: timestamppb.New(createdAfter),
+	})
+	require.NoError(t, err)
+	require.Nil(t, lastRequest.CreatedBefore)
+	require.Len(t, resp.Results, 2)
+
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{
+		Query:         "vacation plans",
+		CreatedAfter:  timestamppb.New(createdBefore),
+		CreatedBefore: timestamppb.New(createdAfter),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// fakeAiSearchStream collects the results sent on an AiSearchStream call.
+type fakeAiSearchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	results []*apiv1.AiSearchResult
+}
+
+func (s *fakeAiSearchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeAiSearchStream) Send(result *apiv1.AiSearchResult) error {
+	s.results = append(s.results, result)
+	return nil
+}
+
+func TestAiSearchStream(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	withPhrase, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Deploy failed with error E1234", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	withoutPhrase, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Deploy failed with error E5678", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	var lastRequest ai.SearchRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/search/stream" {
+			http.NotFound(w, r)
+			return
+		}
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		encoder := json.NewEncoder(w)
+		for _, name := range []string{withPhrase.Name, withoutPhrase.Name} {
+			_ = encoder.Encode(&ai.SearchResult{MemoUID: name[len("memos/"):], MemoName: name, Score: 0.9, MatchedText: "Deploy failed"})
+		}
+	}))
+
+	t.Run("streams every result", func(t *testing.T) {
+		stream := &fakeAiSearchStream{ctx: userCtx}
+		err := ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy failure", TopK: 50}, stream)
+		require.NoError(t, err)
+		require.Equal(t, 50, lastRequest.TopK)
+		require.Len(t, stream.results, 2)
+		require.Equal(t, withPhrase.Name, stream.results[0].MemoName)
+		require.Equal(t, "Deploy failed", stream.results[0].MatchedText)
+	})
+
+	t.Run("filters results missing a quoted phrase", func(t *testing.T) {
+		stream := &fakeAiSearchStream{ctx: userCtx}
+		err := ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: `deploy failure "E1234"`}, stream)
+		require.NoError(t, err)
+		require.Equal(t, []string{"E1234"}, lastRequest.MustContain)
+		require.Len(t, stream.results, 1)
+		require.Equal(t, withPhrase.Name, stream.results[0].MemoName)
+	})
+
+	t.Run("stops when the client goes away", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(userCtx)
+		cancel()
+		stream := &fakeAiSearchStream{ctx: canceledCtx}
+		err := ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy failure"}, stream)
+		require.Error(t, err)
+		require.Empty(t, stream.results)
+	})
+
+	t.Run("requires authentication", func(t *testing.T) {
+		stream := &fakeAiSearchStream{ctx: ctx}
+		err := ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "deploy failure"}, stream)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestVerifyIndex(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	first, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "first memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	second, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "second memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	firstUID := first.Name[len("memos/"):]
+	secondUID := second.Name[len("memos/"):]
+
+	// The fake AI service reports whatever the test puts in its index.
+	creator := fmt.Sprintf("users/%d", user.ID)
+	indexed := map[string]string{}
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/index/checksum/"+creator {
+			http.NotFound(w, r)
+			return
+		}
+		memos := []ai.IndexedMemo{}
+		for uid, hash := range indexed {
+			memos = append(memos, ai.IndexedMemo{MemoUID: uid, ContentHash: hash})
+		}
+		_ = json.NewEncoder(w).Encode(&ai.IndexChecksumResponse{
+			Checksum: ai.IndexChecksum(indexed),
+			Memos:    memos,
+		})
+	}))
+
+	t.Run("matching checksums", func(t *testing.T) {
+		indexed = map[string]string{
+			firstUID:  ai.ContentHash("first memo"),
+			secondUID: ai.ContentHash("second memo"),
+		}
+		resp, err := ts.Service.VerifyIndex(userCtx, &apiv1.VerifyIndexRequest{Creator: creator})
+		require.NoError(t, err)
+		require.True(t, resp.Match)
+		require.Equal(t, resp.Checksum, resp.IndexChecksum)
+		require.Empty(t, resp.MismatchedMemoUids)
+	})
+
+	t.Run("mismatching checksums", func(t *testing.T) {
+		// The first memo is stale, the second is missing, and a deleted memo is still indexed.
+		indexed = map[string]string{
+			firstUID:  ai.ContentHash("old first memo"),
+			"deleted": ai.ContentHash("deleted memo"),
+		}
+		resp, err := ts.Service.VerifyIndex(userCtx, &apiv1.VerifyIndexRequest{Creator: creator})
+		require.NoError(t, err)
+		require.False(t, resp.Match)
+		require.NotEqual(t, resp.Checksum, resp.IndexChecksum)
+		expected := []string{firstUID, secondUID, "deleted"}
+		require.ElementsMatch(t, expected, resp.MismatchedMemoUids)
+	})
+
+	t.Run("images only memos are indexed without content", func(t *testing.T) {
+		_, err := ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_AI,
+			Value: &storepb.InstanceSetting_AiSetting{
+				AiSetting: &storepb.InstanceAiSetting{
+					AiServiceUrl: server.URL,
+					IndexMode:    "images_only",
+				},
+			},
+		})
+		require.NoError(t, err)
+		indexed = map[string]string{
+			firstUID:  ai.ContentHash(""),
+			secondUID: ai.ContentHash(""),
+		}
+		resp, err := ts.Service.VerifyIndex(userCtx, &apiv1.VerifyIndexRequest{Creator: creator})
+		require.NoError(t, err)
+		require.True(t, resp.Match)
+	})
+
+	t.Run("other users cannot verify the index", func(t *testing.T) {
+		_, err := ts.Service.VerifyIndex(otherUserCtx, &apiv1.VerifyIndexRequest{Creator: creator})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestDeleteCreatorIndex(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "host-user")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	// The fake AI service only has an index for the first user; deleting any other is a 404.
+	creator := fmt.Sprintf("users/%d", user.ID)
+	var mu sync.Mutex
+	deleted := []string{}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, ok := strings.CutPrefix(r.URL.Path, "/internal/index/creator/")
+		if r.Method != http.MethodDelete || !ok {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, path)
+		mu.Unlock()
+		if path != creator {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	t.Run("owner can delete their index", func(t *testing.T) {
+		resp, err := ts.Service.DeleteCreatorIndex(userCtx, &apiv1.DeleteCreatorIndexRequest{Creator: creator})
+		require.NoError(t, err)
+		require.True(t, resp.Success)
+	})
+
+	t.Run("missing index is not an error", func(t *testing.T) {
+		otherCreator := fmt.Sprintf("users/%d", otherUser.ID)
+		resp, err := ts.Service.DeleteCreatorIndex(otherUserCtx, &apiv1.DeleteCreatorIndexRequest{Creator: otherCreator})
+		require.NoError(t, err)
+		require.True(t, resp.Success)
+	})
+
+	t.Run("other users cannot delete the index", func(t *testing.T) {
+		_, err := ts.Service.DeleteCreatorIndex(otherUserCtx, &apiv1.DeleteCreatorIndexRequest{Creator: creator})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("deleting a user deletes their index", func(t *testing.T) {
+		mu.Lock()
+		deleted = deleted[:0]
+		mu.Unlock()
+		_, err := ts.Service.DeleteUser(hostCtx, &apiv1.DeleteUserRequest{Name: creator})
+		require.NoError(t, err)
+		// The index is deleted in the background.
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return slices.Equal(deleted, []string{creator})
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestAiHealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok","version":"1.2.0","embedding_model":"bge-m3","vector_store_ok":true}`))
+	}))
+
+	resp, err := ts.Service.AiHealthCheck(userCtx, &apiv1.AiHealthCheckRequest{})
+	require.NoError(t, err)
+	require.True(t, resp.Healthy)
+	require.Equal(t, "ok", resp.Status)
+	require.Equal(t, "1.2.0", resp.Version)
+	require.Equal(t, "bge-m3", resp.EmbeddingModel)
+	require.NotNil(t, resp.VectorStoreOk)
+	require.True(t, resp.GetVectorStoreOk())
+
+	server.Close()
+	resp, err = ts.Service.AiHealthCheck(userCtx, &apiv1.AiHealthCheckRequest{})
+	require.NoError(t, err)
+	require.False(t, resp.Healthy)
+	require.Nil(t, resp.VectorStoreOk)
+}
+
+func TestGetAiServiceConfig(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	requests := 0
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/config" {
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		_, _ = w.Write([]byte(`{"search_modes":["text","rrf","hybrid","custom"],"image_indexing":true,"max_tags":5,` +
+			`"models":{"tag_generation":"gpt-4.1-mini","text_embedding":"jina-embeddings-v3"}}`))
+	}))
+
+	config, err := ts.Service.GetAiServiceConfig(userCtx, &apiv1.GetAiServiceConfigRequest{})
+	require.NoError(t, err)
+	// Modes AiSearch doesn't accept are left out, in the order of ai.SearchModes.
+	require.Equal(t, []string{ai.SearchModeHybrid, ai.SearchModeText, ai.SearchModeRRF}, config.SearchModes)
+	require.True(t, config.ImageIndexingEnabled)
+	require.Equal(t, int32(5), config.MaxTags)
+	require.Equal(t, "gpt-4.1-mini", config.TagGenerationModel)
+	require.Equal(t, "jina-embeddings-v3", config.TextEmbeddingModel)
+	require.Empty(t, config.ImageEmbeddingModel)
+
+	// The config is cached, even once the AI service is gone.
+	server.Close()
+	cached, err := ts.Service.GetAiServiceConfig(userCtx, &apiv1.GetAiServiceConfigRequest{})
+	require.NoError(t, err)
+	require.Equal(t, config.SearchModes, cached.SearchModes)
+	require.Equal(t, 1, requests)
+
+	// A service not reporting its search modes gets every mode AiSearch accepts.
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"max_tags":3}`))
+	}))
+	config, err = ts.Service.GetAiServiceConfig(userCtx, &apiv1.GetAiServiceConfigRequest{})
+	require.NoError(t, err)
+	require.Equal(t, ai.SearchModes, config.SearchModes)
+	require.False(t, config.ImageIndexingEnabled)
+
+	// An unreachable service that was never cached is an error.
+	unreachable := ts.NewFakeAIService(ctx, t, http.NotFoundHandler())
+	unreachable.Close()
+	_, err = ts.Service.GetAiServiceConfig(userCtx, &apiv1.GetAiServiceConfigRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestAiRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal/search" {
+			_ = json.NewEncoder(w).Encode(&ai.SearchResponse{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{"work"}})
+	}))
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl:        server.URL,
+				AiRequestsPerMinute: 2,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Quarterly planning notes", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	hostMemo, err := ts.Service.CreateMemo(hostCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Release checklist", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	t.Run("limits regular users", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+			require.NoError(t, err)
+		}
+		_, err := ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("limits each method separately", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "planning"})
+			require.NoError(t, err)
+		}
+		_, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "planning"})
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		err = ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "planning"}, &fakeAiSearchStream{ctx: userCtx})
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("exempts the host", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			_, err := ts.Service.GenerateAiTags(hostCtx, &apiv1.GenerateAiTagsRequest{Name: hostMemo.Name})
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestBatchGenerateAiTags(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	// The fake AI service tags each memo with its content and fails for "broken". Given a language,
+	// it also suggests the language and "generic".
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/tags/generate" {
+			http.NotFound(w, r)
+			return
+		}
+		var req ai.TagGenerationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Memo.Content == "broken" {
+			http.Error(w, "model failed", http.StatusInternalServerError)
+			return
+		}
+		tags := []string{req.Memo.Content}
+		if req.Language != "" {
+			tags = append(tags, req.Language, "generic")
+		}
+		_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: tags})
+	}))
+
+	names := []string{}
+	for _, content := range []string{"work", "travel", "broken"} {
+		memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: content, Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		names = append(names, memo.Name)
+	}
+	otherMemo, err := ts.Service.CreateMemo(otherCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "secret", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+
+	resp, err := ts.Service.BatchGenerateAiTags(userCtx, &apiv1.BatchGenerateAiTagsRequest{
+		Names: append(names, names[0], otherMemo.Name, "memos/missing"),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 5)
+	require.Equal(t, []string{"work"}, resp.Results[names[0]].Tags)
+	require.Empty(t, resp.Results[names[0]].Error)
+	require.Equal(t, []string{"travel"}, resp.Results[names[1]].Tags)
+	require.Empty(t, resp.Results[names[2]].Tags)
+	require.Contains(t, resp.Results[names[2]].Error, "model failed")
+	require.Equal(t, "permission denied", resp.Results[otherMemo.Name].Error)
+	require.Equal(t, "memo not found", resp.Results["memos/missing"].Error)
+
+	// The language and excluded tags apply to every memo.
+	resp, err = ts.Service.BatchGenerateAiTags(userCtx, &apiv1.BatchGenerateAiTagsRequest{
+		Names:       names[:2],
+		ExcludeTags: []string{"#Generic"},
+		Language:    "de",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"work", "de"}, resp.Results[names[0]].Tags)
+	require.Equal(t, []string{"travel", "de"}, resp.Results[names[1]].Tags)
+
+	// Single memos are checked the same way.
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: otherMemo.Name})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = ts.Service.BatchGenerateAiTags(userCtx, &apiv1.BatchGenerateAiTagsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	tooMany := []string{}
+	for i := 0; i < 51; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("memos/memo-%d", i))
+	}
+	_, err = ts.Service.BatchGenerateAiTags(userCtx, &apiv1.BatchGenerateAiTagsRequest{Names: tooMany})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAiSearchScope(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+
+	memos := []struct {
+		uid        string
+		creatorID  int32
+		visibility store.Visibility
+	}{
+		{"own-private", user.ID, store.Private},
+		{"own-public", user.ID, store.Public},
+		{"other-public", otherUser.ID, store.Public},
+		{"other-protected", otherUser.ID, store.Protected},
+		{"other-private", otherUser.ID, store.Private},
+	}
+	results := []ai.SearchResult{}
+	for _, memo := range memos {
+		_, err := ts.Store.CreateMemo(ctx, &store.Memo{UID: memo.uid, CreatorID: memo.creatorID, Content: "notes", Visibility: memo.visibility})
+		require.NoError(t, err)
+		results = append(results, ai.SearchResult{MemoUID: "memos/" + memo.uid, MemoName: "memos/" + memo.uid})
+	}
+
+	// The fake AI service returns every memo regardless of the creator.
+	var lastRequest ai.SearchRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/internal/search/stream" {
+			for _, result := range results {
+				_ = json.NewEncoder(w).Encode(&result)
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: results, TotalResults: len(results)})
+	}))
+
+	search := func(scope apiv1.AiSearchRequest_Scope) []string {
+		resp, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", Scope: scope})
+		require.NoError(t, err)
+		uids := []string{}
+		for _, r := range resp.Results {
+			uids = append(uids, r.MemoUid)
+		}
+		return uids
+	}
+
+	require.Len(t, search(apiv1.AiSearchRequest_SCOPE_UNSPECIFIED), 5)
+	require.Equal(t, fmt.Sprintf("users/%d", user.ID), lastRequest.Creator)
+	require.Empty(t, lastRequest.Visibility)
+	require.Len(t, search(apiv1.AiSearchRequest_SCOPE_OWN), 5)
+	require.Equal(t, fmt.Sprintf("users/%d", user.ID), lastRequest.Creator)
+	require.Empty(t, lastRequest.Visibility)
+
+	// Public searches ask the AI service for public memos only, but still post filter what it returns.
+	require.Equal(t, []string{"own-public", "other-public"}, search(apiv1.AiSearchRequest_SCOPE_PUBLIC))
+	require.Empty(t, lastRequest.Creator)
+	require.Equal(t, "PUBLIC", lastRequest.Visibility)
+	require.Equal(t, []string{"own-private", "own-public", "other-public"}, search(apiv1.AiSearchRequest_SCOPE_ALL_ACCESSIBLE))
+	require.Empty(t, lastRequest.Creator)
+	require.Empty(t, lastRequest.Visibility)
+
+	stream := &fakeAiSearchStream{ctx: userCtx}
+	err = ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "notes", Scope: apiv1.AiSearchRequest_SCOPE_PUBLIC}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.results, 2)
+
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", Scope: apiv1.AiSearchRequest_Scope(42)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAiAsk(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	createMemo := func(ctx context.Context, content string, visibility apiv1.Visibility) string {
+		memo, err := ts.Service.CreateMemo(ctx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: content, Visibility: visibility},
+		})
+		require.NoError(t, err)
+		return memo.Name[len("memos/"):]
+	}
+	ownPrivate := createMemo(userCtx, "own private", apiv1.Visibility_PRIVATE)
+	otherPrivate := createMemo(otherUserCtx, "other private", apiv1.Visibility_PRIVATE)
+	otherPublic := createMemo(otherUserCtx, "other public", apiv1.Visibility_PUBLIC)
+
+	var lastPath string
+	var lastRequest ai.AskRequest
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.URL.Path
+		lastRequest = ai.AskRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.AskResponse{
+			Success:   true,
+			Answer:    "the answer",
+			Citations: []string{ownPrivate, otherPrivate, otherPublic, ownPrivate, "deleted-memo"},
+			Usage:     ai.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+		})
+	}))
+
+	resp, err := ts.Service.AiAsk(userCtx, &apiv1.AiAskRequest{Question: "what did I write?"})
+	require.NoError(t, err)
+	require.Equal(t, "/api/v1/ask", lastPath)
+	require.Equal(t, "what did I write?", lastRequest.Question)
+	require.Equal(t, fmt.Sprintf("users/%d", user.ID), lastRequest.Creator)
+	require.Equal(t, 5, lastRequest.TopK)
+	require.Equal(t, "the answer", resp.Answer)
+	require.Equal(t, []string{ownPrivate, otherPublic}, resp.CitedMemoUids)
+	require.Equal(t, int32(15), resp.Usage.TotalTokens)
+
+	_, err = ts.Service.AiAsk(userCtx, &apiv1.AiAskRequest{Question: "what did I write?", TopK: 100})
+	require.NoError(t, err)
+	require.Equal(t, 20, lastRequest.TopK)
+
+	_, err = ts.Service.AiAsk(userCtx, &apiv1.AiAskRequest{Question: " "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = ts.Service.AiAsk(userCtx, &apiv1.AiAskRequest{Question: "what did I write?", TopK: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetMemoIndexInfoStale(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "an edited memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	var indexedAt time.Time
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&ai.MemoIndexInfo{MemoUID: memo.Name, TextCount: 1, IndexedAt: indexedAt})
+	}))
+
+	// Indexed before the last edit.
+	indexedAt = memo.UpdateTime.AsTime().Add(-time.Hour)
+	info, err := ts.Service.GetMemoIndexInfo(userCtx, &apiv1.GetMemoIndexInfoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.True(t, info.Stale)
+	require.True(t, indexedAt.Equal(info.IndexedAt.AsTime()))
+
+	// Indexed in the same second as the last edit.
+	indexedAt = memo.UpdateTime.AsTime()
+	info, err = ts.Service.GetMemoIndexInfo(userCtx, &apiv1.GetMemoIndexInfoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, info.Stale)
+
+	// The AI service doesn't report when the memo was indexed.
+	indexedAt = time.Time{}
+	info, err = ts.Service.GetMemoIndexInfo(userCtx, &apiv1.GetMemoIndexInfoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, info.Stale)
+	require.Nil(t, info.IndexedAt)
+}
+
+func TestIndexMemoOperation(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// The fake AI service rejects creating an indexed memo and replacing one that isn't indexed.
+	indexed := map[string]bool{}
+	var operations []string
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Memo      map[string]interface{} `json:"memo"`
+			Operation string                 `json:"operation"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		operations = append(operations, req.Operation)
+		uid, _ := req.Memo["uid"].(string)
+		switch {
+		case req.Operation == ai.IndexOperationCreate && indexed[uid]:
+			http.Error(w, "already indexed", http.StatusConflict)
+			return
+		case req.Operation == ai.IndexOperationReplace && !indexed[uid]:
+			http.Error(w, "not indexed", http.StatusNotFound)
+			return
+		}
+		indexed[uid] = true
+		_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{MemoUID: uid, Status: "indexed"})
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "a memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Operation: "replace"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Operation: "create"})
+	require.NoError(t, err)
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Operation: "create"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Operation: "replace"})
+	require.NoError(t, err)
+	// The memo is unchanged since the replace, so only a forced upsert is sent.
+	resp, err := ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.True(t, resp.Skipped)
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Force: true})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.Equal(t, []string{"replace", "create", "create", "replace", "upsert"}, operations)
+
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Operation: "refresh"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Len(t, operations, 5)
+}
+
+func TestIndexMemoSkipsUnchangedMemos(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	indexRequests := 0
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/internal/index/memo" && r.Method == http.MethodPost:
+			indexRequests++
+			_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{Status: "indexed"})
+		case r.Method == http.MethodDelete:
+			_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "first version", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	resp, err := ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.Equal(t, 1, indexRequests)
+
+	// Indexing the unchanged memo again doesn't reach the AI service.
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.True(t, resp.Skipped)
+	require.Equal(t, "unchanged", resp.Status)
+	require.Equal(t, 1, indexRequests)
+
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name, Force: true})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.Equal(t, 2, indexRequests)
+
+	// Changing the content or the AI tags makes the memo indexed again.
+	_, err = ts.Service.UpdateMemo(userCtx, &apiv1.UpdateMemoRequest{
+		Memo:       &apiv1.Memo{Name: memo.Name, Content: "second version"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+	})
+	require.NoError(t, err)
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.Equal(t, 3, indexRequests)
+
+	_, err = ts.Service.ApplyAiTags(userCtx, &apiv1.ApplyAiTagsRequest{Name: memo.Name, Tags: []string{"draft"}})
+	require.NoError(t, err)
+	require.Equal(t, 4, indexRequests)
+
+	// A deleted index is sent again even though the memo is unchanged.
+	_, err = ts.Service.DeleteMemoIndex(userCtx, &apiv1.DeleteMemoIndexRequest{Name: memo.Name})
+	require.NoError(t, err)
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.Equal(t, 5, indexRequests)
+
+	_, err = ts.Service.DeleteCreatorIndex(userCtx, &apiv1.DeleteCreatorIndexRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.NoError(t, err)
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.Equal(t, 6, indexRequests)
+}
+
+func TestIndexMemoConfirmsQueuedIndex(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// The fake AI service queues memos and reports the content hash once the queued index succeeded.
+	indexRequests, statusRequests := 0, 0
+	indexed := false
+	contentHash := ""
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/internal/index/memo" && r.Method == http.MethodPost:
+			indexRequests++
+			var req ai.IndexMemoRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			contentHash = req.ContentHash
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{Status: "accepted"})
+		case strings.HasPrefix(r.URL.Path, "/internal/index/memo/") && r.Method == http.MethodGet:
+			statusRequests++
+			if !indexed {
+				http.NotFound(w, r)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&ai.MemoIndexInfo{MemoUID: strings.TrimPrefix(r.URL.Path, "/internal/index/memo/"), ContentHash: contentHash})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "queued", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	resp, err := ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.NotEmpty(t, contentHash)
+
+	// While the queued index hasn't succeeded, the memo is sent again.
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.False(t, resp.Skipped)
+	require.Equal(t, 2, indexRequests)
+
+	// Once the AI service reports the hash, the memo is skipped, and later checks are local.
+	indexed = true
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.True(t, resp.Skipped)
+	require.Equal(t, "unchanged", resp.Status)
+	require.Equal(t, 2, indexRequests)
+	checks := statusRequests
+	resp, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.True(t, resp.Skipped)
+	require.Equal(t, checks, statusRequests)
+}
+
+func TestGetRebuildStatusFailedMemos(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, host.ID)
+
+	taskStatus := &ai.RebuildTaskStatus{Status: ai.RebuildStatusCompleted, Total: 3, Completed: 1, Failed: 2}
+	var lastPath string
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.URL.Path
+		_ = json.NewEncoder(w).Encode(taskStatus)
+	}))
+
+	// Without failed memos reported, the list stays empty.
+	resp, err := ts.Service.GetRebuildStatus(hostCtx, &apiv1.GetRebuildStatusRequest{Creator: "users/1"})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.Failed)
+	require.Empty(t, resp.FailedMemos)
+
+	taskStatus.FailedMemos = []ai.FailedMemo{
+		{MemoUID: "memo-1", Error: "embedding failed"},
+		{MemoUID: "memo-2", Error: "attachment too large"},
+	}
+	resp, err = ts.Service.GetRebuildStatus(hostCtx, &apiv1.GetRebuildStatusRequest{Creator: "users/1"})
+	require.NoError(t, err)
+	require.Equal(t, "/internal/index/rebuild/users/1", lastPath)
+	require.Len(t, resp.FailedMemos, 2)
+	require.Equal(t, "memo-1", resp.FailedMemos[0].MemoUid)
+	require.Equal(t, "embedding failed", resp.FailedMemos[0].Error)
+	require.Equal(t, "memo-2", resp.FailedMemos[1].MemoUid)
+	require.Equal(t, "attachment too large", resp.FailedMemos[1].Error)
+
+	// Regular users can only see their own rebuilds.
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	_, err = ts.Service.GetRebuildStatus(userCtx, &apiv1.GetRebuildStatusRequest{Creator: "users/1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = ts.Service.GetRebuildStatus(userCtx, &apiv1.GetRebuildStatusRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.NoError(t, err)
+}
+
+func TestRebuildIndexCallbackURL(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, host.ID)
+
+	var rebuildReq *ai.RebuildIndexRequest
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rebuildReq = &ai.RebuildIndexRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(rebuildReq))
+		_ = json.NewEncoder(w).Encode(&ai.RebuildIndexResponse{Creator: rebuildReq.Creator, Status: "accepted"})
+	}))
+
+	// Without a callback URL, none is passed to the AI service.
+	_, err = ts.Service.RebuildIndex(hostCtx, &apiv1.RebuildIndexRequest{Creator: "users/1"})
+	require.NoError(t, err)
+	require.Empty(t, rebuildReq.CallbackURL)
+
+	_, err = ts.Service.RebuildIndex(hostCtx, &apiv1.RebuildIndexRequest{Creator: "users/1", CallbackUrl: "https://203.0.113.5/hooks/rebuild"})
+	require.NoError(t, err)
+	require.Equal(t, "https://203.0.113.5/hooks/rebuild", rebuildReq.CallbackURL)
+	require.False(t, rebuildReq.AllowInternalCallback)
+
+	// Internal addresses are rejected before reaching the AI service.
+	rebuildReq = nil
+	_, err = ts.Service.RebuildIndex(hostCtx, &apiv1.RebuildIndexRequest{Creator: "users/1", CallbackUrl: "http://127.0.0.1:8081/hooks/rebuild"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Nil(t, rebuildReq)
+
+	// Unless the AI setting allows them.
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl:              server.URL,
+				AllowInternalCallbackUrls: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.RebuildIndex(hostCtx, &apiv1.RebuildIndexRequest{Creator: "users/1", CallbackUrl: "http://127.0.0.1:8081/hooks/rebuild"})
+	require.NoError(t, err)
+	require.Equal(t, "http://127.0.0.1:8081/hooks/rebuild", rebuildReq.CallbackURL)
+	require.True(t, rebuildReq.AllowInternalCallback)
+
+	// Regular users can only rebuild their own index.
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	rebuildReq = nil
+	_, err = ts.Service.RebuildIndex(userCtx, &apiv1.RebuildIndexRequest{Creator: "users/1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Nil(t, rebuildReq)
+	_, err = ts.Service.RebuildIndex(userCtx, &apiv1.RebuildIndexRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("users/%d", user.ID), rebuildReq.Creator)
+}
+
+func TestListIndexedMemos(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, host.ID)
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastPath, lastPageToken string
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.URL.Path
+		lastPageToken = r.URL.Query().Get("page_token")
+		if lastPageToken == "" {
+			_, _ = w.Write([]byte(`{"memo_uids":["memo-1","memo-2"],"next_page_token":"page-2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"memo_uids":[]}`))
+	}))
+
+	resp, err := ts.Service.ListIndexedMemos(hostCtx, &apiv1.ListIndexedMemosRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("/internal/index/creator/users/%d/memos", user.ID), lastPath)
+	require.Equal(t, []string{"memo-1", "memo-2"}, resp.MemoUids)
+	require.Equal(t, "page-2", resp.NextPageToken)
+
+	resp, err = ts.Service.ListIndexedMemos(hostCtx, &apiv1.ListIndexedMemosRequest{Creator: fmt.Sprintf("users/%d", user.ID), PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+	require.Equal(t, "page-2", lastPageToken)
+	require.NotNil(t, resp.MemoUids)
+	require.Empty(t, resp.MemoUids)
+	require.Empty(t, resp.NextPageToken)
+
+	// Only admins can list indexed memos, even their own.
+	_, err = ts.Service.ListIndexedMemos(userCtx, &apiv1.ListIndexedMemosRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = ts.Service.ListIndexedMemos(hostCtx, &apiv1.ListIndexedMemosRequest{Creator: "memos/1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestReconcileIndex(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, host.ID)
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	for _, uid := range []string{"kept", "missing-1", "missing-2"} {
+		_, err := ts.Store.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: user.ID, Content: "notes", Visibility: store.Private})
+		require.NoError(t, err)
+	}
+
+	// The index holds one stored memo and two orphans over two pages. Deleting one of the orphans fails.
+	var mu sync.Mutex
+	var indexedUIDs, deletedUIDs []string
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/memos"):
+			if r.URL.Query().Get("page_token") == "" {
+				_, _ = w.Write([]byte(`{"memo_uids":["kept","orphan-1"],"next_page_token":"page-2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"memo_uids":["orphan-2"]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/internal/index/memo":
+			var item ai.IndexMemoRequest
+			if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			uid := item.Memo.UID
+			indexedUIDs = append(indexedUIDs, uid)
+			_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{MemoUID: uid, Status: "indexed"})
+		case r.Method == http.MethodDelete:
+			uid := strings.TrimPrefix(r.URL.Path, "/internal/index/memo/")
+			if uid == "orphan-2" {
+				http.Error(w, `{"detail":"index is locked"}`, http.StatusConflict)
+				return
+			}
+			deletedUIDs = append(deletedUIDs, uid)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	resp, err := ts.Service.ReconcileIndex(userCtx, &apiv1.ReconcileIndexRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.Added)
+	require.Equal(t, int32(1), resp.Removed)
+	require.Equal(t, int32(1), resp.Unchanged)
+	require.ElementsMatch(t, []string{"missing-1", "missing-2"}, indexedUIDs)
+	require.Equal(t, []string{"orphan-1"}, deletedUIDs)
+	require.Len(t, resp.Failures, 1)
+	require.Equal(t, "orphan-2", resp.Failures[0].MemoUid)
+	require.Contains(t, resp.Failures[0].Error, "index is locked")
+
+	// Indexed memos are known to be unchanged, so indexing them again is skipped.
+	indexResp, err := ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: "memos/missing-1"})
+	require.NoError(t, err)
+	require.True(t, indexResp.Skipped)
+
+	_, err = ts.Service.ReconcileIndex(hostCtx, &apiv1.ReconcileIndexRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.NoError(t, err)
+	_, err = ts.Service.ReconcileIndex(otherUserCtx, &apiv1.ReconcileIndexRequest{Creator: fmt.Sprintf("users/%d", user.ID)})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCancelRebuild(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, host.ID)
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other-user")
+	require.NoError(t, err)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	// The fake AI service holds a running rebuild of the user, which a cancel stops.
+	creator := fmt.Sprintf("users/%d", user.ID)
+	taskStatus := &ai.RebuildTaskStatus{Status: "running", Total: 10, Completed: 3}
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/index/rebuild/"+creator {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			if taskStatus.Status == "running" {
+				taskStatus.Status = ai.RebuildStatusCancelled
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_ = json.NewEncoder(w).Encode(taskStatus)
+		}
+	}))
+
+	_, err = ts.Service.CancelRebuild(otherUserCtx, &apiv1.CancelRebuildRequest{Creator: creator})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err := ts.Service.CancelRebuild(userCtx, &apiv1.CancelRebuildRequest{Creator: creator})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	rebuildStatus, err := ts.Service.GetRebuildStatus(userCtx, &apiv1.GetRebuildStatusRequest{Creator: creator})
+	require.NoError(t, err)
+	require.Equal(t, ai.RebuildStatusCancelled, rebuildStatus.Status)
+	require.Equal(t, int32(3), rebuildStatus.Completed)
+
+	// Cancelling again, or a rebuild that doesn't exist, still succeeds.
+	resp, err = ts.Service.CancelRebuild(hostCtx, &apiv1.CancelRebuildRequest{Creator: creator})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	resp, err = ts.Service.CancelRebuild(hostCtx, &apiv1.CancelRebuildRequest{Creator: fmt.Sprintf("users/%d", otherUser.ID)})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestIndexMemoSendsExtractedText(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastIndexedMemo *ai.MemoDocument
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var item ai.IndexMemoRequest
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		lastIndexedMemo = item.Memo
+		_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{Status: "indexed"})
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "memo with a receipt", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	memoUID := memo.Name[len("memos/"):]
+	storeMemo, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	require.NoError(t, err)
+
+	for _, att := range []*store.Attachment{
+		{UID: "receipt", Filename: "receipt.png", Payload: &storepb.AttachmentPayload{ExtractedText: "TOTAL 42.00"}},
+		{UID: "photo", Filename: "photo.png"},
+	} {
+		att.CreatorID = user.ID
+		att.MemoID = &storeMemo.ID
+		att.Type = "image/png"
+		att.Blob = []byte("image")
+		att.Size = 5
+		_, err := ts.Store.CreateAttachment(ctx, att)
+		require.NoError(t, err)
+	}
+
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Len(t, lastIndexedMemo.Attachments, 2)
+	for _, att := range lastIndexedMemo.Attachments {
+		if att.Filename == "receipt.png" {
+			require.Equal(t, "TOTAL 42.00", att.ExtractedText)
+		} else {
+			require.Empty(t, att.ExtractedText)
+		}
+	}
+}
+
+func TestAiSearchMode(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastRequest ai.SearchRequest
+	requests := 0
+	ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: []ai.SearchResult{}, SearchMode: lastRequest.SearchMode})
+	}))
+
+	modes, err := ts.Service.ListAiSearchModes(userCtx, &apiv1.ListAiSearchModesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "hybrid", modes.DefaultSearchMode)
+	require.Equal(t, []string{"hybrid", "vector", "text", "image", "rrf", "weighted", "bm25", "bm25_vector", "bm25_vector_alpha", "adaptive"}, modes.SearchModes)
+
+	for _, mode := range modes.SearchModes {
+		_, err := ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", SearchMode: mode})
+		require.NoError(t, err, mode)
+		require.Equal(t, mode, lastRequest.SearchMode)
+	}
+
+	// An empty mode defaults to hybrid.
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes"})
+	require.NoError(t, err)
+	require.Equal(t, "hybrid", lastRequest.SearchMode)
+
+	// Unknown modes are rejected before reaching the AI service.
+	sent := requests
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", SearchMode: "hybrd"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = ts.Service.AiSearchStream(&apiv1.AiSearchRequest{Query: "notes", SearchMode: "Hybrid"}, &fakeAiSearchStream{ctx: userCtx})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	// The AI service has no retriever named "semantic" or "keyword".
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", SearchMode: "semantic"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", SearchMode: "keyword"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, sent, requests)
+}
+
+func TestAiSearchInstanceDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastRequest ai.SearchRequest
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = ai.SearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ai.SearchResponse{Results: []ai.SearchResult{}, SearchMode: lastRequest.SearchMode})
+	}))
+
+	// Without instance defaults, the built-in ones apply.
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes"})
+	require.NoError(t, err)
+	require.Equal(t, 10, lastRequest.TopK)
+	require.Equal(t, float32(0.5), lastRequest.MinScore)
+	require.Equal(t, "hybrid", lastRequest.SearchMode)
+
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl:          server.URL,
+				DefaultSearchTopK:     25,
+				DefaultSearchMinScore: 0.3,
+				DefaultSearchMode:     "vector",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	modes, err := ts.Service.ListAiSearchModes(userCtx, &apiv1.ListAiSearchModesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "vector", modes.DefaultSearchMode)
+
+	// Unset request fields take the instance defaults.
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes"})
+	require.NoError(t, err)
+	require.Equal(t, 25, lastRequest.TopK)
+	require.Equal(t, float32(0.3), lastRequest.MinScore)
+	require.Equal(t, "vector", lastRequest.SearchMode)
+
+	// Request values take precedence.
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", TopK: 3, MinScore: 0.8, SearchMode: "bm25"})
+	require.NoError(t, err)
+	require.Equal(t, 3, lastRequest.TopK)
+	require.Equal(t, float32(0.8), lastRequest.MinScore)
+	require.Equal(t, "bm25", lastRequest.SearchMode)
+
+	// The instance top_k bounds paged searches like the request's.
+	_, err = ts.Service.AiSearch(userCtx, &apiv1.AiSearchRequest{Query: "notes", PageSize: 50})
+	require.NoError(t, err)
+	require.Equal(t, 25, lastRequest.TopK)
+}
+
+func TestAiRequestsTruncateLongContent(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	var lastTagRequest ai.TagGenerationRequest
+	var lastIndexedMemo *ai.MemoDocument
+	server := ts.NewFakeAIService(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/tags/generate":
+			lastTagRequest = ai.TagGenerationRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&lastTagRequest); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&ai.TagGenerationResponse{Success: true, Tags: []string{}})
+		case "/internal/index/memo":
+			var item ai.IndexMemoRequest
+			if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			lastIndexedMemo = item.Memo
+			_ = json.NewEncoder(w).Encode(&ai.IndexMemoResponse{Status: "indexed"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "short memo", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	// Content within the default limit is sent as is.
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "short memo", lastTagRequest.Memo.Content)
+
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_AI,
+		Value: &storepb.InstanceSetting_AiSetting{
+			AiSetting: &storepb.InstanceAiSetting{
+				AiServiceUrl:    server.URL,
+				MaxContentChars: 5,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ts.Service.GenerateAiTags(userCtx, &apiv1.GenerateAiTagsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "short", lastTagRequest.Memo.Content)
+	_, err = ts.Service.IndexMemo(userCtx, &apiv1.IndexMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "short", lastIndexedMemo.Content)
+	_, err = ts.Service.RefreshMemoIndex(userCtx, &apiv1.RefreshMemoIndexRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, "short", lastIndexedMemo.Content)
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
 )
@@ -191,3 +193,45 @@ func TestDeleteMemoReaction(t *testing.T) {
 		require.NotContains(t, err.Error(), "not found")
 	})
 }
+
+func TestUpsertMemoReactionValidatesType(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{
+			Content:    "Test memo",
+			Visibility: apiv1.Visibility_PUBLIC,
+		},
+	})
+	require.NoError(t, err)
+
+	upsert := func(reactionType string) error {
+		_, err := ts.Service.UpsertMemoReaction(userCtx, &apiv1.UpsertMemoReactionRequest{
+			Name: memo.Name,
+			Reaction: &apiv1.Reaction{
+				ContentId:    memo.Name,
+				ReactionType: reactionType,
+			},
+		})
+		return err
+	}
+
+	for _, reactionType := range []string{"👍", "👩‍💻", "+1"} {
+		require.NoError(t, upsert(reactionType), reactionType)
+	}
+	for _, reactionType := range []string{"", "\xff", "👍👎", "👍\x00"} {
+		require.Equal(t, codes.InvalidArgument, status.Code(upsert(reactionType)), reactionType)
+	}
+
+	// Rejected reactions are not stored.
+	reactions, err := ts.Service.ListMemoReactions(userCtx, &apiv1.ListMemoReactionsRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Len(t, reactions.Reactions, 3)
+}
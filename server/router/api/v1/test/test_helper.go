@@ -58,6 +58,7 @@ func NewTestService(t *testing.T) *TestService {
 // Cleanup clears caches and closes resources after test.
 func (ts *TestService) Cleanup() {
 	ts.Store.Close()
+	apiv1.ClearUserTagCache(context.Background())
 	// Note: Owner cache is package-level in parent package, cannot clear from test package
 }
 
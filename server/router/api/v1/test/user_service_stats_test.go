@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -103,3 +105,87 @@ func TestGetUserStats_TagCount(t *testing.T) {
 	require.Contains(t, response3.TagCount, "test")
 	require.Equal(t, int32(2), response3.TagCount["test"], "Original tag count should remain 2")
 }
+
+func TestListUserTags(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test_user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUser, err := ts.CreateRegularUser(ctx, "other_user")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	memos := []struct {
+		visibility store.Visibility
+		payload    *storepb.MemoPayload
+	}{
+		{store.Public, &storepb.MemoPayload{Tags: []string{"work", "go"}, AiTags: []string{"golang"}}},
+		{store.Public, &storepb.MemoPayload{Tags: []string{"work"}, AiTags: []string{"work", "golang"}}},
+		{store.Protected, &storepb.MemoPayload{Tags: []string{"team"}}},
+		{store.Private, &storepb.MemoPayload{Tags: []string{"diary", "work"}}},
+	}
+	for i, memo := range memos {
+		_, err := ts.Store.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    "content",
+			Visibility: memo.visibility,
+			Payload:    memo.payload,
+		})
+		require.NoError(t, err)
+	}
+	userName := fmt.Sprintf("users/%d", user.ID)
+
+	t.Run("own tags", func(t *testing.T) {
+		resp, err := ts.Service.ListUserTags(userCtx, &v1pb.ListUserTagsRequest{Parent: userName})
+		require.NoError(t, err)
+		require.Len(t, resp.Tags, 5)
+		require.Equal(t, "diary", resp.Tags[0].Tag)
+		require.Equal(t, "go", resp.Tags[1].Tag)
+		golang := resp.Tags[2]
+		require.Equal(t, "golang", golang.Tag)
+		require.Equal(t, int32(2), golang.Count)
+		require.Equal(t, int32(0), golang.ManualCount)
+		require.Equal(t, int32(2), golang.AiCount)
+		require.Equal(t, "team", resp.Tags[3].Tag)
+		work := resp.Tags[4]
+		require.Equal(t, "work", work.Tag)
+		require.Equal(t, int32(4), work.Count)
+		require.Equal(t, int32(3), work.ManualCount)
+		require.Equal(t, int32(1), work.AiCount)
+	})
+
+	t.Run("min count", func(t *testing.T) {
+		resp, err := ts.Service.ListUserTags(userCtx, &v1pb.ListUserTagsRequest{Parent: userName, MinCount: 2})
+		require.NoError(t, err)
+		require.Len(t, resp.Tags, 2)
+		require.Equal(t, "golang", resp.Tags[0].Tag)
+		require.Equal(t, "work", resp.Tags[1].Tag)
+
+		_, err = ts.Service.ListUserTags(userCtx, &v1pb.ListUserTagsRequest{Parent: userName, MinCount: -1})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("other users only count visible memos", func(t *testing.T) {
+		resp, err := ts.Service.ListUserTags(otherCtx, &v1pb.ListUserTagsRequest{Parent: userName})
+		require.NoError(t, err)
+		tags := []string{}
+		for _, tag := range resp.Tags {
+			tags = append(tags, tag.Tag)
+		}
+		require.Equal(t, []string{"go", "golang", "team", "work"}, tags)
+		require.Equal(t, int32(3), resp.Tags[3].Count)
+
+		resp, err = ts.Service.ListUserTags(ctx, &v1pb.ListUserTagsRequest{Parent: userName})
+		require.NoError(t, err)
+		tags = []string{}
+		for _, tag := range resp.Tags {
+			tags = append(tags, tag.Tag)
+		}
+		require.Equal(t, []string{"go", "golang", "work"}, tags)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"regexp"
 	"slices"
@@ -334,6 +335,20 @@ func (s *APIV1Service) DeleteUser(ctx context.Context, request *v1pb.DeleteUserR
 		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", err)
 	}
 
+	// Clean up the AI indexes of the user's memos in the background, so a slow AI service doesn't
+	// hold up the deletion. The user is already deleted, so a failure is only logged; a later
+	// rebuild or verification will surface the leftovers.
+	creator := fmt.Sprintf("%s%d", UserNamePrefix, user.ID)
+	if aiClient, err := s.getAIClient(ctx); err != nil {
+		slog.Warn("Failed to get AI client to delete creator index", slog.String("creator", creator), slog.Any("err", err))
+	} else {
+		go func() {
+			if err := aiClient.DeleteCreatorIndex(context.WithoutCancel(ctx), creator); err != nil {
+				slog.Warn("Failed to delete creator index", slog.String("creator", creator), slog.Any("err", err))
+			}
+		}()
+	}
+
 	return &emptypb.Empty{}, nil
 }
 
@@ -1301,6 +1316,11 @@ func convertUserSettingFromStore(storeSetting *storepb.UserSetting, userID int32
 		return setting
 	}
 
+	if storeSetting.Key == storepb.UserSetting_AI_INDEX_DELETIONS {
+		// Pending AI index deletions are internal state, not a setting of the user.
+		return nil
+	}
+
 	settingKey := convertSettingKeyFromStore(storeSetting.Key)
 	setting := &v1pb.UserSetting{
 		Name: fmt.Sprintf("users/%d/settings/%s", userID, settingKey),
@@ -206,3 +206,49 @@ func (s *APIV1Service) GetUserStats(ctx context.Context, request *v1pb.GetUserSt
 
 	return userStats, nil
 }
+
+// ListUserTags returns the tags of a user's memos visible to the current user, with their counts.
+// Tags are aggregated by the store from the memo payloads rather than by listing the memos.
+func (s *APIV1Service) ListUserTags(ctx context.Context, request *v1pb.ListUserTagsRequest) (*v1pb.ListUserTagsResponse, error) {
+	userID, err := ExtractUserIDFromName(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	if request.MinCount < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "min_count must not be negative")
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	normalStatus := store.Normal
+	memoTagFind := &store.FindMemoTag{
+		CreatorID:       &userID,
+		RowStatus:       &normalStatus,
+		ExcludeComments: true,
+		MinCount:        int(request.MinCount),
+	}
+	if currentUser == nil {
+		memoTagFind.VisibilityList = []store.Visibility{store.Public}
+	} else if currentUser.ID != userID {
+		memoTagFind.VisibilityList = []store.Visibility{store.Public, store.Protected}
+	}
+
+	memoTags, err := s.Store.ListMemoTags(ctx, memoTagFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+
+	tags := make([]*v1pb.UserTag, 0, len(memoTags))
+	for _, memoTag := range memoTags {
+		tags = append(tags, &v1pb.UserTag{
+			Tag:         memoTag.Tag,
+			Count:       int32(memoTag.Count),
+			ManualCount: int32(memoTag.ManualCount),
+			AiCount:     int32(memoTag.AiCount),
+		})
+	}
+	return &v1pb.ListUserTagsResponse{Tags: tags}, nil
+}
@@ -3,7 +3,9 @@ package v1
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"math"
+	"os"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
@@ -18,6 +20,7 @@ import (
 	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/plugin/markdown"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/ai"
 	"github.com/usememos/memos/store"
 )
 
@@ -37,6 +40,12 @@ type APIV1Service struct {
 	Profile         *profile.Profile
 	Store           *store.Store
 	MarkdownService markdown.Service
+	// AIMetrics records the requests sent to the AI service. Nil records nothing.
+	AIMetrics *ai.Metrics
+	// AIBreaker stops AI service requests after consecutive failures. Nil never stops them.
+	AIBreaker *ai.CircuitBreaker
+	// AILogger logs the requests sent to the AI service. Nil logs them to slog.Default().
+	AILogger *slog.Logger
 
 	grpcServer *grpc.Server
 
@@ -54,9 +63,15 @@ func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store
 		Profile:            profile,
 		Store:              store,
 		MarkdownService:    markdownService,
+		AIBreaker:          ai.NewCircuitBreaker(),
 		grpcServer:         grpcServer,
 		thumbnailSemaphore: semaphore.NewWeighted(3), // Limit to 3 concurrent thumbnail generations
 	}
+	if profile.Mode == "dev" {
+		// Successful AI service requests are logged at debug level, which only the AI client enables
+		// in dev mode, leaving the level of the default logger alone.
+		apiv1Service.AILogger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
 	grpc_health_v1.RegisterHealthServer(grpcServer, apiv1Service)
 	v1pb.RegisterInstanceServiceServer(grpcServer, apiv1Service)
 	v1pb.RegisterAuthServiceServer(grpcServer, apiv1Service)
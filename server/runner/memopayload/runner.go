@@ -30,6 +30,13 @@ func (r *Runner) RunOnce(ctx context.Context) {
 	offset := 0
 	processed := 0
 
+	memoRelatedSetting, err := r.Store.GetInstanceMemoRelatedSetting(ctx)
+	if err != nil {
+		slog.Error("failed to get instance memo related setting", "err", err)
+		return
+	}
+	markdownService := r.MarkdownService.WithTagNormalization(NewTagNormalization(memoRelatedSetting))
+
 	for {
 		limit := batchSize
 		memos, err := r.Store.ListMemos(ctx, &store.FindMemo{
@@ -49,7 +56,7 @@ func (r *Runner) RunOnce(ctx context.Context) {
 		// Process batch
 		batchSuccessCount := 0
 		for _, memo := range memos {
-			if err := RebuildMemoPayload(memo, r.MarkdownService); err != nil {
+			if err := RebuildMemoPayload(memo, markdownService); err != nil {
 				slog.Error("failed to rebuild memo payload", "err", err, "memoID", memo.ID)
 				continue
 			}
@@ -86,3 +93,11 @@ func RebuildMemoPayload(memo *store.Memo, markdownService markdown.Service) erro
 	memo.Payload.Property = data.Property
 	return nil
 }
+
+// NewTagNormalization returns the tag normalization configured by the instance memo related setting.
+func NewTagNormalization(setting *storepb.InstanceMemoRelatedSetting) markdown.TagNormalization {
+	return markdown.TagNormalization{
+		Locale:       setting.GetTagLocale(),
+		PreserveCase: setting.GetPreserveTagCase(),
+	}
+}
@@ -0,0 +1,183 @@
+package utf8sanitize
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// batchSize is the number of rows fetched per query.
+	batchSize = 100
+	// defaultQueriesPerSecond bounds the queries issued by a sweep so it doesn't hammer the database.
+	defaultQueriesPerSecond = 10
+)
+
+// Runner periodically repairs invalid UTF-8 in stored text, using the same
+// sanitization as the write path.
+type Runner struct {
+	Store    *store.Store
+	Interval time.Duration
+	Limiter  *rate.Limiter
+}
+
+// Summary is the outcome of a single sweep.
+type Summary struct {
+	MemosScanned       int
+	MemosFixed         int
+	AttachmentsScanned int
+	AttachmentsFixed   int
+	ActivitiesScanned  int
+	// ActivitiesInvalid counts activities with invalid text. Their type and level
+	// are written from constants, so they are reported but never rewritten.
+	ActivitiesInvalid int
+}
+
+func NewRunner(store *store.Store, interval time.Duration) *Runner {
+	return &Runner{
+		Store:    store,
+		Interval: interval,
+		Limiter:  rate.NewLimiter(defaultQueriesPerSecond, 1),
+	}
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RunOnce sweeps all memos, attachments and activities once and logs a summary.
+func (r *Runner) RunOnce(ctx context.Context) *Summary {
+	summary := &Summary{}
+	if err := r.sanitizeMemos(ctx, summary); err != nil {
+		slog.Error("failed to sanitize memos", "err", err)
+	}
+	if err := r.sanitizeAttachments(ctx, summary); err != nil {
+		slog.Error("failed to sanitize attachments", "err", err)
+	}
+	if err := r.checkActivities(ctx, summary); err != nil {
+		slog.Error("failed to check activities", "err", err)
+	}
+	slog.Info("UTF-8 sanitize sweep finished",
+		"memosScanned", summary.MemosScanned,
+		"memosFixed", summary.MemosFixed,
+		"attachmentsScanned", summary.AttachmentsScanned,
+		"attachmentsFixed", summary.AttachmentsFixed,
+		"activitiesScanned", summary.ActivitiesScanned,
+		"activitiesInvalid", summary.ActivitiesInvalid,
+	)
+	return summary
+}
+
+func (r *Runner) sanitizeMemos(ctx context.Context, summary *Summary) error {
+	offset := 0
+	for {
+		if err := r.Limiter.Wait(ctx); err != nil {
+			return err
+		}
+		limit := batchSize
+		memos, err := r.Store.ListMemos(ctx, &store.FindMemo{
+			Limit:  &limit,
+			Offset: &offset,
+		})
+		if err != nil {
+			return err
+		}
+		if len(memos) == 0 {
+			return nil
+		}
+
+		for _, memo := range memos {
+			summary.MemosScanned++
+			if util.IsValidUTF8(memo.Content) {
+				continue
+			}
+			if err := r.Limiter.Wait(ctx); err != nil {
+				return err
+			}
+			content := util.SanitizeUTF8(memo.Content)
+			if err := r.Store.UpdateMemo(ctx, &store.UpdateMemo{
+				ID:      memo.ID,
+				Content: &content,
+			}); err != nil {
+				slog.Error("failed to update memo", "err", err, "memoID", memo.ID)
+				continue
+			}
+			summary.MemosFixed++
+		}
+		offset += len(memos)
+	}
+}
+
+func (r *Runner) sanitizeAttachments(ctx context.Context, summary *Summary) error {
+	offset := 0
+	for {
+		if err := r.Limiter.Wait(ctx); err != nil {
+			return err
+		}
+		limit := batchSize
+		attachments, err := r.Store.ListAttachments(ctx, &store.FindAttachment{
+			GetBlob: false,
+			Limit:   &limit,
+			Offset:  &offset,
+		})
+		if err != nil {
+			return err
+		}
+		if len(attachments) == 0 {
+			return nil
+		}
+
+		for _, attachment := range attachments {
+			summary.AttachmentsScanned++
+			if util.IsValidUTF8(attachment.Filename) {
+				continue
+			}
+			if err := r.Limiter.Wait(ctx); err != nil {
+				return err
+			}
+			filename := util.SanitizeUTF8(attachment.Filename)
+			if err := r.Store.UpdateAttachment(ctx, &store.UpdateAttachment{
+				ID:       attachment.ID,
+				Filename: &filename,
+			}); err != nil {
+				slog.Error("failed to update attachment", "err", err, "attachmentID", attachment.ID)
+				continue
+			}
+			summary.AttachmentsFixed++
+		}
+		offset += len(attachments)
+	}
+}
+
+func (r *Runner) checkActivities(ctx context.Context, summary *Summary) error {
+	if err := r.Limiter.Wait(ctx); err != nil {
+		return err
+	}
+	activities, err := r.Store.ListActivities(ctx, &store.FindActivity{})
+	if err != nil {
+		return err
+	}
+	for _, activity := range activities {
+		summary.ActivitiesScanned++
+		if !util.IsValidUTF8(activity.Type.String()) || !util.IsValidUTF8(activity.Level.String()) {
+			summary.ActivitiesInvalid++
+			slog.Warn("found activity with invalid UTF-8", "activityID", activity.ID)
+		}
+	}
+	return nil
+}
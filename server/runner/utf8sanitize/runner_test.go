@@ -0,0 +1,70 @@
+package utf8sanitize
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestRunOnce(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "test",
+		Role:     store.RoleHost,
+		Email:    "test@test.com",
+	})
+	require.NoError(t, err)
+
+	badMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "bad-memo",
+		CreatorID:  user.ID,
+		Content:    "hello",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	goodMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "good-memo",
+		CreatorID:  user.ID,
+		Content:    "héllo wörld",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	// The store sanitizes memos it writes, so seed the invalid content directly.
+	_, err = ts.GetDriver().GetDB().ExecContext(ctx, "UPDATE memo SET content = ? WHERE id = ?", "hello \xff\xfe world", badMemo.ID)
+	require.NoError(t, err)
+	attachment, err := ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "bad-attachment",
+		CreatorID: user.ID,
+		Filename:  "report\xc3.txt",
+		Type:      "text/plain",
+	})
+	require.NoError(t, err)
+
+	runner := NewRunner(ts, time.Hour)
+	summary := runner.RunOnce(ctx)
+	require.Equal(t, 2, summary.MemosScanned)
+	require.Equal(t, 1, summary.MemosFixed)
+	require.Equal(t, 1, summary.AttachmentsScanned)
+	require.Equal(t, 1, summary.AttachmentsFixed)
+
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{ID: &badMemo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "hello �� world", memo.Content)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &goodMemo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "héllo wörld", memo.Content)
+	fixedAttachment, err := ts.GetAttachment(ctx, &store.FindAttachment{ID: &attachment.ID})
+	require.NoError(t, err)
+	require.Equal(t, "report�.txt", fixedAttachment.Filename)
+
+	// A second sweep finds nothing left to repair.
+	summary = runner.RunOnce(ctx)
+	require.Equal(t, 0, summary.MemosFixed)
+	require.Equal(t, 0, summary.AttachmentsFixed)
+}
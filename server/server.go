@@ -7,6 +7,7 @@ import (
 	"math"
 	"net"
 	"net/http"
+	"net/url"
 	"runtime"
 	"runtime/debug"
 	"time"
@@ -21,10 +22,12 @@ import (
 
 	"github.com/usememos/memos/internal/profile"
 	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/ai"
 	apiv1 "github.com/usememos/memos/server/router/api/v1"
 	"github.com/usememos/memos/server/router/frontend"
 	"github.com/usememos/memos/server/router/rss"
 	"github.com/usememos/memos/server/runner/s3presign"
+	"github.com/usememos/memos/server/runner/utf8sanitize"
 	"github.com/usememos/memos/store"
 )
 
@@ -75,17 +78,28 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	// Log full stacktraces if we're in dev
 	logStacktraces := profile.IsDev()
 
+	loggerInterceptor := apiv1.NewLoggerInterceptor(logStacktraces)
+	authInterceptor := apiv1.NewGRPCAuthInterceptor(store, secret)
 	grpcServer := grpc.NewServer(
 		// Override the maximum receiving message size to math.MaxInt32 for uploading large attachments.
 		grpc.MaxRecvMsgSize(math.MaxInt32),
 		grpc.ChainUnaryInterceptor(
-			apiv1.NewLoggerInterceptor(logStacktraces).LoggerInterceptor,
+			loggerInterceptor.LoggerInterceptor,
 			newRecoveryInterceptor(logStacktraces),
-			apiv1.NewGRPCAuthInterceptor(store, secret).AuthenticationInterceptor,
+			authInterceptor.AuthenticationInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			loggerInterceptor.LoggerStreamInterceptor,
+			newStreamRecoveryInterceptor(logStacktraces),
+			authInterceptor.AuthenticationStreamInterceptor,
 		))
 	s.grpcServer = grpcServer
 
 	apiV1Service := apiv1.NewAPIV1Service(s.Secret, profile, store, grpcServer)
+	s.logAIServiceURL(ctx)
+	if err := apiV1Service.ResumeMemoIndexDeletions(ctx); err != nil {
+		slog.Warn("failed to resume pending memo index deletions", slog.String("error", err.Error()))
+	}
 
 	// Create and register RSS routes (needs markdown service from apiV1Service).
 	rss.NewRSSService(s.Profile, s.Store, apiV1Service.MarkdownService).RegisterRoutes(rootGroup)
@@ -97,7 +111,36 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	return s, nil
 }
 
+// logAIServiceURL logs the host of the AI service URL in effect, and warns if the URL is rejected
+// by ai.ValidateServiceURL, in which case AI features fail until it's fixed.
+func (s *Server) logAIServiceURL(ctx context.Context) {
+	aiSetting, err := s.Store.GetInstanceAiSetting(ctx)
+	if err != nil {
+		slog.Warn("failed to get AI setting", slog.String("error", err.Error()))
+		return
+	}
+	serviceURL := ai.ResolveServiceURL(aiSetting.AiServiceUrl)
+	host := serviceURL
+	if u, err := url.Parse(serviceURL); err == nil {
+		host = u.Host
+	}
+	if err := ai.ValidateServiceURL(serviceURL); err != nil {
+		slog.Warn("AI service URL is unsafe", slog.String("host", host), slog.String("error", err.Error()),
+			slog.Bool("allowed", ai.AllowUnsafeServiceURL()))
+		return
+	}
+	slog.Info("AI service configured", slog.String("host", host))
+}
+
 func newRecoveryInterceptor(logStacktraces bool) grpc.UnaryServerInterceptor {
+	return grpcrecovery.UnaryServerInterceptor(newRecoveryOptions(logStacktraces)...)
+}
+
+func newStreamRecoveryInterceptor(logStacktraces bool) grpc.StreamServerInterceptor {
+	return grpcrecovery.StreamServerInterceptor(newRecoveryOptions(logStacktraces)...)
+}
+
+func newRecoveryOptions(logStacktraces bool) []grpcrecovery.Option {
 	var recoveryOptions []grpcrecovery.Option
 	if logStacktraces {
 		recoveryOptions = append(recoveryOptions, grpcrecovery.WithRecoveryHandler(func(p any) error {
@@ -114,7 +157,7 @@ func newRecoveryInterceptor(logStacktraces bool) grpc.UnaryServerInterceptor {
 		}))
 	}
 
-	return grpcrecovery.UnaryServerInterceptor(recoveryOptions...)
+	return recoveryOptions
 }
 
 func (s *Server) Start(ctx context.Context) error {
@@ -202,6 +245,18 @@ func (s *Server) StartBackgroundRunners(ctx context.Context) {
 		slog.Info("s3presign runner stopped")
 	}()
 
+	// Start the UTF-8 sanitize runner if it is enabled
+	if s.Profile.UTF8SanitizeInterval > 0 {
+		utf8Context, utf8Cancel := context.WithCancel(ctx)
+		s.runnerCancelFuncs = append(s.runnerCancelFuncs, utf8Cancel)
+
+		utf8sanitizeRunner := utf8sanitize.NewRunner(s.Store, s.Profile.UTF8SanitizeInterval)
+		go func() {
+			utf8sanitizeRunner.Run(utf8Context)
+			slog.Info("utf8sanitize runner stopped")
+		}()
+	}
+
 	// Log the number of goroutines running
 	slog.Info("background runners started", "goroutines", runtime.NumGoroutine())
 }
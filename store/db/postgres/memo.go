@@ -242,3 +242,53 @@ func (d *DB) DeleteMemo(ctx context.Context, delete *store.DeleteMemo) error {
 	}
 	return nil
 }
+
+func (d *DB) ListMemoTags(ctx context.Context, find *store.FindMemoTag) ([]*store.MemoTag, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.CreatorID; v != nil {
+		where, args = append(where, "memo.creator_id = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.RowStatus; v != nil {
+		where, args = append(where, "memo.row_status = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.VisibilityList; len(v) != 0 {
+		holders := []string{}
+		for _, visibility := range v {
+			holders = append(holders, placeholder(len(args)+1))
+			args = append(args, visibility.String())
+		}
+		where = append(where, fmt.Sprintf("memo.visibility in (%s)", strings.Join(holders, ", ")))
+	}
+	if find.ExcludeComments {
+		where = append(where, `NOT EXISTS (SELECT 1 FROM memo_relation
+			JOIN memo AS parent_memo ON memo_relation.related_memo_id = parent_memo.id
+			WHERE memo_relation.memo_id = memo.id AND memo_relation.type = 'COMMENT')`)
+	}
+
+	// Each key of the payload holding tags is unnested separately, flagging which list a tag comes from.
+	// Both halves share the placeholders, so the arguments are passed once.
+	selectTags := func(key string, manual int) string {
+		return fmt.Sprintf(`SELECT jsonb_array_elements_text(memo.payload->'%s') AS tag, %d AS manual FROM memo WHERE `, key, manual) + strings.Join(where, " AND ")
+	}
+	query := `SELECT tag, SUM(manual), COUNT(*) - SUM(manual) FROM (` + selectTags("tags", 1) + ` UNION ALL ` + selectTags("aiTags", 0) + `) AS memo_tag GROUP BY tag`
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.MemoTag, 0)
+	for rows.Next() {
+		var memoTag store.MemoTag
+		if err := rows.Scan(&memoTag.Tag, &memoTag.ManualCount, &memoTag.AiCount); err != nil {
+			return nil, err
+		}
+		list = append(list, &memoTag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
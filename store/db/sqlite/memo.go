@@ -233,3 +233,53 @@ func (d *DB) DeleteMemo(ctx context.Context, delete *store.DeleteMemo) error {
 	}
 	return nil
 }
+
+func (d *DB) ListMemoTags(ctx context.Context, find *store.FindMemoTag) ([]*store.MemoTag, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.CreatorID; v != nil {
+		where, args = append(where, "`memo`.`creator_id` = ?"), append(args, *v)
+	}
+	if v := find.RowStatus; v != nil {
+		where, args = append(where, "`memo`.`row_status` = ?"), append(args, *v)
+	}
+	if v := find.VisibilityList; len(v) != 0 {
+		placeholder := []string{}
+		for _, visibility := range v {
+			placeholder = append(placeholder, "?")
+			args = append(args, visibility.String())
+		}
+		where = append(where, fmt.Sprintf("`memo`.`visibility` IN (%s)", strings.Join(placeholder, ",")))
+	}
+	if find.ExcludeComments {
+		where = append(where, "NOT EXISTS (SELECT 1 FROM `memo_relation` "+
+			"JOIN `memo` AS `parent_memo` ON `memo_relation`.`related_memo_id` = `parent_memo`.`id` "+
+			"WHERE `memo_relation`.`memo_id` = `memo`.`id` AND `memo_relation`.`type` = 'COMMENT')")
+	}
+
+	// Each path of the payload holding tags is unnested separately, flagging which list a tag comes from.
+	selectTags := func(path string, manual int) string {
+		return fmt.Sprintf("SELECT `json_tag`.`value` AS `tag`, %d AS `manual` FROM `memo`, json_each(`memo`.`payload`, '%s') AS `json_tag` ", manual, path) +
+			"WHERE " + strings.Join(where, " AND ")
+	}
+	query := "SELECT `tag`, SUM(`manual`), COUNT(*) - SUM(`manual`) FROM (" + selectTags("$.tags", 1) + " UNION ALL " + selectTags("$.aiTags", 0) + ") GROUP BY `tag`"
+	rows, err := d.db.QueryContext(ctx, query, append(append([]any{}, args...), args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.MemoTag, 0)
+	for rows.Next() {
+		var memoTag store.MemoTag
+		if err := rows.Scan(&memoTag.Tag, &memoTag.ManualCount, &memoTag.AiCount); err != nil {
+			return nil, err
+		}
+		list = append(list, &memoTag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
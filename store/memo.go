@@ -3,8 +3,11 @@ package store
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 
 	"github.com/usememos/memos/internal/base"
+	"github.com/usememos/memos/internal/util"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
@@ -106,10 +109,32 @@ type DeleteMemo struct {
 	ID int32
 }
 
+// MemoTag is a tag and the number of memos carrying it.
+type MemoTag struct {
+	Tag string
+	// Count is the number of times memos carry the tag, i.e. ManualCount + AiCount.
+	Count int
+	// ManualCount is the number of memos carrying the tag in their tags.
+	ManualCount int
+	// AiCount is the number of memos carrying the tag in their AI tags.
+	AiCount int
+}
+
+type FindMemoTag struct {
+	RowStatus       *RowStatus
+	CreatorID       *int32
+	VisibilityList  []Visibility
+	ExcludeComments bool
+	// MinCount skips the tags carried fewer times than it, if positive.
+	MinCount int
+}
+
 func (s *Store) CreateMemo(ctx context.Context, create *Memo) (*Memo, error) {
 	if !base.UIDMatcher.MatchString(create.UID) {
 		return nil, errors.New("invalid uid")
 	}
+	create.Content = util.SanitizeUTF8(create.Content)
+	sanitizeMemoPayload(create.Payload)
 	return s.driver.CreateMemo(ctx, create)
 }
 
@@ -134,9 +159,62 @@ func (s *Store) UpdateMemo(ctx context.Context, update *UpdateMemo) error {
 	if update.UID != nil && !base.UIDMatcher.MatchString(*update.UID) {
 		return errors.New("invalid uid")
 	}
+	if update.Content != nil {
+		content := util.SanitizeUTF8(*update.Content)
+		update.Content = &content
+	}
+	sanitizeMemoPayload(update.Payload)
 	return s.driver.UpdateMemo(ctx, update)
 }
 
+// sanitizeMemoPayload replaces invalid UTF-8 in the user-provided text of payload.
+func sanitizeMemoPayload(payload *storepb.MemoPayload) {
+	if payload == nil {
+		return
+	}
+	for i, tag := range payload.Tags {
+		payload.Tags[i] = util.SanitizeUTF8(tag)
+	}
+	for i, tag := range payload.AiTags {
+		payload.AiTags[i] = util.SanitizeUTF8(tag)
+	}
+	if payload.Location != nil {
+		payload.Location.Placeholder = util.SanitizeUTF8(payload.Location.Placeholder)
+	}
+}
+
 func (s *Store) DeleteMemo(ctx context.Context, delete *DeleteMemo) error {
 	return s.driver.DeleteMemo(ctx, delete)
 }
+
+// ListMemoTags returns the distinct manual and AI tags of the memos matching find, sorted by tag.
+// Tags are aggregated by the database from the memo payloads, so it's much cheaper than listing the
+// memos. A memo carrying a tag both as a manual and an AI tag counts twice.
+func (s *Store) ListMemoTags(ctx context.Context, find *FindMemoTag) ([]*MemoTag, error) {
+	list, err := s.driver.ListMemoTags(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	for _, memoTag := range list {
+		memoTag.Count = memoTag.ManualCount + memoTag.AiCount
+	}
+	if find.MinCount > 0 {
+		list = slices.DeleteFunc(list, func(memoTag *MemoTag) bool {
+			return memoTag.Count < find.MinCount
+		})
+	}
+	slices.SortFunc(list, func(a, b *MemoTag) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return list, nil
+}
+
+// ListUserTags returns the tags of the normal memos of a user, excluding comments. See ListMemoTags.
+func (s *Store) ListUserTags(ctx context.Context, creatorID int32) ([]*MemoTag, error) {
+	normalStatus := Normal
+	return s.ListMemoTags(ctx, &FindMemoTag{
+		RowStatus:       &normalStatus,
+		CreatorID:       &creatorID,
+		ExcludeComments: true,
+	})
+}
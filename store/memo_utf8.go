@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/util"
+)
+
+// sanitizeMemoUTF8BatchSize is the number of memos repaired in a single transaction.
+const sanitizeMemoUTF8BatchSize = 100
+
+// SanitizeMemoUTF8Result counts the memos scanned and repaired by SanitizeMemoUTF8.
+type SanitizeMemoUTF8Result struct {
+	Scanned int
+	Invalid int
+	Fixed   int
+}
+
+// SanitizeMemoUTF8 replaces invalid UTF-8 in the content and payload of all memos with
+// util.SanitizeUTF8. It reads the raw columns, so memos whose payload can no longer be
+// decoded are repaired too. Each batch is updated in its own transaction.
+// With dryRun, invalid memos are counted but left unchanged.
+func (s *Store) SanitizeMemoUTF8(ctx context.Context, dryRun bool) (*SanitizeMemoUTF8Result, error) {
+	result := &SanitizeMemoUTF8Result{}
+	db := s.driver.GetDB()
+	query := fmt.Sprintf("SELECT id, content, payload FROM memo WHERE id > %s ORDER BY id LIMIT %s", s.placeholder(1), s.placeholder(2))
+	update := fmt.Sprintf("UPDATE memo SET content = %s, payload = %s WHERE id = %s", s.placeholder(1), s.placeholder(2), s.placeholder(3))
+
+	type invalidMemo struct {
+		id      int32
+		content string
+		payload string
+	}
+	lastID := int32(0)
+	for {
+		rows, err := db.QueryContext(ctx, query, lastID, sanitizeMemoUTF8BatchSize)
+		if err != nil {
+			return result, errors.Wrap(err, "failed to list memos")
+		}
+		count := 0
+		invalidMemos := []invalidMemo{}
+		for rows.Next() {
+			memo := invalidMemo{}
+			if err := rows.Scan(&memo.id, &memo.content, &memo.payload); err != nil {
+				rows.Close()
+				return result, errors.Wrap(err, "failed to scan memo")
+			}
+			count++
+			lastID = memo.id
+			if !util.IsValidUTF8(memo.content) || !util.IsValidUTF8(memo.payload) {
+				invalidMemos = append(invalidMemos, memo)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return result, errors.Wrap(err, "failed to list memos")
+		}
+		rows.Close()
+
+		result.Scanned += count
+		result.Invalid += len(invalidMemos)
+		if !dryRun && len(invalidMemos) > 0 {
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return result, errors.Wrap(err, "failed to start transaction")
+			}
+			for _, memo := range invalidMemos {
+				if _, err := tx.ExecContext(ctx, update, util.SanitizeUTF8(memo.content), util.SanitizeUTF8(memo.payload), memo.id); err != nil {
+					tx.Rollback()
+					return result, errors.Wrapf(err, "failed to update memo %d", memo.id)
+				}
+			}
+			if err := tx.Commit(); err != nil {
+				return result, errors.Wrap(err, "failed to commit transaction")
+			}
+			result.Fixed += len(invalidMemos)
+		}
+
+		if count < sanitizeMemoUTF8BatchSize {
+			return result, nil
+		}
+	}
+}
+
+// placeholder returns the n-th query placeholder of the database driver.
+func (s *Store) placeholder(n int) string {
+	if s.profile.Driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
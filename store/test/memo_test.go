@@ -2,6 +2,7 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -94,6 +95,162 @@ func TestMemoListByTags(t *testing.T) {
 	ts.Close()
 }
 
+func TestListUserTags(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	otherUser, err := ts.CreateUser(ctx, &store.User{
+		Username: "other",
+		Role:     store.RoleUser,
+		Email:    "other@test.com",
+	})
+	require.NoError(t, err)
+
+	createMemo := func(uid string, creatorID int32, payload *storepb.MemoPayload) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        uid,
+			CreatorID:  creatorID,
+			Content:    "test_content",
+			Visibility: store.Public,
+			Payload:    payload,
+		})
+		require.NoError(t, err)
+		return memo
+	}
+	createMemo("memo-1", user.ID, &storepb.MemoPayload{Tags: []string{"work", "go"}, AiTags: []string{"Go"}})
+	createMemo("memo-2", user.ID, &storepb.MemoPayload{Tags: []string{"work"}, AiTags: []string{"work"}})
+	createMemo("memo-3", user.ID, nil)
+	privateMemo := createMemo("memo-private", user.ID, &storepb.MemoPayload{Tags: []string{"private"}})
+	privateVisibility := store.Private
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: privateMemo.ID, Visibility: &privateVisibility}))
+	archivedMemo := createMemo("memo-archived", user.ID, &storepb.MemoPayload{Tags: []string{"archived"}})
+	archivedStatus := store.Archived
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: archivedMemo.ID, RowStatus: &archivedStatus}))
+	commentMemo := createMemo("memo-comment", user.ID, &storepb.MemoPayload{Tags: []string{"comment"}})
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        commentMemo.ID,
+		RelatedMemoID: archivedMemo.ID,
+		Type:          store.MemoRelationComment,
+	})
+	require.NoError(t, err)
+	createMemo("memo-other", otherUser.ID, &storepb.MemoPayload{Tags: []string{"other"}})
+
+	tags, err := ts.ListUserTags(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*store.MemoTag{
+		{Tag: "Go", Count: 1, AiCount: 1},
+		{Tag: "go", Count: 1, ManualCount: 1},
+		{Tag: "private", Count: 1, ManualCount: 1},
+		{Tag: "work", Count: 3, ManualCount: 2, AiCount: 1},
+	}, tags)
+
+	tags, err = ts.ListMemoTags(ctx, &store.FindMemoTag{CreatorID: &user.ID, MinCount: 2})
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, "work", tags[0].Tag)
+
+	tags, err = ts.ListMemoTags(ctx, &store.FindMemoTag{CreatorID: &user.ID, VisibilityList: []store.Visibility{store.Private}})
+	require.NoError(t, err)
+	require.Equal(t, []*store.MemoTag{{Tag: "private", Count: 1, ManualCount: 1}}, tags)
+
+	tags, err = ts.ListUserTags(ctx, otherUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*store.MemoTag{{Tag: "other", Count: 1, ManualCount: 1}}, tags)
+	ts.Close()
+}
+
+// BenchmarkListUserTags compares aggregating the tags of a user in the database against listing
+// all memos of the user and counting their tags.
+func BenchmarkListUserTags(b *testing.B) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, b)
+	defer ts.Close()
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(b, err)
+	for i := 0; i < 2000; i++ {
+		_, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    fmt.Sprintf("test content of memo %d", i),
+			Visibility: store.Private,
+			Payload: &storepb.MemoPayload{
+				Tags:   []string{fmt.Sprintf("tag-%d", i%50), fmt.Sprintf("project/%d", i%7)},
+				AiTags: []string{fmt.Sprintf("topic-%d", i%100)},
+			},
+		})
+		require.NoError(b, err)
+	}
+
+	b.Run("ListUserTags", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ts.ListUserTags(ctx, user.ID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("ListMemos", func(b *testing.B) {
+		normalStatus := store.Normal
+		for i := 0; i < b.N; i++ {
+			memos, err := ts.ListMemos(ctx, &store.FindMemo{
+				CreatorID:       &user.ID,
+				RowStatus:       &normalStatus,
+				ExcludeComments: true,
+				ExcludeContent:  true,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			tagCounts := make(map[string]int)
+			for _, memo := range memos {
+				for _, tag := range memo.Payload.Tags {
+					tagCounts[tag]++
+				}
+				for _, tag := range memo.Payload.AiTags {
+					tagCounts[tag]++
+				}
+			}
+		}
+	})
+}
+
+func TestMemoStoreSanitizesUTF8(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-resource-name",
+		CreatorID:  user.ID,
+		Content:    "hello \xff world",
+		Visibility: store.Public,
+		Payload: &storepb.MemoPayload{
+			Tags:   []string{"t\xc3"},
+			AiTags: []string{"ai\xff"},
+		},
+	})
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "hello \uFFFD world", memo.Content)
+	require.Equal(t, []string{"t\uFFFD"}, memo.Payload.Tags)
+	require.Equal(t, []string{"ai\uFFFD"}, memo.Payload.AiTags)
+
+	content := "updated \xfe"
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:      memo.ID,
+		Content: &content,
+		Payload: &storepb.MemoPayload{Tags: []string{"\xfftag"}},
+	})
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "updated \uFFFD", memo.Content)
+	require.Equal(t, []string{"\uFFFDtag"}, memo.Payload.Tags)
+	ts.Close()
+}
+
 func TestDeleteMemoStore(t *testing.T) {
 	ctx := context.Background()
 	ts := NewTestingStore(ctx, t)
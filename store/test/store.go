@@ -19,7 +19,7 @@ import (
 	"github.com/usememos/memos/store/db"
 )
 
-func NewTestingStore(ctx context.Context, t *testing.T) *store.Store {
+func NewTestingStore(ctx context.Context, t testing.TB) *store.Store {
 	profile := getTestingProfile(t)
 	dbDriver, err := db.NewDBDriver(profile)
 	if err != nil {
@@ -91,7 +91,7 @@ func getUnusedPort() int {
 	return port
 }
 
-func getTestingProfile(t *testing.T) *profile.Profile {
+func getTestingProfile(t testing.TB) *profile.Profile {
 	if err := godotenv.Load(".env"); err != nil {
 		t.Log("failed to load .env file, but it's ok")
 	}
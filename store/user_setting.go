@@ -349,6 +349,81 @@ func (s *Store) UpdateUserWebhook(ctx context.Context, userID int32, webhook *st
 	return err
 }
 
+// GetUserAiIndexDeletions returns the AI index deletions requested by the user that are waiting for their grace period.
+func (s *Store) GetUserAiIndexDeletions(ctx context.Context, userID int32) ([]*storepb.AiIndexDeletionsUserSetting_Deletion, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSetting_AI_INDEX_DELETIONS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return []*storepb.AiIndexDeletionsUserSetting_Deletion{}, nil
+	}
+
+	aiIndexDeletionsUserSetting := userSetting.GetAiIndexDeletions()
+	return aiIndexDeletionsUserSetting.Deletions, nil
+}
+
+// AddUserAiIndexDeletion adds a pending AI index deletion for the user, replacing any of the same memo.
+func (s *Store) AddUserAiIndexDeletion(ctx context.Context, userID int32, deletion *storepb.AiIndexDeletionsUserSetting_Deletion) error {
+	existingDeletions, err := s.GetUserAiIndexDeletions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	updatedDeletions := make([]*storepb.AiIndexDeletionsUserSetting_Deletion, 0, len(existingDeletions)+1)
+	for _, existing := range existingDeletions {
+		if existing.MemoId != deletion.MemoId {
+			updatedDeletions = append(updatedDeletions, existing)
+		}
+	}
+	updatedDeletions = append(updatedDeletions, deletion)
+
+	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSetting_AI_INDEX_DELETIONS,
+		Value: &storepb.UserSetting_AiIndexDeletions{
+			AiIndexDeletions: &storepb.AiIndexDeletionsUserSetting{
+				Deletions: updatedDeletions,
+			},
+		},
+	})
+
+	return err
+}
+
+// RemoveUserAiIndexDeletion removes the pending AI index deletion of a memo for the user.
+func (s *Store) RemoveUserAiIndexDeletion(ctx context.Context, userID int32, memoID string) error {
+	oldDeletions, err := s.GetUserAiIndexDeletions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	newDeletions := make([]*storepb.AiIndexDeletionsUserSetting_Deletion, 0, len(oldDeletions))
+	for _, deletion := range oldDeletions {
+		if memoID != deletion.MemoId {
+			newDeletions = append(newDeletions, deletion)
+		}
+	}
+	if len(newDeletions) == len(oldDeletions) {
+		return nil
+	}
+
+	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSetting_AI_INDEX_DELETIONS,
+		Value: &storepb.UserSetting_AiIndexDeletions{
+			AiIndexDeletions: &storepb.AiIndexDeletionsUserSetting{
+				Deletions: newDeletions,
+			},
+		},
+	})
+
+	return err
+}
+
 func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
 	userSetting := &storepb.UserSetting{
 		UserId: raw.UserID,
@@ -386,6 +461,12 @@ func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
 			return nil, err
 		}
 		userSetting.Value = &storepb.UserSetting_Webhooks{Webhooks: webhooksUserSetting}
+	case storepb.UserSetting_AI_INDEX_DELETIONS:
+		aiIndexDeletionsUserSetting := &storepb.AiIndexDeletionsUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), aiIndexDeletionsUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_AiIndexDeletions{AiIndexDeletions: aiIndexDeletionsUserSetting}
 	default:
 		return nil, nil
 	}
@@ -434,6 +515,13 @@ func convertUserSettingToRaw(userSetting *storepb.UserSetting) (*UserSetting, er
 			return nil, err
 		}
 		raw.Value = string(value)
+	case storepb.UserSetting_AI_INDEX_DELETIONS:
+		aiIndexDeletionsUserSetting := userSetting.GetAiIndexDeletions()
+		value, err := protojson.Marshal(aiIndexDeletionsUserSetting)
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
 	default:
 		return nil, errors.Errorf("unsupported user setting key: %v", userSetting.Key)
 	}